@@ -8,6 +8,7 @@ require (
 	github.com/Azure/go-autorest/autorest/date v0.3.0
 	github.com/google/uuid v1.6.0
 	github.com/microsoft/moc v0.20.4
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240820151423-278611b39280
 	google.golang.org/grpc v1.62.1
 	k8s.io/klog v1.0.0
 )
@@ -37,7 +38,6 @@ require (
 	golang.org/x/crypto v0.21.0 // indirect
 	golang.org/x/net v0.28.0 // indirect
 	golang.org/x/text v0.17.0 // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20240820151423-278611b39280 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,145 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package authorizer
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/microsoft/moc/pkg/auth"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// Environment variables holding the credentials, either PEM encoded or base64 encoded PEM
+const (
+	ServerCertificateEnv = "MOC_SERVER_CERTIFICATE"
+	ClientCertificateEnv = "MOC_CLIENT_CERTIFICATE"
+	ClientKeyEnv         = "MOC_CLIENT_KEY"
+)
+
+// PEMBundle holds the credentials used to authenticate with the cloud agent
+type PEMBundle struct {
+	// ServerCertificate - PEM encoded certificate of the cloud agent, or of the CA that issued it
+	ServerCertificate []byte
+	// ClientCertificate - PEM encoded client certificate
+	ClientCertificate []byte
+	// ClientKey - PEM encoded private key of the client certificate
+	ClientKey []byte
+	// ServerName - Name the server certificate must be issued to, empty to use the host of the agent address
+	ServerName string
+}
+
+type pemAuthorizer struct {
+	transport credentials.TransportCredentials
+}
+
+// NewAuthorizerFromPEM returns an authorizer using the in-memory credentials, without writing them to disk
+func NewAuthorizerFromPEM(bundle PEMBundle) (auth.Authorizer, error) {
+	if len(bundle.ServerCertificate) == 0 {
+		return nil, errors.Wrapf(errors.InvalidInput, "Server certificate not specified")
+	}
+	if len(bundle.ClientCertificate) == 0 || len(bundle.ClientKey) == 0 {
+		return nil, errors.Wrapf(errors.InvalidInput, "Client certificate and key not specified")
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle.ServerCertificate) {
+		return nil, errors.Wrapf(errors.InvalidInput, "Server certificate is not a valid PEM encoded certificate")
+	}
+	clientCertificate, err := tls.X509KeyPair(bundle.ClientCertificate, bundle.ClientKey)
+	if err != nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "Invalid client certificate or key: %v", err)
+	}
+
+	return &pemAuthorizer{
+		transport: credentials.NewTLS(&tls.Config{
+			Certificates: []tls.Certificate{clientCertificate},
+			RootCAs:      pool,
+			ServerName:   getHost(bundle.ServerName),
+			MinVersion:   tls.VersionTLS12,
+		}),
+	}, nil
+}
+
+// NewAuthorizerFromEnvironmentVariables returns an authorizer using the credentials held in the
+// MOC_SERVER_CERTIFICATE, MOC_CLIENT_CERTIFICATE and MOC_CLIENT_KEY environment variables. When none of
+// them is set, it falls back to the configuration file used by auth.NewAuthorizerFromEnvironment. The server
+// certificate must be issued to serverName, or to the host of the agent address if serverName is empty.
+func NewAuthorizerFromEnvironmentVariables(serverName string) (auth.Authorizer, error) {
+	serverCertificate, hasServer := os.LookupEnv(ServerCertificateEnv)
+	clientCertificate, hasClient := os.LookupEnv(ClientCertificateEnv)
+	clientKey, hasKey := os.LookupEnv(ClientKeyEnv)
+	if !hasServer && !hasClient && !hasKey {
+		return auth.NewAuthorizerFromEnvironment(serverName)
+	}
+
+	bundle := PEMBundle{ServerName: serverName}
+	var err error
+	if bundle.ServerCertificate, err = decodePEM(ServerCertificateEnv, serverCertificate); err != nil {
+		return nil, err
+	}
+	if bundle.ClientCertificate, err = decodePEM(ClientCertificateEnv, clientCertificate); err != nil {
+		return nil, err
+	}
+	if bundle.ClientKey, err = decodePEM(ClientKeyEnv, clientKey); err != nil {
+		return nil, err
+	}
+	return NewAuthorizerFromPEM(bundle)
+}
+
+// WithTransportAuthorization returns the TLS credentials presenting the client certificate
+func (a *pemAuthorizer) WithTransportAuthorization() credentials.TransportCredentials {
+	return a.transport
+}
+
+// WithRPCAuthorization returns per RPC credentials. The client certificate authenticates the
+// connection, so no token is added to the requests.
+func (a *pemAuthorizer) WithRPCAuthorization() credentials.PerRPCCredentials {
+	return emptyRPCCredentials{}
+}
+
+type emptyRPCCredentials struct{}
+
+func (emptyRPCCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (emptyRPCCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// getHost returns the host of an address that may include a port
+func getHost(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return address
+}
+
+// decodePEM accepts a PEM encoded value, or a base64 encoded PEM value as stored in the moc configuration file
+func decodePEM(name, value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return nil, errors.Wrapf(errors.InvalidInput, "Environment variable %s not set", name)
+	}
+	if block, _ := pem.Decode([]byte(value)); block != nil {
+		return []byte(value), nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "Environment variable %s is neither PEM nor base64 encoded PEM", name)
+	}
+	if block, _ := pem.Decode(decoded); block == nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "Environment variable %s does not hold a PEM encoded value", name)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package authorizer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateTestCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func Test_NewAuthorizerFromPEM(t *testing.T) {
+	certificate, key := generateTestCertificate(t)
+
+	authorizer, err := NewAuthorizerFromPEM(PEMBundle{ServerCertificate: certificate, ClientCertificate: certificate, ClientKey: key})
+	assert.Nil(t, err)
+	assert.NotNil(t, authorizer.WithTransportAuthorization())
+	assert.NotNil(t, authorizer.WithRPCAuthorization())
+	assert.Equal(t, "", authorizer.WithTransportAuthorization().Info().ServerName)
+
+	authorizer, err = NewAuthorizerFromPEM(PEMBundle{ServerCertificate: certificate, ClientCertificate: certificate, ClientKey: key, ServerName: "agent.contoso.com"})
+	assert.Nil(t, err)
+	assert.Equal(t, "agent.contoso.com", authorizer.WithTransportAuthorization().Info().ServerName)
+
+	_, err = NewAuthorizerFromPEM(PEMBundle{ServerCertificate: certificate, ClientCertificate: certificate})
+	assert.True(t, errors.IsInvalidInput(err))
+	_, err = NewAuthorizerFromPEM(PEMBundle{ServerCertificate: []byte("invalid"), ClientCertificate: certificate, ClientKey: key})
+	assert.True(t, errors.IsInvalidInput(err))
+}
+
+func Test_NewAuthorizerFromEnvironmentVariables(t *testing.T) {
+	certificate, key := generateTestCertificate(t)
+	t.Setenv(ServerCertificateEnv, string(certificate))
+	t.Setenv(ClientCertificateEnv, base64.StdEncoding.EncodeToString(certificate))
+	t.Setenv(ClientKeyEnv, base64.StdEncoding.EncodeToString(key))
+
+	authorizer, err := NewAuthorizerFromEnvironmentVariables("localhost:55000")
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost", authorizer.WithTransportAuthorization().Info().ServerName)
+
+	t.Setenv(ClientKeyEnv, "not a key")
+	_, err = NewAuthorizerFromEnvironmentVariables("localhost")
+	assert.True(t, errors.IsInvalidInput(err))
+}
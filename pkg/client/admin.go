@@ -4,8 +4,6 @@
 package client
 
 import (
-	log "k8s.io/klog"
-
 	"github.com/microsoft/moc/pkg/auth"
 	admin_pb "github.com/microsoft/moc/rpc/cloudagent/admin"
 	cadmin_pb "github.com/microsoft/moc/rpc/common/admin"
@@ -15,7 +13,7 @@ import (
 func GetLogClient(serverAddress *string, authorizer auth.Authorizer) (admin_pb.LogAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get LogClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return admin_pb.NewLogAgentClient(conn), nil
@@ -25,7 +23,7 @@ func GetLogClient(serverAddress *string, authorizer auth.Authorizer) (admin_pb.L
 func GetRecoveryClient(serverAddress *string, authorizer auth.Authorizer) (cadmin_pb.RecoveryAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get RecoveryClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cadmin_pb.NewRecoveryAgentClient(conn), nil
@@ -35,7 +33,7 @@ func GetRecoveryClient(serverAddress *string, authorizer auth.Authorizer) (cadmi
 func GetDebugClient(serverAddress *string, authorizer auth.Authorizer) (cadmin_pb.DebugAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get DebugClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cadmin_pb.NewDebugAgentClient(conn), nil
@@ -45,7 +43,7 @@ func GetDebugClient(serverAddress *string, authorizer auth.Authorizer) (cadmin_p
 func GetVersionClient(serverAddress *string, authorizer auth.Authorizer) (cadmin_pb.VersionAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get VersionClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cadmin_pb.NewVersionAgentClient(conn), nil
@@ -55,7 +53,7 @@ func GetVersionClient(serverAddress *string, authorizer auth.Authorizer) (cadmin
 func GetValidationClient(serverAddress *string, authorizer auth.Authorizer) (cadmin_pb.ValidationAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get ValidationClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cadmin_pb.NewValidationAgentClient(conn), nil
@@ -65,7 +63,7 @@ func GetValidationClient(serverAddress *string, authorizer auth.Authorizer) (cad
 func GetHealthClient(serverAddress *string, authorizer auth.Authorizer) (cadmin_pb.HealthAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get HealthClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cadmin_pb.NewHealthAgentClient(conn), nil
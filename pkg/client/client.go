@@ -14,7 +14,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/keepalive"
-	log "k8s.io/klog"
 
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -61,6 +60,9 @@ func getAuthServerEndpoint(serverAddress *string) string {
 	return fmt.Sprintf("%s:%d", *serverAddress, AuthPort)
 }
 
+// unaryInterceptors are run on every call to the agent, the first one outermost
+var unaryInterceptors = []grpc.UnaryClientInterceptor{dryRunUnaryInterceptor, telemetryUnaryInterceptor, sanitizeUnaryInterceptor, statsUnaryInterceptor, requestSizeUnaryInterceptor, hedgeUnaryInterceptor, throttleRetryUnaryInterceptor}
+
 func getDefaultDialOption(authorizer auth.Authorizer) []grpc.DialOption {
 	var opts []grpc.DialOption
 
@@ -81,6 +83,7 @@ func getDefaultDialOption(authorizer auth.Authorizer) []grpc.DialOption {
 			Timeout:             20 * time.Second,
 			PermitWithoutStream: true,
 		}))
+	opts = append(opts, grpc.WithChainUnaryInterceptor(unaryInterceptors...))
 
 	return opts
 }
@@ -97,28 +100,51 @@ func isValidConnections(conn *grpc.ClientConn) bool {
 	}
 }
 
+// getClientConnection returns the cached connection to the agent, dialing a new one if there is none or it failed.
+// The dial and warm-up run without holding the lock, so a slow or unreachable agent does not block the clients
+// of other agents.
 func getClientConnection(serverAddress *string, authorizer auth.Authorizer) (*grpc.ClientConn, error) {
-	mux.Lock()
-	defer mux.Unlock()
 	endpoint := getServerEndpoint(serverAddress)
 
+	mux.Lock()
 	conn, ok := connectionCache[endpoint]
-	if ok {
-		if isValidConnections(conn) {
-			return conn, nil
-		}
-		conn.Close()
+	dialer := transport
+	mux.Unlock()
+	if ok && isValidConnections(conn) {
+		return conn, nil
 	}
 
 	opts := getDefaultDialOption(authorizer)
-	conn, err := grpc.Dial(endpoint, opts...)
+	conn, err := dialer.Dial(endpoint, opts...)
 	if err != nil {
-		log.Fatalf("Failed to dial: %v", err)
+		return nil, err
 	}
 
-	connectionCache[endpoint] = conn
+	if timeout := getWarmUpTimeout(); timeout > 0 {
+		if err := warmUp(conn, endpoint, timeout); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
 
-	return conn, nil
+	return storeClientConnection(endpoint, conn), nil
+}
+
+// storeClientConnection caches conn for the endpoint and returns it, unless another caller cached a valid connection
+// while conn was dialed, in which case conn is closed and the cached connection returned
+func storeClientConnection(endpoint string, conn *grpc.ClientConn) *grpc.ClientConn {
+	mux.Lock()
+	defer mux.Unlock()
+	if cached, ok := connectionCache[endpoint]; ok {
+		if isValidConnections(cached) {
+			conn.Close()
+			return cached
+		}
+		cached.Close()
+		recordReconnect(endpoint)
+	}
+	connectionCache[endpoint] = conn
+	return conn
 }
 
 func getAuthConnection(serverAddress *string, authorizer auth.Authorizer) (*grpc.ClientConn, error) {
@@ -134,9 +160,9 @@ func getAuthConnection(serverAddress *string, authorizer auth.Authorizer) (*grpc
 	opts = append(opts, grpc.WithTransportCredentials(authorizer.WithTransportAuthorization()))
 	opts = append(opts, grpc.WithPerRPCCredentials(authorizer.WithRPCAuthorization()))
 
-	conn, err := grpc.Dial(endpoint, opts...)
+	conn, err := transport.Dial(endpoint, opts...)
 	if err != nil {
-		log.Fatalf("Failed to dial: %v", err)
+		return nil, err
 	}
 
 	connectionCache[endpoint] = conn
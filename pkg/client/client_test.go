@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func Test_getClientConnection(t *testing.T) {
+	t.Setenv(debugModeTLS, "on")
+	dialing, release := make(chan struct{}), make(chan struct{})
+	SetTransport(TransportFunc(func(endpoint string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+		if endpoint == "slow:1" {
+			close(dialing)
+			<-release
+		}
+		return grpc.Dial(endpoint, grpc.WithInsecure())
+	}))
+	defer SetTransport(nil)
+
+	slow, fast := "slow:1", "fast:1"
+	done := make(chan error)
+	go func() {
+		_, err := getClientConnection(&slow, nil)
+		done <- err
+	}()
+	<-dialing
+
+	// A slow dial does not block the connections to other agents
+	connected := make(chan error)
+	go func() {
+		_, err := getClientConnection(&fast, nil)
+		connected <- err
+	}()
+	select {
+	case err := <-connected:
+		if err != nil {
+			t.Fatalf("Test_getClientConnection failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Test_getClientConnection failed: connection blocked by the dial of another agent")
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("Test_getClientConnection failed: %v", err)
+	}
+	first, _ := getClientConnection(&slow, nil)
+	second, _ := getClientConnection(&slow, nil)
+	if first != second {
+		t.Fatalf("Test_getClientConnection failed: connection not cached")
+	}
+}
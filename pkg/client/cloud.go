@@ -4,8 +4,6 @@
 package client
 
 import (
-	log "k8s.io/klog"
-
 	"github.com/microsoft/moc/pkg/auth"
 	cloud_pb "github.com/microsoft/moc/rpc/cloudagent/cloud"
 )
@@ -14,7 +12,7 @@ import (
 func GetLocationClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.LocationAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get LocationClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cloud_pb.NewLocationAgentClient(conn), nil
@@ -24,7 +22,7 @@ func GetLocationClient(serverAddress *string, authorizer auth.Authorizer) (cloud
 func GetGroupClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.GroupAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get GroupClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cloud_pb.NewGroupAgentClient(conn), nil
@@ -34,7 +32,7 @@ func GetGroupClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb
 func GetNodeClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.NodeAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get NodeClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cloud_pb.NewNodeAgentClient(conn), nil
@@ -44,7 +42,7 @@ func GetNodeClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.
 func GetKubernetesClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.KubernetesAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get KubernetesClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cloud_pb.NewKubernetesAgentClient(conn), nil
@@ -54,7 +52,7 @@ func GetKubernetesClient(serverAddress *string, authorizer auth.Authorizer) (clo
 func GetClusterClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.ClusterAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get ClusterClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cloud_pb.NewClusterAgentClient(conn), nil
@@ -64,7 +62,7 @@ func GetClusterClient(serverAddress *string, authorizer auth.Authorizer) (cloud_
 func GetControlPlaneClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.ControlPlaneAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get ControlPlaneClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cloud_pb.NewControlPlaneAgentClient(conn), nil
@@ -74,7 +72,7 @@ func GetControlPlaneClient(serverAddress *string, authorizer auth.Authorizer) (c
 func GetZoneClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.ZoneAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get ZoneClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cloud_pb.NewZoneAgentClient(conn), nil
@@ -84,7 +82,7 @@ func GetZoneClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.
 func GetEtcdClusterClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.EtcdClusterAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get EtcdClusterClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cloud_pb.NewEtcdClusterAgentClient(conn), nil
@@ -94,7 +92,7 @@ func GetEtcdClusterClient(serverAddress *string, authorizer auth.Authorizer) (cl
 func GetEtcdServerClient(serverAddress *string, authorizer auth.Authorizer) (cloud_pb.EtcdServerAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get EtcdServerClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return cloud_pb.NewEtcdServerAgentClient(conn), nil
@@ -3,8 +3,6 @@
 package client
 
 import (
-	log "k8s.io/klog"
-
 	"github.com/microsoft/moc/pkg/auth"
 	compute_pb "github.com/microsoft/moc/rpc/cloudagent/compute"
 )
@@ -13,7 +11,7 @@ import (
 func GetGalleryImageClient(serverAddress *string, authorizer auth.Authorizer) (compute_pb.GalleryImageAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get GalleryImageClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return compute_pb.NewGalleryImageAgentClient(conn), nil
@@ -23,7 +21,7 @@ func GetGalleryImageClient(serverAddress *string, authorizer auth.Authorizer) (c
 func GetVirtualMachineClient(serverAddress *string, authorizer auth.Authorizer) (compute_pb.VirtualMachineAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get VirtualMachineClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return compute_pb.NewVirtualMachineAgentClient(conn), nil
@@ -33,7 +31,7 @@ func GetVirtualMachineClient(serverAddress *string, authorizer auth.Authorizer)
 func GetAvailabilitySetClient(serverAddress *string, authorizer auth.Authorizer) (compute_pb.AvailabilitySetAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get AvailabilitySetClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return compute_pb.NewAvailabilitySetAgentClient(conn), nil
@@ -43,7 +41,7 @@ func GetAvailabilitySetClient(serverAddress *string, authorizer auth.Authorizer)
 func GetVirtualMachineScaleSetClient(serverAddress *string, authorizer auth.Authorizer) (compute_pb.VirtualMachineScaleSetAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get VirtualMachineScaleSetClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return compute_pb.NewVirtualMachineScaleSetAgentClient(conn), nil
@@ -53,7 +51,7 @@ func GetVirtualMachineScaleSetClient(serverAddress *string, authorizer auth.Auth
 func GetBareMetalHostClient(serverAddress *string, authorizer auth.Authorizer) (compute_pb.BareMetalHostAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get BareMetalHostClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return compute_pb.NewBareMetalHostAgentClient(conn), nil
@@ -63,7 +61,7 @@ func GetBareMetalHostClient(serverAddress *string, authorizer auth.Authorizer) (
 func GetBareMetalMachineClient(serverAddress *string, authorizer auth.Authorizer) (compute_pb.BareMetalMachineAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get BareMetalMachineClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return compute_pb.NewBareMetalMachineAgentClient(conn), nil
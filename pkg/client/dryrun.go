@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/debug"
+)
+
+// dryRunUnaryInterceptor captures the request instead of sending it when the call is made with a
+// debug.WithDryRun context. It runs first, so that dry runs are not counted as failed calls by the stats and
+// telemetry interceptors and a hedged read is captured once. No interceptor changes the request, so the captured
+// request is still the one that would be sent. The request is checked against the maximum request size, as it
+// would be if it were sent.
+func dryRunUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if capture := debug.CaptureFromContext(ctx); capture != nil {
+		if err := checkRequestSize(method, req, getMaxRequestSize()); err != nil {
+			return err
+		}
+		capture.Record(method, req)
+		return debug.ErrDryRun
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/debug"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+func Test_dryRunUnaryInterceptor(t *testing.T) {
+	invoked := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		invoked++
+		return nil
+	}
+	request, err := structpb.NewStruct(map[string]interface{}{"name": "vm1"})
+	if err != nil {
+		t.Fatalf("Test_dryRunUnaryInterceptor failed: %v", err)
+	}
+
+	ctx, capture := debug.WithDryRun(context.Background())
+	if err := dryRunUnaryInterceptor(ctx, "/test/Invoke", request, nil, nil, invoker); err != debug.ErrDryRun {
+		t.Fatalf("Test_dryRunUnaryInterceptor failed: expected ErrDryRun, got %v", err)
+	}
+	if invoked != 0 || len(capture.Requests()) != 1 {
+		t.Fatalf("Test_dryRunUnaryInterceptor failed: request sent or not captured")
+	}
+
+	// A dry run reports a request that would be rejected for its size
+	SetMaxRequestSize(16)
+	defer SetMaxRequestSize(DefaultMaxRequestSize)
+	request.Fields["customdata"] = structpb.NewStringValue(strings.Repeat("x", 64))
+	if err := dryRunUnaryInterceptor(ctx, "/test/Invoke", request, nil, nil, invoker); !errors.IsInvalidInput(err) {
+		t.Fatalf("Test_dryRunUnaryInterceptor failed: expected InvalidInput, got %v", err)
+	}
+
+	if err := dryRunUnaryInterceptor(context.Background(), "/test/Invoke", request, nil, nil, invoker); err != nil || invoked != 1 {
+		t.Fatalf("Test_dryRunUnaryInterceptor failed: request not sent outside a dry run: %v", err)
+	}
+}
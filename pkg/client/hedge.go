@@ -0,0 +1,152 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	log "k8s.io/klog"
+)
+
+// HedgingPolicy configures the hedging of read calls. A read call still waiting for the agent after Delay is
+// sent a second time and the first response is used. Each call adds BudgetRatio to the hedging budget, up to
+// MaxBudget, and each hedged attempt takes one from it, so that hedging adds at most BudgetRatio extra load.
+type HedgingPolicy struct {
+	// Delay - Wait for the first attempt before sending the second. Zero disables hedging.
+	Delay time.Duration
+	// BudgetRatio - Hedged attempts allowed per call
+	BudgetRatio float64
+	// MaxBudget - Hedged attempts that can be saved up while the agent responds quickly
+	MaxBudget float64
+}
+
+type hedgingBudget struct {
+	mux    sync.Mutex
+	policy HedgingPolicy
+	tokens float64
+}
+
+var hedging = &hedgingBudget{}
+
+// SetHedgingPolicy sets the hedging policy of read calls and resets the hedging budget. Hedging is disabled by default.
+func SetHedgingPolicy(policy HedgingPolicy) {
+	hedging.mux.Lock()
+	defer hedging.mux.Unlock()
+	hedging.policy = policy
+	hedging.tokens = 0
+}
+
+// deposit adds a call to the budget and returns the hedging delay, zero if hedging is disabled
+func (b *hedgingBudget) deposit() time.Duration {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.policy.Delay <= 0 {
+		return 0
+	}
+	b.tokens += b.policy.BudgetRatio
+	if b.tokens > b.policy.MaxBudget {
+		b.tokens = b.policy.MaxBudget
+	}
+	return b.policy.Delay
+}
+
+// withdraw takes a hedged attempt from the budget, returning false if the budget is spent
+func (b *hedgingBudget) withdraw() bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// hedgeUnaryInterceptor sends a second attempt of read calls that are slow to respond, within the hedging budget
+func hedgeUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	out, ok := reply.(protov1.Message)
+	if !ok || !isReadRequest(req) {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	delay := hedging.deposit()
+	if delay <= 0 {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	return hedge(ctx, delay, out, func(ctx context.Context, reply protov1.Message) error {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+}
+
+// hedge runs call, and runs it a second time if it has not returned after delay and the budget allows. The first
+// successful response is copied to reply. If both attempts fail the error of the first to fail is returned.
+func hedge(ctx context.Context, delay time.Duration, reply protov1.Message, call func(context.Context, protov1.Message) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		reply protov1.Message
+		err   error
+	}
+	// Buffered so the losing attempt does not block after the winner returns
+	results := make(chan result, 2)
+	attempt := func() {
+		r := protov1.Clone(reply)
+		r.Reset()
+		results <- result{reply: r, err: call(ctx, r)}
+	}
+
+	go attempt()
+	pending := 1
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var firstErr error
+	for {
+		select {
+		case <-timer.C:
+			if hedging.withdraw() {
+				log.Infof("[Client] No response after %v, sending a hedged attempt", delay)
+				go attempt()
+				pending++
+			}
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				reply.Reset()
+				protov1.Merge(reply, r.reply)
+				return nil
+			}
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			if pending == 0 {
+				return firstErr
+			}
+		}
+	}
+}
+
+// isReadRequest returns true for agent requests with a GET operation type, which are safe to send twice
+func isReadRequest(req interface{}) bool {
+	m, ok := req.(protov1.Message)
+	if !ok {
+		return false
+	}
+	msg := protov1.MessageV2(m).ProtoReflect()
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.EnumKind || !strings.EqualFold(string(fd.Name()), "OperationType") {
+			continue
+		}
+		value := fd.Enum().Values().ByNumber(msg.Get(fd).Enum())
+		return value != nil && value.Name() == "GET"
+	}
+	return false
+}
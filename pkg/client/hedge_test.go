@@ -0,0 +1,52 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_hedge(t *testing.T) {
+	defer SetHedgingPolicy(HedgingPolicy{})
+	SetHedgingPolicy(HedgingPolicy{Delay: 10 * time.Millisecond, BudgetRatio: 1, MaxBudget: 1})
+
+	// The first attempt hangs until cancelled, the hedged attempt responds
+	var attempts int32
+	slowFirst := func(ctx context.Context, reply protov1.Message) error {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		reply.(*wrapperspb.StringValue).Value = "hedged"
+		return nil
+	}
+
+	hedging.deposit()
+	reply := &wrapperspb.StringValue{}
+	if err := hedge(context.Background(), 10*time.Millisecond, reply, slowFirst); err != nil || reply.Value != "hedged" {
+		t.Fatalf("Test_hedge failed: unexpected result %q, %v", reply.Value, err)
+	}
+
+	// The budget is spent, so the call waits for the first attempt
+	atomic.StoreInt32(&attempts, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := hedge(ctx, 10*time.Millisecond, &wrapperspb.StringValue{}, slowFirst); err == nil || atomic.LoadInt32(&attempts) != 1 {
+		t.Fatalf("Test_hedge failed: hedged attempt sent without budget")
+	}
+
+	failing := func(ctx context.Context, reply protov1.Message) error {
+		return fmt.Errorf("agent unavailable")
+	}
+	if err := hedge(context.Background(), time.Hour, &wrapperspb.StringValue{}, failing); err == nil {
+		t.Fatalf("Test_hedge failed: expected the error of the attempt")
+	}
+}
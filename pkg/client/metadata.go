@@ -0,0 +1,85 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/microsoft/moc/pkg/auth"
+)
+
+// DefaultMetadataTTL is how long metadata such as the list of locations is served from the cache
+const DefaultMetadataTTL = 5 * time.Minute
+
+// MetadataCacheKey identifies a metadata cache shared by the clients of a cloud using the same authorizer, so that a
+// client is never served metadata fetched with the credentials of another
+type MetadataCacheKey struct {
+	cloudFQDN  string
+	authorizer auth.Authorizer
+}
+
+// NewMetadataCacheKey returns the key of the metadata cache of the cloud and authorizer. It returns false if the
+// authorizer cannot be compared, in which case the client should not share its cache.
+func NewMetadataCacheKey(cloudFQDN string, authorizer auth.Authorizer) (MetadataCacheKey, bool) {
+	if authorizer != nil && !reflect.TypeOf(authorizer).Comparable() {
+		return MetadataCacheKey{}, false
+	}
+	return MetadataCacheKey{cloudFQDN: cloudFQDN, authorizer: authorizer}, true
+}
+
+// MetadataCache caches metadata that rarely changes, such as locations and their capabilities,
+// fetching it again from the agent once it is older than the ttl
+type MetadataCache[T any] struct {
+	mux       sync.Mutex
+	ttl       time.Duration
+	fetch     func(ctx context.Context) (T, error)
+	value     T
+	fetchedAt time.Time
+	valid     bool
+}
+
+// NewMetadataCache returns a cache of the metadata returned by fetch. A ttl of zero or less uses DefaultMetadataTTL.
+func NewMetadataCache[T any](ttl time.Duration, fetch func(ctx context.Context) (T, error)) *MetadataCache[T] {
+	if ttl <= 0 {
+		ttl = DefaultMetadataTTL
+	}
+	return &MetadataCache[T]{ttl: ttl, fetch: fetch}
+}
+
+// Get returns the cached metadata, fetching it if it was never fetched, was invalidated or has expired
+func (c *MetadataCache[T]) Get(ctx context.Context) (T, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.valid && time.Since(c.fetchedAt) < c.ttl {
+		return c.value, nil
+	}
+	return c.refresh(ctx)
+}
+
+// Refresh fetches the metadata regardless of the age of the cached copy. The cached copy is kept if the fetch fails.
+func (c *MetadataCache[T]) Refresh(ctx context.Context) (T, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.refresh(ctx)
+}
+
+// Invalidate makes the next Get fetch the metadata, typically after the metadata was changed through the sdk
+func (c *MetadataCache[T]) Invalidate() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.valid = false
+}
+
+func (c *MetadataCache[T]) refresh(ctx context.Context) (T, error) {
+	value, err := c.fetch(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	c.value, c.fetchedAt, c.valid = value, time.Now(), true
+	return value, nil
+}
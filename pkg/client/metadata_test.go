@@ -0,0 +1,78 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/microsoft/moc/pkg/auth"
+)
+
+func Test_MetadataCache(t *testing.T) {
+	fetches := 0
+	fail := false
+	cache := NewMetadataCache(time.Hour, func(ctx context.Context) ([]string, error) {
+		if fail {
+			return nil, fmt.Errorf("agent unavailable")
+		}
+		fetches++
+		return []string{fmt.Sprintf("location-%d", fetches)}, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		locations, err := cache.Get(context.Background())
+		if err != nil || locations[0] != "location-1" {
+			t.Fatalf("Test_MetadataCache failed: unexpected result %v, %v", locations, err)
+		}
+	}
+	if fetches != 1 {
+		t.Fatalf("Test_MetadataCache failed: expected a single fetch, got %d", fetches)
+	}
+
+	if locations, _ := cache.Refresh(context.Background()); locations[0] != "location-2" {
+		t.Fatalf("Test_MetadataCache failed: Refresh returned %v", locations)
+	}
+
+	fail = true
+	if _, err := cache.Refresh(context.Background()); err == nil {
+		t.Fatalf("Test_MetadataCache failed: expected the failed fetch to be returned")
+	}
+	if locations, err := cache.Get(context.Background()); err != nil || locations[0] != "location-2" {
+		t.Fatalf("Test_MetadataCache failed: cached copy lost after a failed refresh: %v, %v", locations, err)
+	}
+
+	cache.Invalidate()
+	if _, err := cache.Get(context.Background()); err == nil {
+		t.Fatalf("Test_MetadataCache failed: expected Get to fetch after Invalidate")
+	}
+}
+
+type comparableAuthorizer struct {
+	auth.Authorizer
+}
+
+type uncomparableAuthorizer struct {
+	auth.Authorizer
+	scopes []string
+}
+
+func Test_NewMetadataCacheKey(t *testing.T) {
+	first, second := &comparableAuthorizer{}, &comparableAuthorizer{}
+	firstKey, ok := NewMetadataCacheKey("cloud", first)
+	if !ok {
+		t.Fatalf("Test_NewMetadataCacheKey failed: pointer authorizer not comparable")
+	}
+	if key, _ := NewMetadataCacheKey("cloud", first); key != firstKey {
+		t.Fatalf("Test_NewMetadataCacheKey failed: keys of the same authorizer differ")
+	}
+	if key, _ := NewMetadataCacheKey("cloud", second); key == firstKey {
+		t.Fatalf("Test_NewMetadataCacheKey failed: keys of different authorizers are equal")
+	}
+	if _, ok := NewMetadataCacheKey("cloud", uncomparableAuthorizer{}); ok {
+		t.Fatalf("Test_NewMetadataCacheKey failed: expected an authorizer holding a slice not to be comparable")
+	}
+}
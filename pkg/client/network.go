@@ -4,8 +4,6 @@
 package client
 
 import (
-	log "k8s.io/klog"
-
 	"github.com/microsoft/moc/pkg/auth"
 	network_pb "github.com/microsoft/moc/rpc/cloudagent/network"
 )
@@ -14,7 +12,7 @@ import (
 func GetVirtualNetworkClient(serverAddress *string, authorizer auth.Authorizer) (network_pb.VirtualNetworkAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get VirtualNetworkClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return network_pb.NewVirtualNetworkAgentClient(conn), nil
@@ -24,7 +22,7 @@ func GetVirtualNetworkClient(serverAddress *string, authorizer auth.Authorizer)
 func GetLogicalNetworkClient(serverAddress *string, authorizer auth.Authorizer) (network_pb.LogicalNetworkAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get LogicalNetworkClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return network_pb.NewLogicalNetworkAgentClient(conn), nil
@@ -34,7 +32,7 @@ func GetLogicalNetworkClient(serverAddress *string, authorizer auth.Authorizer)
 func GetNetworkInterfaceClient(serverAddress *string, authorizer auth.Authorizer) (network_pb.NetworkInterfaceAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get NetworkInterfaceClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return network_pb.NewNetworkInterfaceAgentClient(conn), nil
@@ -44,7 +42,7 @@ func GetNetworkInterfaceClient(serverAddress *string, authorizer auth.Authorizer
 func GetLoadBalancerClient(serverAddress *string, authorizer auth.Authorizer) (network_pb.LoadBalancerAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get LoadBalancerClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return network_pb.NewLoadBalancerAgentClient(conn), nil
@@ -54,7 +52,7 @@ func GetLoadBalancerClient(serverAddress *string, authorizer auth.Authorizer) (n
 func GetVipPoolClient(serverAddress *string, authorizer auth.Authorizer) (network_pb.VipPoolAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get VipPoolClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return network_pb.NewVipPoolAgentClient(conn), nil
@@ -64,7 +62,7 @@ func GetVipPoolClient(serverAddress *string, authorizer auth.Authorizer) (networ
 func GetMacPoolClient(serverAddress *string, authorizer auth.Authorizer) (network_pb.MacPoolAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get MacPoolClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return network_pb.NewMacPoolAgentClient(conn), nil
@@ -74,7 +72,7 @@ func GetMacPoolClient(serverAddress *string, authorizer auth.Authorizer) (networ
 func GetNetworkSecurityGroupClient(serverAddress *string, authorizer auth.Authorizer) (network_pb.NetworkSecurityGroupAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get NetworkSecurityGroupAgentClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return network_pb.NewNetworkSecurityGroupAgentClient(conn), nil
@@ -0,0 +1,171 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	log "k8s.io/klog"
+)
+
+const (
+	// RetryAfterMetadataKey is the trailer the agent uses to hint how long to wait before retrying a throttled call
+	RetryAfterMetadataKey = "retry-after"
+
+	defaultThrottleRetryCount    = 3
+	defaultThrottleRetryInterval = 1 * time.Second
+	maxThrottleRetryInterval     = 1 * time.Minute
+)
+
+// throttleError carries the retry-after hint received with a throttled call
+type throttleError struct {
+	error
+	retryAfter time.Duration
+}
+
+func (e *throttleError) Unwrap() error {
+	return e.error
+}
+
+// GRPCStatus allows status.FromError and status.Code to see through the wrapper
+func (e *throttleError) GRPCStatus() *status.Status {
+	return status.Convert(e.error)
+}
+
+var (
+	throttleMux           sync.Mutex
+	throttleRetryCount    = defaultThrottleRetryCount
+	throttleRetryInterval = defaultThrottleRetryInterval
+)
+
+// SetThrottleRetryPolicy sets how many times a throttled call is retried and the
+// wait used when the agent does not send a retry-after hint
+func SetThrottleRetryPolicy(retryCount int, defaultInterval time.Duration) {
+	throttleMux.Lock()
+	defer throttleMux.Unlock()
+	throttleRetryCount = retryCount
+	throttleRetryInterval = defaultInterval
+}
+
+func getThrottleRetryPolicy() (int, time.Duration) {
+	throttleMux.Lock()
+	defer throttleMux.Unlock()
+	return throttleRetryCount, throttleRetryInterval
+}
+
+// IsThrottled returns true if the agent rejected the call because it is throttling requests
+func IsThrottled(err error) bool {
+	return status.Code(err) == codes.ResourceExhausted
+}
+
+// GetRetryAfter returns the wait the agent asked for before retrying the call
+// that returned err, which may wrap the error returned by the client. The second
+// return value is false if no hint was sent.
+func GetRetryAfter(err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+	var terr *throttleError
+	if errors.As(err, &terr) {
+		return terr.retryAfter, true
+	}
+	return getRetryInfoFromStatus(err)
+}
+
+// throttleRetryUnaryInterceptor retries read calls rejected with ResourceExhausted,
+// waiting for the interval hinted by the agent. Other calls are not retried, since
+// the agent does not guarantee that a throttled mutation was not applied; their
+// error still carries the hint, for GetRetryAfter.
+func throttleRetryUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	retryCount, defaultInterval := getThrottleRetryPolicy()
+	if !isReadRequest(req) {
+		retryCount = 0
+	}
+	for attempt := 0; ; attempt++ {
+		var trailer metadata.MD
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
+		if !IsThrottled(err) {
+			return err
+		}
+
+		retryAfter, hinted := getRetryAfterFromTrailer(trailer)
+		if !hinted {
+			retryAfter, hinted = getRetryInfoFromStatus(err)
+		}
+		if hinted {
+			err = &throttleError{error: err, retryAfter: retryAfter}
+		} else {
+			retryAfter = defaultInterval
+		}
+		if attempt >= retryCount {
+			return err
+		}
+
+		log.Infof("[Client] %s throttled by the agent, retrying in %v", method, retryAfter)
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func getRetryAfterFromTrailer(trailer metadata.MD) (time.Duration, bool) {
+	values := trailer.Get(RetryAfterMetadataKey)
+	if len(values) == 0 {
+		return 0, false
+	}
+	return parseRetryAfter(values[0])
+}
+
+func getRetryInfoFromStatus(err error) (time.Duration, bool) {
+	s, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return clampRetryAfter(info.GetRetryDelay().AsDuration()), true
+		}
+	}
+	return 0, false
+}
+
+// parseRetryAfter accepts the retry-after value either as a number of seconds or as an http date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return clampRetryAfter(time.Duration(seconds * float64(time.Second))), true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return clampRetryAfter(time.Until(t)), true
+	}
+	return 0, false
+}
+
+func clampRetryAfter(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	if d > maxThrottleRetryInterval {
+		return maxThrottleRetryInterval
+	}
+	return d
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
+	wssdcommon "github.com/microsoft/moc/rpc/common"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func Test_parseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter(" 2 "); !ok || d != 2*time.Second {
+		t.Fatalf("Test_parseRetryAfter failed: seconds parsed as %v, %v", d, ok)
+	}
+	if d, ok := parseRetryAfter("0.5"); !ok || d != 500*time.Millisecond {
+		t.Fatalf("Test_parseRetryAfter failed: fractional seconds parsed as %v, %v", d, ok)
+	}
+	if d, ok := parseRetryAfter("3600"); !ok || d != maxThrottleRetryInterval {
+		t.Fatalf("Test_parseRetryAfter failed: long wait not clamped: %v", d)
+	}
+	if _, ok := parseRetryAfter("-1"); ok {
+		t.Fatalf("Test_parseRetryAfter failed: negative seconds accepted")
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Fatalf("Test_parseRetryAfter failed: invalid value accepted")
+	}
+	date := time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date); !ok || d <= 0 || d > 10*time.Second {
+		t.Fatalf("Test_parseRetryAfter failed: http date parsed as %v, %v", d, ok)
+	}
+}
+
+func newThrottlingInvoker(calls *int, throttled int) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls > throttled {
+			return nil
+		}
+		for _, opt := range opts {
+			if trailer, ok := opt.(grpc.TrailerCallOption); ok {
+				*trailer.TrailerAddr = metadata.Pairs(RetryAfterMetadataKey, "0.01")
+			}
+		}
+		return status.Error(codes.ResourceExhausted, "throttled")
+	}
+}
+
+func Test_throttleRetryUnaryInterceptor(t *testing.T) {
+	defer SetThrottleRetryPolicy(defaultThrottleRetryCount, defaultThrottleRetryInterval)
+	SetThrottleRetryPolicy(3, time.Millisecond)
+
+	read := &wssdcloudnetwork.VirtualNetworkRequest{OperationType: wssdcommon.Operation_GET}
+	calls := 0
+	if err := throttleRetryUnaryInterceptor(context.Background(), "Invoke", read, nil, nil, newThrottlingInvoker(&calls, 2)); err != nil || calls != 3 {
+		t.Fatalf("Test_throttleRetryUnaryInterceptor failed: read retried %d times, %v", calls, err)
+	}
+
+	calls = 0
+	err := throttleRetryUnaryInterceptor(context.Background(), "Invoke", read, nil, nil, newThrottlingInvoker(&calls, 10))
+	if !IsThrottled(err) || calls != 4 {
+		t.Fatalf("Test_throttleRetryUnaryInterceptor failed: read retried %d times, %v", calls, err)
+	}
+	if d, ok := GetRetryAfter(err); !ok || d != 10*time.Millisecond {
+		t.Fatalf("Test_throttleRetryUnaryInterceptor failed: retry-after hint lost: %v, %v", d, ok)
+	}
+
+	// Mutations are not retried
+	calls = 0
+	mutation := &wssdcloudnetwork.VirtualNetworkRequest{OperationType: wssdcommon.Operation_POST}
+	if err := throttleRetryUnaryInterceptor(context.Background(), "Invoke", mutation, nil, nil, newThrottlingInvoker(&calls, 1)); !IsThrottled(err) || calls != 1 {
+		t.Fatalf("Test_throttleRetryUnaryInterceptor failed: mutation sent %d times", calls)
+	}
+	calls = 0
+	if err := throttleRetryUnaryInterceptor(context.Background(), "Invoke", &wrapperspb.StringValue{}, nil, nil, newThrottlingInvoker(&calls, 1)); !IsThrottled(err) || calls != 1 {
+		t.Fatalf("Test_throttleRetryUnaryInterceptor failed: unknown request sent %d times", calls)
+	}
+}
+
+// chainUnaryInterceptors runs the interceptors around invoker in the order used by grpc.WithChainUnaryInterceptor
+func chainUnaryInterceptors(interceptors []grpc.UnaryClientInterceptor, invoker grpc.UnaryInvoker) grpc.UnaryInvoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return interceptor(ctx, method, req, reply, cc, next, opts...)
+		}
+	}
+	return invoker
+}
+
+func Test_unaryInterceptorsKeepRetryAfter(t *testing.T) {
+	defer SetThrottleRetryPolicy(defaultThrottleRetryCount, defaultThrottleRetryInterval)
+	SetThrottleRetryPolicy(0, time.Millisecond)
+
+	cc, err := grpc.Dial("passthrough:///agent:1", grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Test_unaryInterceptorsKeepRetryAfter failed: %v", err)
+	}
+	defer cc.Close()
+
+	st, err := status.New(codes.ResourceExhausted, `throttled request with password:"hunter2"`).WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(5 * time.Second)})
+	if err != nil {
+		t.Fatalf("Test_unaryInterceptorsKeepRetryAfter failed: %v", err)
+	}
+	invoker := chainUnaryInterceptors(unaryInterceptors, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		for _, opt := range opts {
+			if trailer, ok := opt.(grpc.TrailerCallOption); ok {
+				*trailer.TrailerAddr = metadata.Pairs(RetryAfterMetadataKey, "2")
+			}
+		}
+		return st.Err()
+	})
+
+	read := &wssdcloudnetwork.VirtualNetworkRequest{OperationType: wssdcommon.Operation_GET}
+	err = invoker(context.Background(), "Invoke", read, nil, cc)
+	if !IsThrottled(err) || strings.Contains(err.Error(), "hunter2") {
+		t.Fatalf("Test_unaryInterceptorsKeepRetryAfter failed: unexpected error %v", err)
+	}
+
+	// The sanitized error keeps the retry-after trailer, which takes precedence over the status details
+	if d, ok := GetRetryAfter(err); !ok || d != 2*time.Second {
+		t.Fatalf("Test_unaryInterceptorsKeepRetryAfter failed: retry-after hint lost: %v, %v", d, ok)
+	}
+	if d, ok := GetRetryAfter(fmt.Errorf("listing virtual networks: %w", err)); !ok || d != 2*time.Second {
+		t.Fatalf("Test_unaryInterceptorsKeepRetryAfter failed: retry-after hint lost by wrapping: %v, %v", d, ok)
+	}
+	if d, ok := getRetryInfoFromStatus(err); !ok || d != 5*time.Second {
+		t.Fatalf("Test_unaryInterceptorsKeepRetryAfter failed: status details lost: %v, %v", d, ok)
+	}
+}
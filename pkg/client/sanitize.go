@@ -0,0 +1,40 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/debug"
+)
+
+// sanitizeUnaryInterceptor redacts sensitive fields from the message of the errors returned by the call,
+// keeping the status code and details of agent errors and the retry-after hint of throttled calls. It runs
+// before the other interceptors, other than telemetry, so the errors they return and the errors reported to
+// telemetry are sanitized as well.
+func sanitizeUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		msg := debug.Sanitize(st.Message())
+		if msg == st.Message() {
+			return err
+		}
+		p := st.Proto()
+		p.Message = msg
+		sanitized := status.ErrorProto(p)
+		var terr *throttleError
+		if errors.As(err, &terr) {
+			return &throttleError{error: sanitized, retryAfter: terr.retryAfter}
+		}
+		return sanitized
+	}
+	return debug.SanitizeError(err)
+}
@@ -4,8 +4,6 @@
 package client
 
 import (
-	log "k8s.io/klog"
-
 	"github.com/microsoft/moc/pkg/auth"
 	security_pb "github.com/microsoft/moc/rpc/cloudagent/security"
 )
@@ -14,7 +12,7 @@ import (
 func GetKeyVaultClient(serverAddress *string, authorizer auth.Authorizer) (security_pb.KeyVaultAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get KeyVaultClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return security_pb.NewKeyVaultAgentClient(conn), nil
@@ -24,7 +22,7 @@ func GetKeyVaultClient(serverAddress *string, authorizer auth.Authorizer) (secur
 func GetSecretClient(serverAddress *string, authorizer auth.Authorizer) (security_pb.SecretAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get SecretClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return security_pb.NewSecretAgentClient(conn), nil
@@ -34,7 +32,7 @@ func GetSecretClient(serverAddress *string, authorizer auth.Authorizer) (securit
 func GetKeyClient(serverAddress *string, authorizer auth.Authorizer) (security_pb.KeyAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get KeyClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return security_pb.NewKeyAgentClient(conn), nil
@@ -44,7 +42,7 @@ func GetKeyClient(serverAddress *string, authorizer auth.Authorizer) (security_p
 func GetCertificateClient(serverAddress *string, authorizer auth.Authorizer) (security_pb.CertificateAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get CertificateClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return security_pb.NewCertificateAgentClient(conn), nil
@@ -54,7 +52,7 @@ func GetCertificateClient(serverAddress *string, authorizer auth.Authorizer) (se
 func GetIdentityClient(serverAddress *string, authorizer auth.Authorizer) (security_pb.IdentityAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get IdentityClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return security_pb.NewIdentityAgentClient(conn), nil
@@ -64,7 +62,7 @@ func GetIdentityClient(serverAddress *string, authorizer auth.Authorizer) (secur
 func GetRoleClient(serverAddress *string, authorizer auth.Authorizer) (security_pb.RoleAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get RoleClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return security_pb.NewRoleAgentClient(conn), nil
@@ -74,7 +72,7 @@ func GetRoleClient(serverAddress *string, authorizer auth.Authorizer) (security_
 func GetRoleAssignmentClient(serverAddress *string, authorizer auth.Authorizer) (security_pb.RoleAssignmentAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get RoleAssignmentClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return security_pb.NewRoleAssignmentAgentClient(conn), nil
@@ -84,7 +82,7 @@ func GetRoleAssignmentClient(serverAddress *string, authorizer auth.Authorizer)
 func GetAuthenticationClient(serverAddress *string, authorizer auth.Authorizer) (security_pb.AuthenticationAgentClient, error) {
 	conn, err := getAuthConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get Authentication. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return security_pb.NewAuthenticationAgentClient(conn), nil
@@ -0,0 +1,147 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// DefaultMaxRequestSize matches the default maximum message size accepted by the agent
+const DefaultMaxRequestSize = 4 * 1024 * 1024
+
+var (
+	requestSizeMux sync.Mutex
+	maxRequestSize = DefaultMaxRequestSize
+)
+
+// SetMaxRequestSize sets the largest request, in bytes, sent to the agent. Larger requests fail
+// before being sent with an error naming the largest field. A size of 0 disables the check.
+func SetMaxRequestSize(size int) {
+	requestSizeMux.Lock()
+	defer requestSizeMux.Unlock()
+	maxRequestSize = size
+}
+
+func getMaxRequestSize() int {
+	requestSizeMux.Lock()
+	defer requestSizeMux.Unlock()
+	return maxRequestSize
+}
+
+// requestSizeUnaryInterceptor rejects requests larger than the maximum request size
+func requestSizeUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if err := checkRequestSize(method, req, getMaxRequestSize()); err != nil {
+		return err
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func checkRequestSize(method string, req interface{}, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	m, ok := req.(protov1.Message)
+	if !ok {
+		return nil
+	}
+	msg := protov1.MessageV2(m)
+	size := proto.Size(msg)
+	if size <= limit {
+		return nil
+	}
+	field, fieldSize := largestField(msg.ProtoReflect(), "")
+	if len(field) == 0 {
+		return errors.Wrapf(errors.InvalidInput, "Request to %s is %d bytes, exceeding the maximum of %d bytes", method, size, limit)
+	}
+	return errors.Wrapf(errors.InvalidInput, "Request to %s is %d bytes, exceeding the maximum of %d bytes. Field %s is %d bytes", method, size, limit, field, fieldSize)
+}
+
+// largestField returns the path and size of the largest field of m, descending into the largest
+// element of nested messages, lists and maps
+func largestField(m protoreflect.Message, prefix string) (string, int) {
+	var (
+		largestDesc  protoreflect.FieldDescriptor
+		largestValue protoreflect.Value
+		largestSize  int
+	)
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		single := m.New()
+		single.Set(fd, v)
+		if size := proto.Size(single.Interface()); size > largestSize {
+			largestDesc, largestValue, largestSize = fd, v, size
+		}
+		return true
+	})
+	if largestDesc == nil {
+		return "", 0
+	}
+
+	path := string(largestDesc.Name())
+	if len(prefix) > 0 {
+		path = prefix + "." + path
+	}
+
+	switch {
+	case largestDesc.IsList():
+		list := largestValue.List()
+		index, size := -1, 0
+		for i := 0; i < list.Len(); i++ {
+			if s := valueSize(largestDesc, list.Get(i)); s > size {
+				index, size = i, s
+			}
+		}
+		if index < 0 {
+			return path, largestSize
+		}
+		path = fmt.Sprintf("%s[%d]", path, index)
+		if largestDesc.Message() != nil {
+			if nested, nestedSize := largestField(list.Get(index).Message(), path); len(nested) > 0 {
+				return nested, nestedSize
+			}
+		}
+		return path, size
+
+	case largestDesc.IsMap():
+		var key protoreflect.MapKey
+		size := 0
+		largestValue.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			if s := valueSize(largestDesc.MapValue(), v) + len(k.String()); s > size {
+				key, size = k, s
+			}
+			return true
+		})
+		if size == 0 {
+			return path, largestSize
+		}
+		return fmt.Sprintf("%s[%s]", path, key.String()), size
+
+	case largestDesc.Message() != nil:
+		if nested, nestedSize := largestField(largestValue.Message(), path); len(nested) > 0 {
+			return nested, nestedSize
+		}
+	}
+	return path, largestSize
+}
+
+// valueSize approximates the encoded size of a single value of the field
+func valueSize(fd protoreflect.FieldDescriptor, v protoreflect.Value) int {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return proto.Size(v.Message().Interface())
+	case protoreflect.StringKind:
+		return len(v.String())
+	case protoreflect.BytesKind:
+		return len(v.Bytes())
+	}
+	return 8
+}
@@ -0,0 +1,38 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+func Test_checkRequestSize(t *testing.T) {
+	request, err := structpb.NewStruct(map[string]interface{}{
+		"name":       "vm1",
+		"customdata": strings.Repeat("x", 2048),
+	})
+	if err != nil {
+		t.Fatalf("Test_checkRequestSize failed: %v", err)
+	}
+
+	if err := checkRequestSize("/test/Invoke", request, 4096); err != nil {
+		t.Fatalf("Test_checkRequestSize failed: unexpected error %v", err)
+	}
+	if err := checkRequestSize("/test/Invoke", request, 0); err != nil {
+		t.Fatalf("Test_checkRequestSize failed: disabled check returned %v", err)
+	}
+
+	err = checkRequestSize("/test/Invoke", request, 1024)
+	if !errors.IsInvalidInput(err) {
+		t.Fatalf("Test_checkRequestSize failed: expected InvalidInput, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "fields[customdata]") {
+		t.Fatalf("Test_checkRequestSize failed: error does not name the largest field: %v", err)
+	}
+}
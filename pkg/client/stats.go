@@ -0,0 +1,113 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// ConnectionStats describes a cached connection to an agent, for inclusion in support bundles
+type ConnectionStats struct {
+	// Endpoint - Address of the agent
+	Endpoint string `json:"endpoint"`
+	// State - Connectivity state of the connection
+	State string `json:"state"`
+	// InFlight - Calls waiting for a response
+	InFlight int64 `json:"inFlight"`
+	// Calls - Calls made since the connection was first dialed
+	Calls int64 `json:"calls"`
+	// Failures - Calls that returned an error
+	Failures int64 `json:"failures"`
+	// LastError - Error of the last failed call
+	LastError string `json:"lastError,omitempty"`
+	// LastErrorTime - Time of the last failed call
+	LastErrorTime time.Time `json:"lastErrorTime"`
+	// Reconnects - Times the connection was dialed again after failing or shutting down
+	Reconnects int64 `json:"reconnects"`
+}
+
+type endpointStats struct {
+	inFlight      int64
+	calls         int64
+	failures      int64
+	lastError     string
+	lastErrorTime time.Time
+	reconnects    int64
+}
+
+var (
+	statsMux sync.Mutex
+	stats    = map[string]*endpointStats{}
+)
+
+// Stats returns the stats of the open connections to agents, sorted by endpoint
+func Stats() []ConnectionStats {
+	mux.Lock()
+	conns := map[string]*grpc.ClientConn{}
+	for endpoint, conn := range connectionCache {
+		conns[endpoint] = conn
+	}
+	mux.Unlock()
+
+	statsMux.Lock()
+	defer statsMux.Unlock()
+	result := []ConnectionStats{}
+	for endpoint, conn := range conns {
+		s := ConnectionStats{Endpoint: endpoint, State: conn.GetState().String()}
+		if es, ok := stats[endpoint]; ok {
+			s.InFlight = es.inFlight
+			s.Calls = es.calls
+			s.Failures = es.failures
+			s.LastError = es.lastError
+			s.LastErrorTime = es.lastErrorTime
+			s.Reconnects = es.reconnects
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Endpoint < result[j].Endpoint })
+	return result
+}
+
+func getEndpointStats(endpoint string) *endpointStats {
+	es, ok := stats[endpoint]
+	if !ok {
+		es = &endpointStats{}
+		stats[endpoint] = es
+	}
+	return es
+}
+
+// recordReconnect counts a connection to the endpoint dialed to replace a failed one
+func recordReconnect(endpoint string) {
+	statsMux.Lock()
+	defer statsMux.Unlock()
+	getEndpointStats(endpoint).reconnects++
+}
+
+// statsUnaryInterceptor counts the calls made on each connection and records their last error
+func statsUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	endpoint := cc.Target()
+	statsMux.Lock()
+	es := getEndpointStats(endpoint)
+	es.inFlight++
+	es.calls++
+	statsMux.Unlock()
+
+	err := invoker(ctx, method, req, reply, cc, opts...)
+
+	statsMux.Lock()
+	es.inFlight--
+	if err != nil {
+		es.failures++
+		es.lastError = err.Error()
+		es.lastErrorTime = time.Now()
+	}
+	statsMux.Unlock()
+	return err
+}
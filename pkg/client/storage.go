@@ -4,8 +4,6 @@
 package client
 
 import (
-	log "k8s.io/klog"
-
 	"github.com/microsoft/moc/pkg/auth"
 	storage_pb "github.com/microsoft/moc/rpc/cloudagent/storage"
 )
@@ -14,7 +12,7 @@ import (
 func GetVirtualHardDiskClient(serverAddress *string, authorizer auth.Authorizer) (storage_pb.VirtualHardDiskAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get VirtualHardDiskClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return storage_pb.NewVirtualHardDiskAgentClient(conn), nil
@@ -24,7 +22,7 @@ func GetVirtualHardDiskClient(serverAddress *string, authorizer auth.Authorizer)
 func GetStorageContainerClient(serverAddress *string, authorizer auth.Authorizer) (storage_pb.ContainerAgentClient, error) {
 	conn, err := getClientConnection(serverAddress, authorizer)
 	if err != nil {
-		log.Fatalf("Unable to get ContainerClient. Failed to dial: %v", err)
+		return nil, err
 	}
 
 	return storage_pb.NewContainerAgentClient(conn), nil
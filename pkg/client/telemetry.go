@@ -0,0 +1,34 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/telemetry"
+)
+
+// telemetryUnaryInterceptor reports every unary call to the registered telemetry exporters
+func telemetryUnaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !telemetry.Enabled() {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	event := telemetry.Event{
+		Time:     start,
+		Method:   method,
+		Target:   cc.Target(),
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	telemetry.Emit(event)
+	return err
+}
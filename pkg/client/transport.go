@@ -0,0 +1,41 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"google.golang.org/grpc"
+)
+
+// Transport creates the connections to the agent used by the service clients. A custom transport can connect to
+// an in-process agent, for example through a bufconn listener, or through a proxy, with a dialer option.
+type Transport interface {
+	// Dial returns a connection to the agent at endpoint. opts hold the credentials and interceptors of the sdk
+	// and should be passed on to grpc.
+	Dial(endpoint string, opts ...grpc.DialOption) (*grpc.ClientConn, error)
+}
+
+// TransportFunc adapts a function to a Transport
+type TransportFunc func(endpoint string, opts ...grpc.DialOption) (*grpc.ClientConn, error)
+
+// Dial calls f
+func (f TransportFunc) Dial(endpoint string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return f(endpoint, opts...)
+}
+
+// DefaultTransport dials the agent over the network
+var DefaultTransport Transport = TransportFunc(grpc.Dial)
+
+var transport = DefaultTransport
+
+// SetTransport sets the transport used for new connections to the agent and clears the connection cache, so
+// that clients created afterwards use it. A nil transport restores DefaultTransport.
+func SetTransport(t Transport) {
+	if t == nil {
+		t = DefaultTransport
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	transport = t
+	connectionCache = map[string]*grpc.ClientConn{}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+var (
+	warmUpMux     sync.Mutex
+	warmUpTimeout time.Duration
+)
+
+// SetWarmUpTimeout makes the construction of service clients dial the agent and complete the TLS handshake,
+// waiting at most timeout, so that an unreachable agent or rejected certificate fails the constructor with a
+// BootstrapError instead of the first call. A timeout of 0, the default, dials on the first call.
+func SetWarmUpTimeout(timeout time.Duration) {
+	warmUpMux.Lock()
+	defer warmUpMux.Unlock()
+	warmUpTimeout = timeout
+}
+
+func getWarmUpTimeout() time.Duration {
+	warmUpMux.Lock()
+	defer warmUpMux.Unlock()
+	return warmUpTimeout
+}
+
+// BootstrapError is returned by client constructors when the connection to the agent is not ready within the warm-up timeout
+type BootstrapError struct {
+	// Endpoint - Address of the agent
+	Endpoint string
+	// State - State of the connection when the warm-up timed out
+	State connectivity.State
+	// Timeout - The warm-up timeout
+	Timeout time.Duration
+}
+
+func (e *BootstrapError) Error() string {
+	return fmt.Sprintf("Unable to connect to the agent at [%s] within %v: connection is %s", e.Endpoint, e.Timeout, e.State)
+}
+
+// warmUp connects conn and waits for it to be ready, at most timeout
+func warmUp(conn *grpc.ClientConn, endpoint string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return &BootstrapError{Endpoint: endpoint, State: conn.GetState(), Timeout: timeout}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package conversion
+
+import (
+	"sync"
+
+	"github.com/microsoft/moc/pkg/tags"
+	"github.com/microsoft/moc/rpc/common"
+)
+
+// Resource types passed to the tags hooks and used in resource IDs
+const (
+	AvailabilitySet        = "AvailabilitySet"
+	BareMetalHost          = "BareMetalHost"
+	BareMetalMachine       = "BareMetalMachine"
+	Certificate            = "Certificate"
+	Cluster                = "Cluster"
+	Container              = "Container"
+	ControlPlane           = "ControlPlane"
+	EtcdCluster            = "EtcdCluster"
+	GalleryImage           = "GalleryImage"
+	Group                  = "Group"
+	Identity               = "Identity"
+	KeyVault               = "KeyVault"
+	Kubernetes             = "Kubernetes"
+	LoadBalancer           = "LoadBalancer"
+	LogicalNetwork         = "LogicalNetwork"
+	MacPool                = "MacPool"
+	NetworkInterface       = "NetworkInterface"
+	NetworkSecurityGroup   = "NetworkSecurityGroup"
+	Node                   = "Node"
+	VipPool                = "VipPool"
+	VirtualHardDisk        = "VirtualHardDisk"
+	VirtualMachine         = "VirtualMachine"
+	VirtualMachineImage    = "VirtualMachineImage"
+	VirtualMachineScaleSet = "VirtualMachineScaleSet"
+	VirtualNetwork         = "VirtualNetwork"
+	Zone                   = "Zone"
+)
+
+// TagsHook is invoked with the type of the resource being converted and a copy
+// of its tags, and returns the tags to use in their place
+type TagsHook func(resourceType string, tags map[string]*string) map[string]*string
+
+var (
+	mux            sync.RWMutex
+	toProtoHooks   []TagsHook
+	fromProtoHooks []TagsHook
+)
+
+// RegisterToProtoTagsHook registers a hook run on the tags of every resource sent to the agent
+func RegisterToProtoTagsHook(hook TagsHook) {
+	mux.Lock()
+	defer mux.Unlock()
+	toProtoHooks = append(toProtoHooks, hook)
+}
+
+// RegisterFromProtoTagsHook registers a hook run on the tags of every resource returned by the agent
+func RegisterFromProtoTagsHook(hook TagsHook) {
+	mux.Lock()
+	defer mux.Unlock()
+	fromProtoHooks = append(fromProtoHooks, hook)
+}
+
+// ClearTagsHooks removes all registered tags hooks
+func ClearTagsHooks() {
+	mux.Lock()
+	defer mux.Unlock()
+	toProtoHooks = nil
+	fromProtoHooks = nil
+}
+
+// TagsToProto converts the tags of a resource to their protobuf form, applying the registered hooks.
+// Nil tags stay nil unless a hook adds tags.
+func TagsToProto(resourceType string, t map[string]*string) *common.Tags {
+	converted := runTagsHooks(getToProtoHooks(), resourceType, t)
+	if t == nil && len(converted) == 0 {
+		return nil
+	}
+	return tags.MapToProto(converted)
+}
+
+// TagsFromProto converts the protobuf tags of a resource, applying the registered hooks.
+// Nil tags stay nil unless a hook adds tags.
+func TagsFromProto(resourceType string, t *common.Tags) map[string]*string {
+	var converted map[string]*string
+	if t != nil {
+		converted = tags.ProtoToMap(t)
+	}
+	converted = runTagsHooks(getFromProtoHooks(), resourceType, converted)
+	if t == nil && len(converted) == 0 {
+		return nil
+	}
+	return converted
+}
+
+func getToProtoHooks() []TagsHook {
+	mux.RLock()
+	defer mux.RUnlock()
+	return toProtoHooks
+}
+
+func getFromProtoHooks() []TagsHook {
+	mux.RLock()
+	defer mux.RUnlock()
+	return fromProtoHooks
+}
+
+func runTagsHooks(hooks []TagsHook, resourceType string, t map[string]*string) map[string]*string {
+	if len(hooks) == 0 {
+		return t
+	}
+	for _, hook := range hooks {
+		copied := make(map[string]*string, len(t))
+		for k, v := range t {
+			copied[k] = v
+		}
+		t = hook(resourceType, copied)
+	}
+	return t
+}
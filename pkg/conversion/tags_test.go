@@ -0,0 +1,71 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package conversion
+
+import (
+	"testing"
+
+	"github.com/microsoft/moc/pkg/tags"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TagsHooks(t *testing.T) {
+	defer ClearTagsHooks()
+	env, owner := "prod", "team"
+
+	RegisterToProtoTagsHook(func(resourceType string, t map[string]*string) map[string]*string {
+		t["resourceType"] = &resourceType
+		return t
+	})
+	RegisterToProtoTagsHook(func(resourceType string, t map[string]*string) map[string]*string {
+		delete(t, "secret")
+		return t
+	})
+	input := map[string]*string{"env": &env, "secret": &owner}
+	result := tags.ProtoToMap(TagsToProto(VirtualMachine, input))
+	assert.Equal(t, VirtualMachine, *result["resourceType"])
+	assert.Equal(t, env, *result["env"])
+	assert.NotContains(t, result, "secret")
+	// The hooks run on copies, so the tags of the caller are unchanged
+	assert.Len(t, input, 2)
+	assert.NotContains(t, input, "resourceType")
+
+	RegisterFromProtoTagsHook(func(resourceType string, t map[string]*string) map[string]*string {
+		t["owner"] = &owner
+		return t
+	})
+	fromProto := TagsFromProto(LoadBalancer, tags.MapToProto(map[string]*string{"env": &env}))
+	assert.Equal(t, owner, *fromProto["owner"])
+	assert.Equal(t, env, *fromProto["env"])
+}
+
+func Test_TagsWithoutHooks(t *testing.T) {
+	ClearTagsHooks()
+	env := "prod"
+	result := TagsFromProto(Group, TagsToProto(Group, map[string]*string{"env": &env}))
+	assert.Equal(t, env, *result["env"])
+}
+
+func Test_TagsNil(t *testing.T) {
+	defer ClearTagsHooks()
+	assert.Nil(t, TagsToProto(Group, nil))
+	assert.Nil(t, TagsFromProto(Group, nil))
+	assert.Nil(t, TagsFromProto(Group, TagsToProto(Group, nil)))
+
+	// Hooks that leave the tags empty keep them nil
+	RegisterToProtoTagsHook(func(resourceType string, t map[string]*string) map[string]*string {
+		delete(t, "secret")
+		return t
+	})
+	assert.Nil(t, TagsToProto(Group, nil))
+
+	// Tags added by a hook are kept
+	owner := "team"
+	RegisterFromProtoTagsHook(func(resourceType string, t map[string]*string) map[string]*string {
+		t["owner"] = &owner
+		return t
+	})
+	fromProto := TagsFromProto(Group, nil)
+	assert.Equal(t, owner, *fromProto["owner"])
+}
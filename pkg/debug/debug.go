@@ -0,0 +1,118 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+// Package debug captures the requests the sdk would send to the agent without sending them,
+// so that bug reports can include the exact payloads.
+package debug
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// Format is the encoding of dumped requests
+type Format string
+
+const (
+	// Text dumps requests in the protobuf text format
+	Text Format = "text"
+	// JSON dumps requests in the protobuf JSON mapping
+	JSON Format = "json"
+)
+
+// ErrDryRun is returned by the calls made with a dry run context instead of sending the request to the agent
+var ErrDryRun = errors.New("dry run: request was captured and not sent to the agent")
+
+// Request is a request captured in a dry run
+type Request struct {
+	// Method - Full name of the agent method that would have been invoked
+	Method string
+	// Message - The request message
+	Message proto.Message
+}
+
+// Capture holds the requests captured in a dry run
+type Capture struct {
+	mux      sync.Mutex
+	requests []Request
+}
+
+type captureKey struct{}
+
+// WithDryRun returns a context making calls to the agent capture their request and fail with ErrDryRun
+// instead of sending it. As the first call to the agent fails, operations that call the agent more than
+// once, such as a Get before an update, only capture their first request.
+func WithDryRun(ctx context.Context) (context.Context, *Capture) {
+	capture := &Capture{}
+	return context.WithValue(ctx, captureKey{}, capture), capture
+}
+
+// CaptureFromContext returns the capture of a dry run context, or nil if ctx is not a dry run context
+func CaptureFromContext(ctx context.Context) *Capture {
+	capture, _ := ctx.Value(captureKey{}).(*Capture)
+	return capture
+}
+
+// Record adds the request sent to method to the capture
+func (c *Capture) Record(method string, req interface{}) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.requests = append(c.requests, Request{Method: method, Message: toMessage(req)})
+}
+
+// Requests returns the captured requests in the order they were made. The requests are not redacted.
+func (c *Capture) Requests() []Request {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return append([]Request{}, c.requests...)
+}
+
+// Dump returns the captured requests in the format, each preceded by the name of its method
+func (c *Capture) Dump(format Format) (string, error) {
+	var b strings.Builder
+	for _, req := range c.Requests() {
+		out, err := Dump(req.Message, format)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "# %s\n%s\n", req.Method, out)
+	}
+	return b.String(), nil
+}
+
+// Dump encodes msg in the format, with its sensitive fields redacted. msg may be a message of either protobuf api.
+func Dump(msg interface{}, format Format) (string, error) {
+	m := Redact(msg)
+	if m == nil {
+		return "", fmt.Errorf("%T is not a protobuf message", msg)
+	}
+	switch format {
+	case Text:
+		out, err := prototext.MarshalOptions{Multiline: true}.Marshal(m)
+		return string(out), err
+	case JSON:
+		out, err := protojson.MarshalOptions{Multiline: true}.Marshal(m)
+		return string(out), err
+	default:
+		return "", fmt.Errorf("unknown dump format %q", format)
+	}
+}
+
+func toMessage(msg interface{}) proto.Message {
+	switch m := msg.(type) {
+	case proto.Message:
+		return m
+	case protov1.Message:
+		return protov1.MessageV2(m)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package debug
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func Test_Capture(t *testing.T) {
+	if CaptureFromContext(context.Background()) != nil {
+		t.Fatalf("Test_Capture failed: background context is not a dry run context")
+	}
+
+	ctx, capture := WithDryRun(context.Background())
+	if CaptureFromContext(ctx) != capture {
+		t.Fatalf("Test_Capture failed: capture not found in the dry run context")
+	}
+
+	req, _ := structpb.NewStruct(map[string]interface{}{"name": "vm1"})
+	capture.Record("/moc.cloudagent.compute.VirtualMachineAgent/Invoke", req)
+
+	for _, format := range []Format{Text, JSON} {
+		out, err := capture.Dump(format)
+		if err != nil {
+			t.Fatalf("Test_Capture failed: %v", err)
+		}
+		if !strings.Contains(out, "VirtualMachineAgent/Invoke") || !strings.Contains(out, "vm1") {
+			t.Fatalf("Test_Capture failed: unexpected %s dump %q", format, out)
+		}
+	}
+
+	if _, err := Dump("not a message", Text); err == nil {
+		t.Fatalf("Test_Capture failed: expected an error dumping a non protobuf value")
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package debug
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Redacted replaces the value of sensitive fields
+const Redacted = "[REDACTED]"
+
+// DefaultSensitiveFields are the field name fragments redacted unless SetSensitiveFields is called
+var DefaultSensitiveFields = []string{"password", "secret", "credential", "customdata", "privatekey", "token"}
+
+var (
+	sensitiveMux     sync.RWMutex
+	sensitiveFields  []string
+	sensitivePattern *regexp.Regexp
+)
+
+func init() {
+	SetSensitiveFields(DefaultSensitiveFields...)
+}
+
+// SetSensitiveFields replaces the field name fragments whose values are redacted from error messages
+// and dumps. A field is sensitive if its name, lower cased and without underscores, contains a fragment.
+// Calling SetSensitiveFields with no fragments disables redaction.
+func SetSensitiveFields(fragments ...string) {
+	normalized := []string{}
+	quoted := []string{}
+	for _, f := range fragments {
+		f = normalizeFieldName(f)
+		if len(f) == 0 {
+			continue
+		}
+		normalized = append(normalized, f)
+		quoted = append(quoted, regexp.QuoteMeta(f))
+	}
+
+	var pattern *regexp.Regexp
+	if len(quoted) > 0 {
+		// Matches name:"value", "name": "value" and name=value, as printed by the protobuf text and
+		// JSON formats and by fmt, where name contains a fragment.
+		pattern = regexp.MustCompile(`(?i)("?\w*(?:` + strings.Join(quoted, "|") + `)\w*"?\s*[:=]\s*)("(?:[^"\\]|\\.)*"|[^\s,}\]]+)`)
+	}
+
+	sensitiveMux.Lock()
+	defer sensitiveMux.Unlock()
+	sensitiveFields = normalized
+	sensitivePattern = pattern
+}
+
+// IsSensitiveField returns true if the values of the field are redacted
+func IsSensitiveField(name string) bool {
+	name = normalizeFieldName(name)
+	sensitiveMux.RLock()
+	defer sensitiveMux.RUnlock()
+	for _, f := range sensitiveFields {
+		if strings.Contains(name, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sanitize returns s with the values of sensitive fields redacted
+func Sanitize(s string) string {
+	sensitiveMux.RLock()
+	pattern := sensitivePattern
+	sensitiveMux.RUnlock()
+	if pattern == nil {
+		return s
+	}
+	return pattern.ReplaceAllString(s, `${1}"`+Redacted+`"`)
+}
+
+// SanitizeError returns an error with the message of err sanitized. The returned error unwraps to err.
+func SanitizeError(err error) error {
+	if err == nil {
+		return nil
+	}
+	msg := Sanitize(err.Error())
+	if msg == err.Error() {
+		return err
+	}
+	return &sanitizedError{err: err, msg: msg}
+}
+
+type sanitizedError struct {
+	err error
+	msg string
+}
+
+func (e *sanitizedError) Error() string { return e.msg }
+func (e *sanitizedError) Unwrap() error { return e.err }
+
+// Redact returns a copy of msg with its sensitive string and bytes fields set to Redacted and its
+// sensitive message fields cleared. msg may be a message of either protobuf api.
+func Redact(msg interface{}) proto.Message {
+	m := toMessage(msg)
+	if m == nil {
+		return nil
+	}
+	m = proto.Clone(m)
+	redactMessage(m.ProtoReflect())
+	return m
+}
+
+func redactMessage(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if IsSensitiveField(string(fd.Name())) {
+			redactField(m, fd, v)
+			return true
+		}
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redactMessage(list.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				redactMessage(mv.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Message() != nil:
+			redactMessage(v.Message())
+		}
+		return true
+	})
+}
+
+func redactField(m protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) {
+	switch {
+	case fd.IsList() && fd.Kind() == protoreflect.StringKind:
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			list.Set(i, protoreflect.ValueOfString(Redacted))
+		}
+	case fd.IsList() || fd.IsMap() || fd.Message() != nil:
+		m.Clear(fd)
+	case fd.Kind() == protoreflect.StringKind:
+		m.Set(fd, protoreflect.ValueOfString(Redacted))
+	case fd.Kind() == protoreflect.BytesKind:
+		m.Set(fd, protoreflect.ValueOfBytes([]byte(Redacted)))
+	}
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "")
+}
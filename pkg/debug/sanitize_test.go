@@ -0,0 +1,84 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package debug
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func getTestOsProfile(t *testing.T) *dynamicpb.Message {
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("sanitize_test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("OperatingSystemConfiguration"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("computerName"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), JsonName: proto.String("computerName")},
+				{Name: proto.String("adminPassword"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), JsonName: proto.String("adminPassword")},
+				{Name: proto.String("custom_data"), Number: proto.Int32(3), Type: descriptorpb.FieldDescriptorProto_TYPE_BYTES.Enum(), Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), JsonName: proto.String("customData")},
+			},
+		}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed building the test descriptor: %v", err)
+	}
+	desc := file.Messages().Get(0)
+	msg := dynamicpb.NewMessage(desc)
+	msg.Set(desc.Fields().ByName("computerName"), protoreflect.ValueOfString("vm1"))
+	msg.Set(desc.Fields().ByName("adminPassword"), protoreflect.ValueOfString("p@ssw0rd"))
+	msg.Set(desc.Fields().ByName("custom_data"), protoreflect.ValueOfBytes([]byte("#cloud-config")))
+	return msg
+}
+
+func Test_Redact(t *testing.T) {
+	msg := getTestOsProfile(t)
+
+	for _, format := range []Format{Text, JSON} {
+		out, err := Dump(msg, format)
+		if err != nil {
+			t.Fatalf("Test_Redact failed: %v", err)
+		}
+		if !strings.Contains(out, "vm1") || strings.Contains(out, "p@ssw0rd") || strings.Contains(out, "cloud-config") {
+			t.Fatalf("Test_Redact failed: unexpected %s dump %q", format, out)
+		}
+	}
+
+	// The original message is left unchanged
+	password := msg.Get(msg.Descriptor().Fields().ByName("adminPassword")).String()
+	if password != "p@ssw0rd" {
+		t.Fatalf("Test_Redact failed: the original message was modified")
+	}
+}
+
+func Test_SanitizeError(t *testing.T) {
+	defer SetSensitiveFields(DefaultSensitiveFields...)
+
+	cause := errors.New(`Invalid request: osProfile:{computerName:"vm1" adminPassword:"p@ss \"w0rd\""} {"clientSecret": "abc"} token=xyz`)
+	err := SanitizeError(cause)
+	if !errors.Is(err, cause) {
+		t.Fatalf("Test_SanitizeError failed: sanitized error does not unwrap to its cause")
+	}
+	for _, secret := range []string{"p@ss", "w0rd", "abc", "xyz"} {
+		if strings.Contains(err.Error(), secret) {
+			t.Fatalf("Test_SanitizeError failed: %q found in %q", secret, err.Error())
+		}
+	}
+	if !strings.Contains(err.Error(), `computerName:"vm1"`) {
+		t.Fatalf("Test_SanitizeError failed: unexpected message %q", err.Error())
+	}
+
+	SetSensitiveFields()
+	if SanitizeError(cause) != cause {
+		t.Fatalf("Test_SanitizeError failed: error sanitized with redaction disabled")
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+// Package deprecation warns once per process when a deprecated method or field of the sdk is used, so that
+// consumers learn about the replacement before the deprecated api is removed.
+package deprecation
+
+import (
+	"fmt"
+	"sync"
+
+	log "k8s.io/klog"
+)
+
+// Warning describes the use of a deprecated method or field
+type Warning struct {
+	// Service - Service the api belongs to, e.g. compute/virtualmachine
+	Service string `json:"service"`
+	// Name - Deprecated method or field, e.g. VirtualMachineClient.Query
+	Name string `json:"name"`
+	// Since - Sdk version the api was deprecated in
+	Since string `json:"since,omitempty"`
+	// Replacement - Api to use instead, empty if the api is removed without replacement
+	Replacement string `json:"replacement,omitempty"`
+	// Message - Additional migration guidance
+	Message string `json:"message,omitempty"`
+}
+
+func (w Warning) String() string {
+	s := fmt.Sprintf("%s: %s is deprecated", w.Service, w.Name)
+	if len(w.Since) > 0 {
+		s += " since " + w.Since
+	}
+	if len(w.Replacement) > 0 {
+		s += ", use " + w.Replacement + " instead"
+	}
+	if len(w.Message) > 0 {
+		s += ". " + w.Message
+	}
+	return s
+}
+
+// Handler receives the deprecation warnings
+type Handler func(Warning)
+
+// LogHandler logs warnings with klog. It is the default handler.
+func LogHandler(w Warning) {
+	log.Warningf("[Deprecation] %s", w)
+}
+
+var (
+	mux     sync.Mutex
+	handler Handler = LogHandler
+	warned          = map[string]bool{}
+)
+
+// SetHandler sets the handler receiving the deprecation warnings. A nil handler disables the warnings.
+func SetHandler(h Handler) {
+	mux.Lock()
+	defer mux.Unlock()
+	handler = h
+}
+
+// Warn reports the use of a deprecated api to the handler, the first time it is used in the process
+func Warn(w Warning) {
+	mux.Lock()
+	key := w.Service + "/" + w.Name
+	if warned[key] || handler == nil {
+		mux.Unlock()
+		return
+	}
+	warned[key] = true
+	h := handler
+	mux.Unlock()
+
+	h(w)
+}
+
+// Reset forgets the warnings already reported, so that they are reported again
+func Reset() {
+	mux.Lock()
+	defer mux.Unlock()
+	warned = map[string]bool{}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package deprecation
+
+import (
+	"testing"
+)
+
+func Test_Warn(t *testing.T) {
+	defer SetHandler(LogHandler)
+	defer Reset()
+
+	warnings := []Warning{}
+	SetHandler(func(w Warning) { warnings = append(warnings, w) })
+
+	w := Warning{Service: "compute/virtualmachine", Name: "VirtualMachineClient.Query", Since: "v0.20.0", Replacement: "VirtualMachineClient.List"}
+	Warn(w)
+	Warn(w)
+	if len(warnings) != 1 {
+		t.Fatalf("Test_Warn failed: expected a single warning, got %d", len(warnings))
+	}
+	if s := warnings[0].String(); s != "compute/virtualmachine: VirtualMachineClient.Query is deprecated since v0.20.0, use VirtualMachineClient.List instead" {
+		t.Fatalf("Test_Warn failed: unexpected warning %q", s)
+	}
+
+	Reset()
+	Warn(w)
+	if len(warnings) != 2 {
+		t.Fatalf("Test_Warn failed: expected the warning to be reported again after Reset")
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package healthserver
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/services/admin/health"
+	"github.com/microsoft/moc/pkg/auth"
+	"github.com/microsoft/moc/pkg/marshal"
+)
+
+const (
+	// LivenessPath reports whether the process is alive
+	LivenessPath = "/livez"
+	// ReadinessPath reports whether the process can serve, including agent connectivity
+	ReadinessPath = "/readyz"
+
+	defaultCheckTimeout = 10 * time.Second
+)
+
+// Check returns an error if the component it checks is unhealthy
+type Check func(ctx context.Context) error
+
+// Report is the body returned by the health endpoints
+type Report struct {
+	Healthy bool              `json:"healthy"`
+	Checks  map[string]string `json:"checks,omitempty"`
+}
+
+// Server serves liveness and readiness endpoints for processes embedding the SDK
+type Server struct {
+	// CheckTimeout - Time allowed for all the checks of a request to complete
+	CheckTimeout time.Duration
+
+	mux        sync.RWMutex
+	liveness   map[string]Check
+	readiness  map[string]Check
+	httpServer *http.Server
+}
+
+// NewServer returns a server listening on address once started
+func NewServer(address string) *Server {
+	s := &Server{
+		CheckTimeout: defaultCheckTimeout,
+		liveness:     map[string]Check{},
+		readiness:    map[string]Check{},
+	}
+	s.httpServer = &http.Server{Addr: address, Handler: s.Handler(), ReadHeaderTimeout: defaultCheckTimeout}
+	return s
+}
+
+// AddLivenessCheck adds a check to the liveness endpoint
+func (s *Server) AddLivenessCheck(name string, check Check) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.liveness[name] = check
+}
+
+// AddReadinessCheck adds a check to the readiness endpoint
+func (s *Server) AddReadinessCheck(name string, check Check) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.readiness[name] = check
+}
+
+// Handler returns the handler serving the liveness and readiness endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(LivenessPath, func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, r, s.liveness)
+	})
+	mux.HandleFunc(ReadinessPath, func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, r, s.readiness)
+	})
+	return mux
+}
+
+// ListenAndServe serves the endpoints until the server is shut down
+func (s *Server) ListenAndServe() error {
+	err := s.httpServer.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops the server
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, checks map[string]Check) {
+	s.mux.RLock()
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	selected := make([]Check, len(names))
+	sort.Strings(names)
+	for i, name := range names {
+		selected[i] = checks[name]
+	}
+	s.mux.RUnlock()
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.CheckTimeout)
+	defer cancel()
+
+	report := Report{Healthy: true, Checks: map[string]string{}}
+	for i, name := range names {
+		if err := selected[i](ctx); err != nil {
+			report.Healthy = false
+			report.Checks[name] = err.Error()
+		} else {
+			report.Checks[name] = "ok"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if report.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
+
+// AgentConnectivityCheck returns a check that fails when the cloud agent does not report healthy
+func AgentConnectivityCheck(cloudFQDN string, authorizer auth.Authorizer) Check {
+	return func(ctx context.Context) error {
+		client, err := health.NewHealthClient(cloudFQDN, authorizer)
+		if err != nil {
+			return err
+		}
+		timeoutSeconds := uint32(defaultCheckTimeout / time.Second)
+		if deadline, ok := ctx.Deadline(); ok {
+			if remaining := uint32(time.Until(deadline) / time.Second); remaining > 0 {
+				timeoutSeconds = remaining
+			}
+		}
+		return client.CheckHealth(ctx, timeoutSeconds)
+	}
+}
+
+// CertificateCheck returns a check that fails when the PEM encoded certificate returned by load
+// expires within minRemaining
+func CertificateCheck(minRemaining time.Duration, load func() ([]byte, error)) Check {
+	return func(ctx context.Context) error {
+		data, err := load()
+		if err != nil {
+			return err
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return fmt.Errorf("certificate is not PEM encoded")
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if now.Before(certificate.NotBefore) {
+			return fmt.Errorf("certificate is not valid before %s", certificate.NotBefore.UTC().Format(time.RFC3339))
+		}
+		if now.Add(minRemaining).After(certificate.NotAfter) {
+			return fmt.Errorf("certificate expires at %s", certificate.NotAfter.UTC().Format(time.RFC3339))
+		}
+		return nil
+	}
+}
+
+// WssdConfigCertificateCheck returns a check that fails when the client certificate of the moc
+// configuration file expires within minRemaining
+func WssdConfigCertificateCheck(minRemaining time.Duration) Check {
+	return CertificateCheck(minRemaining, func() ([]byte, error) {
+		wssdConfig := auth.WssdConfig{}
+		if err := marshal.FromJSONFile(auth.GetWssdConfigLocation(), &wssdConfig); err != nil {
+			return nil, err
+		}
+		pemCert, err := marshal.FromBase64(wssdConfig.ClientCertificate)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(pemCert), nil
+	})
+}
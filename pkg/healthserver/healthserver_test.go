@@ -0,0 +1,34 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package healthserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Server(t *testing.T) {
+	s := NewServer("localhost:0")
+	s.AddLivenessCheck("process", func(ctx context.Context) error { return nil })
+	s.AddReadinessCheck("agent", func(ctx context.Context) error { return fmt.Errorf("agent unreachable") })
+	handler := s.Handler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, LivenessPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ReadinessPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	report := Report{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.False(t, report.Healthy)
+	assert.Equal(t, "agent unreachable", report.Checks["agent"])
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package list
+
+import (
+	"context"
+)
+
+// PageFunc fetches the page of results following the continuation token. An
+// empty token requests the first page and an empty next token ends the listing.
+type PageFunc[T any] func(ctx context.Context, token string) (items []T, next string, err error)
+
+// Lister iterates over the results of a list call, fetching pages as needed
+//
+//	it := client.NewLister(group)
+//	for it.Next(ctx) {
+//		item := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Lister[T any] struct {
+	fetch   PageFunc[T]
+	items   []T
+	index   int
+	token   string
+	started bool
+	done    bool
+	err     error
+}
+
+// NewLister returns a lister that fetches pages using fetch
+func NewLister[T any](fetch PageFunc[T]) *Lister[T] {
+	return &Lister[T]{fetch: fetch, index: -1}
+}
+
+// NewSinglePageLister returns a lister over a list call that returns all
+// results at once, such as the service Get calls made with an empty name
+func NewSinglePageLister[T any](get func(ctx context.Context) (*[]T, error)) *Lister[T] {
+	return NewLister(func(ctx context.Context, token string) ([]T, string, error) {
+		items, err := get(ctx)
+		if err != nil || items == nil {
+			return nil, "", err
+		}
+		return *items, "", nil
+	})
+}
+
+// Next advances to the next item, fetching the next page if required. It
+// returns false once all items were returned or an error occurred.
+func (l *Lister[T]) Next(ctx context.Context) bool {
+	if l.err != nil {
+		return false
+	}
+	l.index++
+	for l.index >= len(l.items) {
+		if l.done {
+			return false
+		}
+		if l.started && len(l.token) == 0 {
+			l.done = true
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			l.err = err
+			return false
+		}
+		items, next, err := l.fetch(ctx, l.token)
+		if err != nil {
+			l.err = err
+			return false
+		}
+		l.started = true
+		l.items, l.index, l.token = items, 0, next
+		if len(next) == 0 {
+			l.done = len(items) == 0
+		}
+	}
+	return true
+}
+
+// Item returns the current item
+func (l *Lister[T]) Item() T {
+	return l.items[l.index]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (l *Lister[T]) Err() error {
+	return l.err
+}
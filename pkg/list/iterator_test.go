@@ -0,0 +1,44 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package list
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func Test_ListerPages(t *testing.T) {
+	pages := map[string][]int{"": {1, 2}, "p2": {}, "p3": {3}}
+	next := map[string]string{"": "p2", "p2": "p3", "p3": ""}
+	it := NewLister(func(ctx context.Context, token string) ([]int, string, error) {
+		return pages[token], next[token], nil
+	})
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Item())
+	}
+	if it.Err() != nil {
+		t.Fatalf("Test_ListerPages failed: %v", it.Err())
+	}
+	if fmt.Sprint(got) != "[1 2 3]" {
+		t.Fatalf("Test_ListerPages failed: got %v", got)
+	}
+	if it.Next(context.Background()) {
+		t.Fatalf("Test_ListerPages failed: Next should keep returning false once done")
+	}
+}
+
+func Test_SinglePageListerError(t *testing.T) {
+	it := NewSinglePageLister(func(ctx context.Context) (*[]string, error) {
+		return nil, fmt.Errorf("agent unavailable")
+	})
+	if it.Next(context.Background()) {
+		t.Fatalf("Test_SinglePageListerError failed: Next should return false on error")
+	}
+	if it.Err() == nil {
+		t.Fatalf("Test_SinglePageListerError failed: expected an error")
+	}
+}
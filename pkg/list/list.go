@@ -0,0 +1,218 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+// Package list applies sorting and projection to the results of the List methods
+// of the service clients. The agent requests have no sort or projection
+// settings, so the agent always returns the full resources and both are applied
+// to the returned slice on the client.
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// SortByName - Sort resources by their name
+	SortByName = "name"
+)
+
+// Options controls how a list of resources is returned to the caller. Field
+// paths are the dotted json names of the fields, e.g. "name" or
+// "properties.provisioningState".
+type Options struct {
+	// SortBy - Field path to sort by, resources keep the agent order if empty
+	SortBy string
+	// Descending - Sort in descending order
+	Descending bool
+	// Select - Field paths to keep when projecting, all fields are kept if empty
+	Select []string
+}
+
+// Sort sorts the slice pointed to by items by the field given in the options.
+// Resources missing the field are placed last.
+func Sort(items interface{}, opts Options) error {
+	if len(opts.SortBy) == 0 {
+		return nil
+	}
+	slice, err := getSlice(items)
+	if err != nil {
+		return err
+	}
+
+	n := slice.Len()
+	keys := make([]interface{}, n)
+	for i := 0; i < n; i++ {
+		fields, err := toMap(slice.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+		keys[i], _ = lookup(fields, opts.SortBy)
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ka, kb := keys[order[a]], keys[order[b]]
+		if ka == nil || kb == nil {
+			return ka != nil
+		}
+		if opts.Descending {
+			return less(kb, ka)
+		}
+		return less(ka, kb)
+	})
+
+	sorted := reflect.MakeSlice(slice.Type(), n, n)
+	for i, j := range order {
+		sorted.Index(i).Set(slice.Index(j))
+	}
+	reflect.Copy(slice, sorted)
+	return nil
+}
+
+// Project returns the selected fields of each resource in items, keyed by their
+// field path
+func Project(items interface{}, fields []string) ([]map[string]interface{}, error) {
+	slice, err := getSlice(items)
+	if err != nil {
+		return nil, err
+	}
+
+	projected := make([]map[string]interface{}, 0, slice.Len())
+	for i := 0; i < slice.Len(); i++ {
+		values, err := toMap(slice.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			projected = append(projected, values)
+			continue
+		}
+		item := map[string]interface{}{}
+		for _, field := range fields {
+			if value, ok := lookup(values, field); ok {
+				item[field] = value
+			}
+		}
+		projected = append(projected, item)
+	}
+	return projected, nil
+}
+
+// Apply sorts the resources returned by a List method and clears the fields of
+// each resource that are not selected, applying each of the options in turn
+func Apply[T any](items *[]T, opts ...Options) (*[]T, error) {
+	if items == nil {
+		return nil, nil
+	}
+	for _, o := range opts {
+		if err := Sort(items, o); err != nil {
+			return nil, err
+		}
+		if err := selectFields(items, o.Select); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+// selectFields replaces each resource with a copy holding only the fields at
+// the paths
+func selectFields[T any](items *[]T, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	for i := range *items {
+		values, err := toMap((*items)[i])
+		if err != nil {
+			return err
+		}
+		selected := map[string]interface{}{}
+		for _, path := range paths {
+			if value, ok := lookup(values, path); ok {
+				set(selected, path, value)
+			}
+		}
+		data, err := json.Marshal(selected)
+		if err != nil {
+			return err
+		}
+		var item T
+		if err := json.Unmarshal(data, &item); err != nil {
+			return err
+		}
+		(*items)[i] = item
+	}
+	return nil
+}
+
+func getSlice(items interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("Expected a pointer to a slice, got %T", items)
+	}
+	return v.Elem(), nil
+}
+
+func toMap(item interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func lookup(fields map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = fields
+	for _, part := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, current != nil
+}
+
+func set(fields map[string]interface{}, path string, value interface{}) {
+	parts := strings.Split(path, ".")
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := fields[part].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			fields[part] = next
+		}
+		fields = next
+	}
+	fields[parts[len(parts)-1]] = value
+}
+
+func less(a, b interface{}) bool {
+	switch av := a.(type) {
+	case string:
+		if bv, ok := b.(string); ok {
+			return lessString(av, bv)
+		}
+	case float64:
+		if bv, ok := b.(float64); ok {
+			return av < bv
+		}
+	case bool:
+		if bv, ok := b.(bool); ok {
+			return !av && bv
+		}
+	}
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package list
+
+import (
+	"testing"
+)
+
+type TestProperties struct {
+	ProvisioningState *string `json:"provisioningState,omitempty"`
+}
+
+type testResource struct {
+	Name            *string `json:"name,omitempty"`
+	*TestProperties `json:"properties,omitempty"`
+	Tags            map[string]*string `json:"tags"`
+}
+
+func newTestResource(name, state string) testResource {
+	return testResource{Name: &name, TestProperties: &TestProperties{ProvisioningState: &state}}
+}
+
+func Test_Sort(t *testing.T) {
+	items := []testResource{newTestResource("vm2", "Failed"), newTestResource("VM1", "Succeeded"), {}, newTestResource("vm3", "Creating")}
+	if err := Sort(&items, Options{SortBy: SortByName}); err != nil {
+		t.Fatalf("Test_Sort failed: %v", err)
+	}
+	expected := []string{"VM1", "vm2", "vm3"}
+	for i, name := range expected {
+		if *items[i].Name != name {
+			t.Fatalf("Test_Sort failed: expected %s at %d, got %s", name, i, *items[i].Name)
+		}
+	}
+	if items[3].Name != nil {
+		t.Fatalf("Test_Sort failed: resource without a name should be last")
+	}
+
+	if err := Sort(&items, Options{SortBy: "properties.provisioningState", Descending: true}); err != nil {
+		t.Fatalf("Test_Sort failed: %v", err)
+	}
+	expected = []string{"Succeeded", "Failed", "Creating"}
+	for i, state := range expected {
+		if *items[i].ProvisioningState != state {
+			t.Fatalf("Test_Sort failed: expected %s at %d, got %s", state, i, *items[i].ProvisioningState)
+		}
+	}
+
+	if err := Sort(items, Options{SortBy: SortByName}); err == nil {
+		t.Fatalf("Test_Sort failed: sorting a slice value should return an error")
+	}
+}
+
+func Test_Project(t *testing.T) {
+	items := []testResource{newTestResource("vm1", "Succeeded")}
+	projected, err := Project(&items, []string{"name", "properties.provisioningState", "missing"})
+	if err != nil {
+		t.Fatalf("Test_Project failed: %v", err)
+	}
+	if len(projected) != 1 || len(projected[0]) != 2 {
+		t.Fatalf("Test_Project failed: unexpected projection %v", projected)
+	}
+	if projected[0]["properties.provisioningState"] != "Succeeded" {
+		t.Fatalf("Test_Project failed: unexpected projection %v", projected)
+	}
+}
+
+func Test_Apply(t *testing.T) {
+	items := []testResource{newTestResource("vm2", "Failed"), newTestResource("vm1", "Succeeded")}
+	items[0].Tags = map[string]*string{}
+	result, err := Apply(&items, Options{SortBy: SortByName, Select: []string{"name", "properties.provisioningState"}})
+	if err != nil {
+		t.Fatalf("Test_Apply failed: %v", err)
+	}
+	if len(*result) != 2 || *(*result)[0].Name != "vm1" || *(*result)[1].ProvisioningState != "Failed" {
+		t.Fatalf("Test_Apply failed: unexpected result %v", *result)
+	}
+	if (*result)[1].Tags != nil {
+		t.Fatalf("Test_Apply failed: unselected field kept")
+	}
+
+	if result, err := Apply[testResource](nil); result != nil || err != nil {
+		t.Fatalf("Test_Apply failed: expected nil for a nil list")
+	}
+}
+
+func Test_SortAgreesWithSortResponse(t *testing.T) {
+	names := []string{"vm2", "VM1", "vm1", "Vm3"}
+	byResponse := []testResource{}
+	for _, name := range names {
+		byResponse = append(byResponse, newTestResource(name, ""))
+	}
+	bySort := append([]testResource{}, byResponse...)
+	SortResponse(&byResponse)
+	if err := Sort(&bySort, Options{SortBy: SortByName}); err != nil {
+		t.Fatalf("Test_SortAgreesWithSortResponse failed: %v", err)
+	}
+	for i := range names {
+		if *byResponse[i].Name != *bySort[i].Name {
+			t.Fatalf("Test_SortAgreesWithSortResponse failed: %s and %s at %d", *byResponse[i].Name, *bySort[i].Name, i)
+		}
+	}
+	if *bySort[0].Name != "VM1" || *bySort[1].Name != "vm1" {
+		t.Fatalf("Test_SortAgreesWithSortResponse failed: unexpected order")
+	}
+}
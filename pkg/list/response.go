@@ -0,0 +1,107 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package list
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+var sortResponsesDisabled atomic.Bool
+
+// SetSortResponses controls whether the collections returned by the agent are sorted by
+// name before being returned to the caller. Sorting is enabled by default.
+func SetSortResponses(enabled bool) {
+	sortResponsesDisabled.Store(!enabled)
+}
+
+// SortResponsesEnabled returns true if the collections returned by the agent are sorted by name
+func SortResponsesEnabled() bool {
+	return !sortResponsesDisabled.Load()
+}
+
+// SortResponse sorts the slice, or pointer to a slice, of resources by their Name field when
+// sorting of responses is enabled. Names are compared as by Sort with SortByName, and resources
+// without a name are placed last. Slices of types without a Name field are left unchanged.
+func SortResponse(items interface{}) {
+	if !SortResponsesEnabled() || items == nil {
+		return
+	}
+	slice := reflect.ValueOf(items)
+	for slice.Kind() == reflect.Ptr {
+		if slice.IsNil() {
+			return
+		}
+		slice = slice.Elem()
+	}
+	if slice.Kind() != reflect.Slice || slice.Len() < 2 || !hasNameField(slice.Type().Elem()) {
+		return
+	}
+
+	names := make([]*string, slice.Len())
+	for i := range names {
+		names[i] = getName(slice.Index(i))
+	}
+	swap := reflect.Swapper(slice.Interface())
+	sort.Stable(&nameSorter{names: names, swap: swap})
+}
+
+type nameSorter struct {
+	names []*string
+	swap  func(i, j int)
+}
+
+func (s *nameSorter) Len() int {
+	return len(s.names)
+}
+
+func (s *nameSorter) Less(i, j int) bool {
+	a, b := s.names[i], s.names[j]
+	if a == nil || b == nil {
+		return a != nil
+	}
+	return lessString(*a, *b)
+}
+
+func (s *nameSorter) Swap(i, j int) {
+	s.names[i], s.names[j] = s.names[j], s.names[i]
+	s.swap(i, j)
+}
+
+// lessString orders strings case insensitively, as resource names are, and strings that differ
+// only in case by their bytes so that the order does not depend on the order of the agent
+func lessString(a, b string) bool {
+	la, lb := strings.ToLower(a), strings.ToLower(b)
+	if la != lb {
+		return la < lb
+	}
+	return a < b
+}
+
+func hasNameField(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	field, ok := t.FieldByName("Name")
+	return ok && field.Type == reflect.TypeOf((*string)(nil))
+}
+
+func getName(v reflect.Value) *string {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	field := v.FieldByName("Name")
+	if !field.IsValid() || field.IsNil() {
+		return nil
+	}
+	return field.Interface().(*string)
+}
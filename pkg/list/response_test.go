@@ -0,0 +1,25 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package list
+
+import (
+	"testing"
+)
+
+func Test_SortResponse(t *testing.T) {
+	b, a, c := "b", "a", "c"
+	items := []testResource{{Name: &b}, {}, {Name: &c}, {Name: &a}}
+	SortResponse(&items)
+	if items[0].Name != &a || items[1].Name != &b || items[2].Name != &c || items[3].Name != nil {
+		t.Fatalf("Test_SortResponse failed: unexpected order")
+	}
+
+	SetSortResponses(false)
+	defer SetSortResponses(true)
+	items = []testResource{{Name: &b}, {Name: &a}}
+	SortResponse(&items)
+	if items[0].Name != &b {
+		t.Fatalf("Test_SortResponse failed: sorted while disabled")
+	}
+}
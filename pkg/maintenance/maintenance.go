@@ -0,0 +1,202 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+// Package maintenance restricts disruptive operations of the sdk, such as stopping, restarting, deleting and
+// draining, to maintenance windows. The agent has no resource to store the windows in, so the policies are held in
+// memory and apply to every client of the process. Each process enforcing a change control sets its own policies.
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Window is a recurring period during which disruptive operations are allowed
+type Window struct {
+	// Weekdays - Days the window opens on. An empty list opens the window every day.
+	Weekdays []time.Weekday
+	// Start - Offset from midnight at which the window opens
+	Start time.Duration
+	// Duration - Length of the window
+	Duration time.Duration
+	// Location - Time zone the window is defined in. Defaults to UTC.
+	Location *time.Location
+}
+
+// Policy restricts disruptive operations to its maintenance windows
+type Policy struct {
+	Windows []Window
+}
+
+// OutsideWindowError is returned when a disruptive operation is attempted outside the maintenance windows
+type OutsideWindowError struct {
+	Operation string
+	Scope     string
+	NextStart time.Time
+}
+
+func (e *OutsideWindowError) Error() string {
+	if e.NextStart.IsZero() {
+		return fmt.Sprintf("Operation %s is not allowed outside the maintenance windows of %s", e.Operation, e.Scope)
+	}
+	return fmt.Sprintf("Operation %s is not allowed outside the maintenance windows of %s, next window opens at %s", e.Operation, e.Scope, e.NextStart.Format(time.RFC3339))
+}
+
+type overrideKey struct{}
+
+var (
+	mux              sync.RWMutex
+	groupPolicies    = map[string]*Policy{}
+	locationPolicies = map[string]*Policy{}
+	now              = time.Now
+)
+
+// SetGroupPolicy sets the maintenance policy of a group. A nil policy removes it.
+func SetGroupPolicy(group string, policy *Policy) {
+	setPolicy(groupPolicies, group, policy)
+}
+
+// SetLocationPolicy sets the maintenance policy of a location. A nil policy removes it.
+func SetLocationPolicy(location string, policy *Policy) {
+	setPolicy(locationPolicies, location, policy)
+}
+
+// ClearPolicies removes all maintenance policies
+func ClearPolicies() {
+	mux.Lock()
+	defer mux.Unlock()
+	groupPolicies = map[string]*Policy{}
+	locationPolicies = map[string]*Policy{}
+}
+
+// Enabled returns true if any maintenance policy is set
+func Enabled() bool {
+	mux.RLock()
+	defer mux.RUnlock()
+	return len(groupPolicies) > 0 || len(locationPolicies) > 0
+}
+
+// WithOverride returns a context that bypasses maintenance window checks
+func WithOverride(ctx context.Context) context.Context {
+	return context.WithValue(ctx, overrideKey{}, true)
+}
+
+// IsOverridden returns true if the context bypasses maintenance window checks
+func IsOverridden(ctx context.Context) bool {
+	overridden, _ := ctx.Value(overrideKey{}).(bool)
+	return overridden
+}
+
+// Check returns an OutsideWindowError if the operation is not allowed now in the group or location.
+// A group policy takes precedence over the policy of its location.
+func Check(ctx context.Context, group, location, operation string) error {
+	if IsOverridden(ctx) {
+		return nil
+	}
+
+	mux.RLock()
+	policy, scope := groupPolicies[strings.ToLower(group)], "group "+group
+	if policy == nil {
+		policy, scope = locationPolicies[strings.ToLower(location)], "location "+location
+	}
+	mux.RUnlock()
+	if policy == nil {
+		return nil
+	}
+
+	t := now()
+	if policy.IsOpen(t) {
+		return nil
+	}
+	return &OutsideWindowError{Operation: operation, Scope: scope, NextStart: policy.NextStart(t)}
+}
+
+// IsOutsideWindow returns true if the error is an OutsideWindowError
+func IsOutsideWindow(err error) bool {
+	_, ok := err.(*OutsideWindowError)
+	return ok
+}
+
+// IsOpen returns true if t falls within one of the policy's windows
+func (p *Policy) IsOpen(t time.Time) bool {
+	for _, w := range p.Windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStart returns the earliest time after t at which one of the policy's windows opens
+func (p *Policy) NextStart(t time.Time) time.Time {
+	var next time.Time
+	for _, w := range p.Windows {
+		if start := w.NextStart(t); !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return next
+}
+
+// Contains returns true if t falls within the window
+func (w Window) Contains(t time.Time) bool {
+	t = t.In(w.location())
+	// A window may span midnight, so the opening on the previous days is also considered
+	for days := 0; days <= int((w.Start+w.Duration)/(24*time.Hour)); days++ {
+		start := w.openingOn(t.AddDate(0, 0, -days))
+		if !start.IsZero() && !t.Before(start) && t.Before(start.Add(w.Duration)) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStart returns the first time after t at which the window opens
+func (w Window) NextStart(t time.Time) time.Time {
+	t = t.In(w.location())
+	for days := 0; days <= 7; days++ {
+		start := w.openingOn(t.AddDate(0, 0, days))
+		if !start.IsZero() && start.After(t) {
+			return start
+		}
+	}
+	return time.Time{}
+}
+
+// openingOn returns the time the window opens on the day of t, or the zero time if it does not open that day
+func (w Window) openingOn(t time.Time) time.Time {
+	if len(w.Weekdays) > 0 {
+		found := false
+		for _, day := range w.Weekdays {
+			if day == t.Weekday() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return time.Time{}
+		}
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return midnight.Add(w.Start)
+}
+
+func (w Window) location() *time.Location {
+	if w.Location == nil {
+		return time.UTC
+	}
+	return w.Location
+}
+
+func setPolicy(policies map[string]*Policy, key string, policy *Policy) {
+	mux.Lock()
+	defer mux.Unlock()
+	if policy == nil {
+		delete(policies, strings.ToLower(key))
+		return
+	}
+	policies[strings.ToLower(key)] = policy
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package maintenance
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Saturday 22:00 to Sunday 04:00
+var weekendWindow = Window{
+	Weekdays: []time.Weekday{time.Saturday},
+	Start:    22 * time.Hour,
+	Duration: 6 * time.Hour,
+}
+
+func Test_WindowContains(t *testing.T) {
+	cases := []struct {
+		at       time.Time
+		expected bool
+	}{
+		{time.Date(2024, 6, 1, 21, 59, 0, 0, time.UTC), false}, // Saturday
+		{time.Date(2024, 6, 1, 22, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 6, 2, 3, 59, 0, 0, time.UTC), true}, // Sunday
+		{time.Date(2024, 6, 2, 4, 0, 0, 0, time.UTC), false},
+		{time.Date(2024, 6, 4, 23, 0, 0, 0, time.UTC), false}, // Tuesday
+	}
+	for _, c := range cases {
+		if actual := weekendWindow.Contains(c.at); actual != c.expected {
+			t.Fatalf("Test_WindowContains failed: Contains(%s) = %v, expected %v", c.at, actual, c.expected)
+		}
+	}
+
+	next := weekendWindow.NextStart(time.Date(2024, 6, 4, 23, 0, 0, 0, time.UTC))
+	if !next.Equal(time.Date(2024, 6, 8, 22, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Test_WindowContains failed: unexpected next start %s", next)
+	}
+}
+
+func Test_Check(t *testing.T) {
+	defer ClearPolicies()
+	defer func() { now = time.Now }()
+	now = func() time.Time { return time.Date(2024, 6, 4, 23, 0, 0, 0, time.UTC) }
+
+	SetLocationPolicy("Location1", &Policy{Windows: []Window{weekendWindow}})
+	if !Enabled() {
+		t.Fatalf("Test_Check failed: expected policies to be enabled")
+	}
+
+	err := Check(context.Background(), "group1", "location1", "Restart")
+	if !IsOutsideWindow(err) {
+		t.Fatalf("Test_Check failed: expected outside window error, got %v", err)
+	}
+	if err := Check(WithOverride(context.Background()), "group1", "location1", "Restart"); err != nil {
+		t.Fatalf("Test_Check failed: override returned %v", err)
+	}
+
+	// The group policy takes precedence over the location policy
+	SetGroupPolicy("group1", &Policy{Windows: []Window{{Start: 0, Duration: 24 * time.Hour}}})
+	if err := Check(context.Background(), "group1", "location1", "Restart"); err != nil {
+		t.Fatalf("Test_Check failed: group policy returned %v", err)
+	}
+	if err := Check(context.Background(), "group2", "location2", "Restart"); err != nil {
+		t.Fatalf("Test_Check failed: unrestricted scope returned %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+// Package moc carries the target location and group of calls in their context, so that code layered over
+// the sdk does not have to pass them through every function. The service clients use the values of the
+// context when their location or group parameter is empty.
+package moc
+
+import "context"
+
+type locationKey struct{}
+
+type groupKey struct{}
+
+// WithLocation returns a context targeting the location
+func WithLocation(ctx context.Context, location string) context.Context {
+	return context.WithValue(ctx, locationKey{}, location)
+}
+
+// WithGroup returns a context targeting the group
+func WithGroup(ctx context.Context, group string) context.Context {
+	return context.WithValue(ctx, groupKey{}, group)
+}
+
+// LocationFromContext returns the location of the context, or false if the context has none
+func LocationFromContext(ctx context.Context) (string, bool) {
+	location, ok := ctx.Value(locationKey{}).(string)
+	return location, ok && len(location) > 0
+}
+
+// GroupFromContext returns the group of the context, or false if the context has none
+func GroupFromContext(ctx context.Context) (string, bool) {
+	group, ok := ctx.Value(groupKey{}).(string)
+	return group, ok && len(group) > 0
+}
+
+// Location returns location, or the location of the context if location is empty
+func Location(ctx context.Context, location string) string {
+	if len(location) > 0 {
+		return location
+	}
+	location, _ = LocationFromContext(ctx)
+	return location
+}
+
+// Group returns group, or the group of the context if group is empty
+func Group(ctx context.Context, group string) string {
+	if len(group) > 0 {
+		return group
+	}
+	group, _ = GroupFromContext(ctx)
+	return group
+}
@@ -0,0 +1,24 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package moc
+
+import (
+	"context"
+	"testing"
+)
+
+func Test_Scope(t *testing.T) {
+	ctx := context.Background()
+	if Location(ctx, "") != "" || Group(ctx, "") != "" {
+		t.Fatalf("Test_Scope failed: background context has a location or group")
+	}
+
+	ctx = WithGroup(WithLocation(ctx, "location1"), "group1")
+	if Location(ctx, "") != "location1" || Group(ctx, "") != "group1" {
+		t.Fatalf("Test_Scope failed: location or group not taken from the context")
+	}
+	if Location(ctx, "location2") != "location2" || Group(ctx, "group2") != "group2" {
+		t.Fatalf("Test_Scope failed: explicit location or group not preferred over the context")
+	}
+}
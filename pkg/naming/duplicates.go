@@ -0,0 +1,94 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package naming
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// DuplicateNameError is returned when a single request holds more than one resource with the same name
+type DuplicateNameError struct {
+	// Names - The names used by more than one resource, as given by the caller
+	Names []string
+}
+
+func (e *DuplicateNameError) Error() string {
+	return fmt.Sprintf("%s: request contains more than one resource named %s", errors.InvalidInput.Error(), strings.Join(e.Names, ", "))
+}
+
+// Cause allows errors.IsInvalidInput to match the error
+func (e *DuplicateNameError) Cause() error {
+	return errors.InvalidInput
+}
+
+// Unwrap allows errors.Is(err, errors.InvalidInput) to match the error
+func (e *DuplicateNameError) Unwrap() error {
+	return errors.InvalidInput
+}
+
+// IsDuplicateName returns true if the error is a DuplicateNameError
+func IsDuplicateName(err error) bool {
+	_, ok := err.(*DuplicateNameError)
+	return ok
+}
+
+// CheckDuplicateNames returns a DuplicateNameError if more than one of the resources in the slice has
+// the same Name. Names are compared case insensitively. Nil resources and resources without a name are ignored.
+func CheckDuplicateNames(resources interface{}) error {
+	slice := reflect.ValueOf(resources)
+	if slice.Kind() != reflect.Slice {
+		return nil
+	}
+
+	seen := map[string]string{}
+	duplicates := map[string]bool{}
+	for i := 0; i < slice.Len(); i++ {
+		name := resourceName(slice.Index(i))
+		if name == nil {
+			continue
+		}
+		key := strings.ToLower(*name)
+		if first, ok := seen[key]; ok {
+			duplicates[first] = true
+			continue
+		}
+		seen[key] = *name
+	}
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(duplicates))
+	for name := range duplicates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &DuplicateNameError{Names: names}
+}
+
+func resourceName(v reflect.Value) *string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	field := v.FieldByName("Name")
+	if !field.IsValid() || field.Kind() != reflect.Ptr || field.IsNil() {
+		return nil
+	}
+	name, ok := field.Interface().(*string)
+	if !ok || len(*name) == 0 {
+		return nil
+	}
+	return name
+}
@@ -0,0 +1,30 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package naming
+
+import (
+	"sync/atomic"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// ErrInvalidName is returned by the Get methods of the service clients when no name is given
+// and strict Get is enabled
+var ErrInvalidName = errors.Wrapf(errors.InvalidInput, "Resource name not specified, use List to get all the resources")
+
+var strictGet atomic.Bool
+
+// SetStrictGet controls whether Get with an empty name returns ErrInvalidName. It is disabled by
+// default, in which case Get with an empty name returns all the resources, as List does.
+func SetStrictGet(enabled bool) {
+	strictGet.Store(enabled)
+}
+
+// CheckGetName returns ErrInvalidName if strict Get is enabled and name is empty
+func CheckGetName(name string) error {
+	if strictGet.Load() && len(name) == 0 {
+		return ErrInvalidName
+	}
+	return nil
+}
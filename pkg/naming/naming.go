@@ -0,0 +1,185 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package naming
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+type nameRule struct {
+	resource  string
+	minLength int
+	maxLength int
+	pattern   *regexp.Regexp
+	hint      string
+}
+
+var (
+	// Network resources: alphanumerics, underscores, periods and hyphens. Start with an alphanumeric, end with an alphanumeric or underscore.
+	networkRule = nameRule{
+		minLength: 1,
+		maxLength: 80,
+		pattern:   regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_.-]*[a-zA-Z0-9_])?$`),
+		hint:      "alphanumerics, underscores, periods and hyphens, starting with an alphanumeric and ending with an alphanumeric or underscore",
+	}
+	virtualMachineRule = nameRule{
+		resource:  "virtual machine",
+		minLength: 1,
+		maxLength: 64,
+		pattern:   regexp.MustCompile(`^[^_\\/"'\[\]:|<>+=;,?*@&\s]([^\\/"'\[\]:|<>+=;,?*@&\s]*[^\\/"'\[\]:|<>+=;,?*@&\s.-])?$`),
+		hint:      `no spaces or any of \/"'[]:|<>+=;,?*@&, not starting with an underscore and not ending with a period or hyphen`,
+	}
+	computerNameRule = nameRule{
+		resource:  "computer",
+		minLength: 1,
+		maxLength: 15,
+		pattern:   regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`),
+		hint:      "alphanumerics and hyphens, not starting or ending with a hyphen",
+	}
+	virtualHardDiskRule = nameRule{
+		resource:  "virtual hard disk",
+		minLength: 1,
+		maxLength: 80,
+		pattern:   regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_.-]*[a-zA-Z0-9_])?$`),
+		hint:      "alphanumerics, underscores, periods and hyphens, starting with an alphanumeric and ending with an alphanumeric or underscore",
+	}
+	groupRule = nameRule{
+		resource:  "group",
+		minLength: 1,
+		maxLength: 90,
+		pattern:   regexp.MustCompile(`^[a-zA-Z0-9_().-]*[a-zA-Z0-9_()-]$`),
+		hint:      "alphanumerics, underscores, parentheses, periods and hyphens, not ending with a period",
+	}
+	containerRule = nameRule{
+		resource:  "storage container",
+		minLength: 1,
+		maxLength: 63,
+		pattern:   regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_-]*[a-zA-Z0-9])?$`),
+		hint:      "alphanumerics, underscores and hyphens, starting and ending with an alphanumeric",
+	}
+	keyVaultRule = nameRule{
+		resource:  "key vault",
+		minLength: 3,
+		maxLength: 24,
+		pattern:   regexp.MustCompile(`^[a-zA-Z]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`),
+		hint:      "alphanumerics and hyphens, starting with a letter and ending with a letter or digit, without consecutive hyphens",
+	}
+	// DNS labels follow RFC 1123
+	domainNameLabelRule = nameRule{
+		resource:  "domain name label",
+		minLength: 1,
+		maxLength: 63,
+		pattern:   regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`),
+		hint:      "alphanumerics and hyphens, not starting or ending with a hyphen",
+	}
+)
+
+func (r nameRule) forResource(resource string) nameRule {
+	r.resource = resource
+	return r
+}
+
+func (r nameRule) validate(name string) error {
+	if len(name) < r.minLength || len(name) > r.maxLength {
+		return errors.Wrapf(errors.InvalidInput, "Invalid %s name [%s]: length must be between %d and %d characters", r.resource, name, r.minLength, r.maxLength)
+	}
+	if !r.pattern.MatchString(name) {
+		return errors.Wrapf(errors.InvalidInput, "Invalid %s name [%s]: may only contain %s", r.resource, name, r.hint)
+	}
+	return nil
+}
+
+// ValidateVirtualMachineName validates the name of a virtual machine
+func ValidateVirtualMachineName(name string) error {
+	return virtualMachineRule.validate(name)
+}
+
+// ValidateComputerName validates the guest computer name of a virtual machine
+func ValidateComputerName(name string) error {
+	if err := computerNameRule.validate(name); err != nil {
+		return err
+	}
+	if isNumeric(name) {
+		return errors.Wrapf(errors.InvalidInput, "Invalid computer name [%s]: must not be entirely numeric", name)
+	}
+	return nil
+}
+
+// ValidateVirtualHardDiskName validates the name of a virtual hard disk
+func ValidateVirtualHardDiskName(name string) error {
+	return virtualHardDiskRule.validate(name)
+}
+
+// ValidateGroupName validates the name of a group
+func ValidateGroupName(name string) error {
+	return groupRule.validate(name)
+}
+
+// ValidateContainerName validates the name of a storage container
+func ValidateContainerName(name string) error {
+	return containerRule.validate(name)
+}
+
+// ValidateKeyVaultName validates the name of a key vault
+func ValidateKeyVaultName(name string) error {
+	if err := keyVaultRule.validate(name); err != nil {
+		return err
+	}
+	if strings.Contains(name, "--") {
+		return errors.Wrapf(errors.InvalidInput, "Invalid key vault name [%s]: must not contain consecutive hyphens", name)
+	}
+	return nil
+}
+
+// ValidateVirtualNetworkName validates the name of a virtual network
+func ValidateVirtualNetworkName(name string) error {
+	return networkRule.forResource("virtual network").validate(name)
+}
+
+// ValidateLogicalNetworkName validates the name of a logical network
+func ValidateLogicalNetworkName(name string) error {
+	return networkRule.forResource("logical network").validate(name)
+}
+
+// ValidateSubnetName validates the name of a virtual or logical network subnet
+func ValidateSubnetName(name string) error {
+	return networkRule.forResource("subnet").validate(name)
+}
+
+// ValidateNetworkInterfaceName validates the name of a network interface
+func ValidateNetworkInterfaceName(name string) error {
+	return networkRule.forResource("network interface").validate(name)
+}
+
+// ValidateLoadBalancerName validates the name of a load balancer
+func ValidateLoadBalancerName(name string) error {
+	return networkRule.forResource("load balancer").validate(name)
+}
+
+// ValidateNetworkSecurityGroupName validates the name of a network security group
+func ValidateNetworkSecurityGroupName(name string) error {
+	return networkRule.forResource("network security group").validate(name)
+}
+
+// ValidatePublicIPAddressName validates the name of a public ip address
+func ValidatePublicIPAddressName(name string) error {
+	return networkRule.forResource("public ip address").validate(name)
+}
+
+// ValidateDomainNameLabel validates the dns label of a public ip address as an RFC 1123 label
+func ValidateDomainNameLabel(label string) error {
+	return domainNameLabelRule.validate(label)
+}
+
+func isNumeric(name string) bool {
+	for _, c := range name {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package naming
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateVirtualMachineName(t *testing.T) {
+	assert.NoError(t, ValidateVirtualMachineName("vm-1"))
+	assert.NoError(t, ValidateVirtualMachineName("a"))
+	assert.Error(t, ValidateVirtualMachineName(""))
+	assert.Error(t, ValidateVirtualMachineName("_vm"))
+	assert.Error(t, ValidateVirtualMachineName("vm-"))
+	assert.Error(t, ValidateVirtualMachineName("vm."))
+	assert.Error(t, ValidateVirtualMachineName("vm 1"))
+	assert.Error(t, ValidateVirtualMachineName(strings.Repeat("a", 65)))
+	assert.True(t, errors.IsInvalidInput(ValidateVirtualMachineName("vm?")))
+}
+
+func Test_ValidateComputerName(t *testing.T) {
+	assert.NoError(t, ValidateComputerName("node-01"))
+	assert.Error(t, ValidateComputerName("1234"))
+	assert.Error(t, ValidateComputerName("averyveryverylongname"))
+	assert.Error(t, ValidateComputerName("-node"))
+}
+
+func Test_ValidateNetworkNames(t *testing.T) {
+	assert.NoError(t, ValidateVirtualNetworkName("vnet_1"))
+	assert.NoError(t, ValidateLoadBalancerName("lb.prod_"))
+	assert.Error(t, ValidateNetworkInterfaceName("nic-"))
+	assert.Error(t, ValidatePublicIPAddressName(".pip"))
+}
+
+func Test_ValidateDomainNameLabel(t *testing.T) {
+	assert.NoError(t, ValidateDomainNameLabel("myapp-01"))
+	assert.NoError(t, ValidateDomainNameLabel("MyApp"))
+	assert.NoError(t, ValidateDomainNameLabel("1app"))
+	assert.NoError(t, ValidateDomainNameLabel("xn--bcher-kva"))
+	assert.NoError(t, ValidateDomainNameLabel("ab"))
+	assert.NoError(t, ValidateDomainNameLabel("a"))
+	assert.Error(t, ValidateDomainNameLabel(""))
+	assert.Error(t, ValidateDomainNameLabel("-app"))
+	assert.Error(t, ValidateDomainNameLabel("app-"))
+	assert.Error(t, ValidateDomainNameLabel("my_app"))
+	assert.Error(t, ValidateDomainNameLabel(strings.Repeat("a", 64)))
+}
+
+func Test_ValidateKeyVaultName(t *testing.T) {
+	assert.NoError(t, ValidateKeyVaultName("vault-01"))
+	assert.Error(t, ValidateKeyVaultName("vault--01"))
+	assert.Error(t, ValidateKeyVaultName("01vault"))
+}
+
+type testNamedResource struct {
+	Name *string
+}
+
+func Test_CheckDuplicateNames(t *testing.T) {
+	a, b, upperA := "vm-a", "vm-b", "VM-A"
+	assert.Nil(t, CheckDuplicateNames([]*testNamedResource{{Name: &a}, {Name: &b}, nil, {}}))
+
+	err := CheckDuplicateNames([]*testNamedResource{{Name: &a}, {Name: &b}, {Name: &upperA}})
+	assert.True(t, IsDuplicateName(err))
+	assert.True(t, errors.IsInvalidInput(err))
+	assert.Equal(t, []string{"vm-a"}, err.(*DuplicateNameError).Names)
+}
+
+func Test_CheckGetName(t *testing.T) {
+	assert.Nil(t, CheckGetName(""))
+
+	SetStrictGet(true)
+	defer SetStrictGet(false)
+	assert.Equal(t, ErrInvalidName, CheckGetName(""))
+	assert.True(t, errors.IsInvalidInput(CheckGetName("")))
+	assert.Nil(t, CheckGetName("vm1"))
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package parallel
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultParallelism is the number of concurrent calls used when no limit is given
+const DefaultParallelism = 8
+
+// Result holds the value or error returned for a single key
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// GetMany invokes get for each of the keys, running at most parallelism calls
+// concurrently, and returns the outcome of each call keyed by its key. Keys that
+// were not started before ctx was cancelled report the context error.
+func GetMany[T any](ctx context.Context, keys []string, parallelism int, get func(ctx context.Context, key string) (T, error)) map[string]Result[T] {
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+
+	var (
+		mux     sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]Result[T], len(keys))
+		limiter = make(chan struct{}, parallelism)
+	)
+
+	for _, key := range keys {
+		mux.Lock()
+		_, seen := results[key]
+		if !seen {
+			// Reserve the key so duplicates are only looked up once
+			results[key] = Result[T]{}
+		}
+		mux.Unlock()
+		if seen {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			mux.Lock()
+			results[key] = Result[T]{Err: ctx.Err()}
+			mux.Unlock()
+			continue
+		case limiter <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(key string) {
+			defer func() {
+				<-limiter
+				wg.Done()
+			}()
+			value, err := get(ctx, key)
+			mux.Lock()
+			results[key] = Result[T]{Value: value, Err: err}
+			mux.Unlock()
+		}(key)
+	}
+	wg.Wait()
+
+	return results
+}
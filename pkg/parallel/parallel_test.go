@@ -0,0 +1,45 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package parallel
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_GetManyBoundsConcurrency(t *testing.T) {
+	var inflight, peak int32
+	keys := []string{"a", "b", "c", "d", "e", "f", "a"}
+	results := GetMany(context.Background(), keys, 2, func(ctx context.Context, key string) (string, error) {
+		n := atomic.AddInt32(&inflight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inflight, -1)
+		if key == "e" {
+			return "", fmt.Errorf("not found")
+		}
+		return key + "-value", nil
+	})
+
+	if peak > 2 {
+		t.Fatalf("Test_GetManyBoundsConcurrency failed: %d calls ran concurrently, limit was 2", peak)
+	}
+	if len(results) != 6 {
+		t.Fatalf("Test_GetManyBoundsConcurrency failed: expected 6 results, got %d", len(results))
+	}
+	if results["a"].Value != "a-value" || results["a"].Err != nil {
+		t.Fatalf("Test_GetManyBoundsConcurrency failed: unexpected result for a: %+v", results["a"])
+	}
+	if results["e"].Err == nil {
+		t.Fatalf("Test_GetManyBoundsConcurrency failed: expected an error for e")
+	}
+}
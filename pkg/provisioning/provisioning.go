@@ -0,0 +1,86 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package provisioning
+
+import (
+	"strconv"
+
+	"github.com/microsoft/moc/pkg/status"
+	"github.com/microsoft/moc/rpc/common"
+)
+
+const (
+	// ProvisionStateKey - Statuses key holding the provisioning state
+	ProvisionStateKey = "ProvisionState"
+	// HealthStateKey - Statuses key holding the health state
+	HealthStateKey = "HealthState"
+	// CurrentHealthStateKey - Statuses key holding the name of the current health state
+	CurrentHealthStateKey = "CurrentHealthState"
+	// FailureReasonKey - Statuses key holding the reason reported by the agent for the last failure
+	FailureReasonKey = "FailureReason"
+	// FailureCodeKey - Statuses key holding the error code reported by the agent for the last failure
+	FailureCodeKey = "FailureCode"
+)
+
+// Failure describes the last error the agent reported while provisioning a resource
+type Failure struct {
+	// Reason - The error message reported by the agent
+	Reason string
+	// Code - The error code reported by the agent
+	Code int32
+}
+
+// GetStatuses returns the statuses of a resource, adding the name of the current
+// health state, and the failure reason and code reported by the agent when the
+// last operation on the resource failed
+func GetStatuses(s *common.Status) map[string]*string {
+	statuses := status.GetStatuses(s)
+	if health := s.GetHealth(); health != nil {
+		state := health.GetCurrentState().String()
+		statuses[CurrentHealthStateKey] = &state
+	}
+	lastError := s.GetLastError()
+	if lastError == nil || (len(lastError.GetMessage()) == 0 && lastError.GetCode() == 0) {
+		return statuses
+	}
+	reason := lastError.GetMessage()
+	code := strconv.FormatInt(int64(lastError.GetCode()), 10)
+	statuses[FailureReasonKey] = &reason
+	statuses[FailureCodeKey] = &code
+	return statuses
+}
+
+// GetFailure returns the failure recorded in statuses, or nil if the agent did
+// not report one
+func GetFailure(statuses map[string]*string) *Failure {
+	reason, hasReason := statuses[FailureReasonKey]
+	code, hasCode := statuses[FailureCodeKey]
+	if !hasReason && !hasCode {
+		return nil
+	}
+	failure := &Failure{}
+	if reason != nil {
+		failure.Reason = *reason
+	}
+	if code != nil {
+		if c, err := strconv.ParseInt(*code, 10, 32); err == nil {
+			failure.Code = int32(c)
+		}
+	}
+	return failure
+}
+
+// GetHealthState returns the current health state recorded in statuses, or
+// NOTKNOWN if the agent did not report one
+func GetHealthState(statuses map[string]*string) common.HealthState {
+	state, ok := statuses[CurrentHealthStateKey]
+	if !ok || state == nil {
+		return common.HealthState_NOTKNOWN
+	}
+	value, ok := common.HealthState_value[*state]
+	if !ok {
+		return common.HealthState_NOTKNOWN
+	}
+	return common.HealthState(value)
+}
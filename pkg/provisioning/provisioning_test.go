@@ -0,0 +1,55 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package provisioning
+
+import (
+	"testing"
+
+	"github.com/microsoft/moc/rpc/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetStatusesWithFailure(t *testing.T) {
+	statuses := GetStatuses(&common.Status{
+		LastError: &common.Error{Message: "disk full", Code: 5},
+	})
+	assert.Equal(t, "disk full", *statuses[FailureReasonKey])
+	assert.Equal(t, "5", *statuses[FailureCodeKey])
+
+	failure := GetFailure(statuses)
+	assert.NotNil(t, failure)
+	assert.Equal(t, "disk full", failure.Reason)
+	assert.Equal(t, int32(5), failure.Code)
+}
+
+func Test_GetStatusesWithoutFailure(t *testing.T) {
+	statuses := GetStatuses(&common.Status{LastError: &common.Error{}})
+	_, hasReason := statuses[FailureReasonKey]
+	_, hasCode := statuses[FailureCodeKey]
+	assert.False(t, hasReason)
+	assert.False(t, hasCode)
+	assert.Nil(t, GetFailure(statuses))
+}
+
+func Test_GetFailure(t *testing.T) {
+	reason, code := "timeout", "not-a-number"
+	failure := GetFailure(map[string]*string{FailureReasonKey: &reason, FailureCodeKey: &code})
+	assert.Equal(t, "timeout", failure.Reason)
+	assert.Equal(t, int32(0), failure.Code)
+
+	assert.Nil(t, GetFailure(map[string]*string{}))
+}
+
+func Test_GetHealthState(t *testing.T) {
+	statuses := GetStatuses(&common.Status{
+		Health: &common.Health{CurrentState: common.HealthState_OK},
+	})
+	assert.Equal(t, common.HealthState_OK, GetHealthState(statuses))
+
+	statuses = GetStatuses(&common.Status{})
+	assert.Equal(t, common.HealthState_NOTKNOWN, GetHealthState(statuses))
+
+	state := "not-a-state"
+	assert.Equal(t, common.HealthState_NOTKNOWN, GetHealthState(map[string]*string{CurrentHealthStateKey: &state}))
+}
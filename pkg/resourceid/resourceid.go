@@ -0,0 +1,106 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package resourceid
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// Scope types of a resource reference
+const (
+	GroupScope    = "groups"
+	LocationScope = "locations"
+)
+
+const providersSegment = "providers"
+
+// ID is a reference to a resource in a format local to the sdk, /{scopeType}/{scope}/providers/{resourceType}/{name},
+// for example /groups/group1/providers/VirtualMachine/vm1. It is used to import existing resources by reference.
+// It is not the ID the agent assigns to a resource, which is an opaque identifier that Parse does not accept.
+type ID struct {
+	// ScopeType - GroupScope or LocationScope
+	ScopeType string
+	// Scope - Name of the group or location holding the resource
+	Scope string
+	// ResourceType - Type of the resource, one of the conversion resource types
+	ResourceType string
+	// Name - Name of the resource
+	Name string
+}
+
+// String formats the reference
+func (id ID) String() string {
+	return fmt.Sprintf("/%s/%s/%s/%s/%s", id.ScopeType, id.Scope, providersSegment, id.ResourceType, id.Name)
+}
+
+// Format returns the reference of a resource
+func Format(scopeType, scope, resourceType, name string) string {
+	return ID{ScopeType: scopeType, Scope: scope, ResourceType: resourceType, Name: name}.String()
+}
+
+// Parse parses a reference
+func Parse(id string) (*ID, error) {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	if len(segments) != 5 || segments[2] != providersSegment {
+		return nil, errors.Wrapf(errors.InvalidInput, "Invalid resource reference %s, expected /{groups|locations}/{scope}/providers/{type}/{name}", id)
+	}
+	for _, segment := range segments {
+		if len(segment) == 0 {
+			return nil, errors.Wrapf(errors.InvalidInput, "Invalid resource reference %s, empty segment", id)
+		}
+	}
+	if segments[0] != GroupScope && segments[0] != LocationScope {
+		return nil, errors.Wrapf(errors.InvalidInput, "Invalid resource reference %s, unknown scope type %s", id, segments[0])
+	}
+	return &ID{ScopeType: segments[0], Scope: segments[1], ResourceType: segments[3], Name: segments[4]}, nil
+}
+
+// ParseAs parses a reference and checks it identifies a resource of the given scope and resource type.
+// It returns the scope and name of the resource.
+func ParseAs(id, scopeType, resourceType string) (string, string, error) {
+	parsed, err := Parse(id)
+	if err != nil {
+		return "", "", err
+	}
+	if parsed.ScopeType != scopeType || !strings.EqualFold(parsed.ResourceType, resourceType) {
+		return "", "", errors.Wrapf(errors.InvalidInput, "Resource reference %s does not identify a %s in %s", id, resourceType, scopeType)
+	}
+	return parsed.Scope, parsed.Name, nil
+}
+
+// Exists returns true if get finds the named resource. A NotFound error from get is reported as false. The agent has
+// no cheaper existence check, so this costs a full Get of the resource.
+func Exists[T any](ctx context.Context, name string, get func(ctx context.Context, name string) (*[]T, error)) (bool, error) {
+	if len(name) == 0 {
+		return false, errors.Wrapf(errors.InvalidInput, "Resource name not specified")
+	}
+	items, err := get(ctx, name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return items != nil && len(*items) > 0, nil
+}
+
+// GetByID parses a reference, checks its scope and resource type, and returns the single resource get finds
+func GetByID[T any](ctx context.Context, id, scopeType, resourceType string, get func(ctx context.Context, scope, name string) (*[]T, error)) (*T, error) {
+	scope, name, err := ParseAs(id, scopeType, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	items, err := get(ctx, scope, name)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil || len(*items) == 0 {
+		return nil, errors.Wrapf(errors.NotFound, "%s [%s] not found", resourceType, id)
+	}
+	return &(*items)[0], nil
+}
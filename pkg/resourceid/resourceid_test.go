@@ -0,0 +1,60 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package resourceid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseFormat(t *testing.T) {
+	id := Format(GroupScope, "group1", "VirtualMachine", "vm1")
+	assert.Equal(t, "/groups/group1/providers/VirtualMachine/vm1", id)
+
+	parsed, err := Parse(id)
+	assert.Nil(t, err)
+	assert.Equal(t, ID{ScopeType: GroupScope, Scope: "group1", ResourceType: "VirtualMachine", Name: "vm1"}, *parsed)
+
+	scope, name, err := ParseAs(id, GroupScope, "virtualmachine")
+	assert.Nil(t, err)
+	assert.Equal(t, "group1", scope)
+	assert.Equal(t, "vm1", name)
+
+	_, _, err = ParseAs(id, LocationScope, "VirtualMachine")
+	assert.True(t, errors.IsInvalidInput(err))
+	_, _, err = ParseAs(id, GroupScope, "LoadBalancer")
+	assert.True(t, errors.IsInvalidInput(err))
+
+	for _, invalid := range []string{"", "vm1", "/groups/group1/VirtualMachine/vm1", "/subscriptions/s/providers/VirtualMachine/vm1", "/groups//providers/VirtualMachine/vm1"} {
+		_, err := Parse(invalid)
+		assert.True(t, errors.IsInvalidInput(err), invalid)
+	}
+}
+
+func Test_Exists(t *testing.T) {
+	get := func(ctx context.Context, name string) (*[]string, error) {
+		switch name {
+		case "found":
+			return &[]string{name}, nil
+		case "missing":
+			return nil, errors.Wrapf(errors.NotFound, "missing")
+		}
+		return &[]string{}, nil
+	}
+
+	exists, err := Exists(context.Background(), "found", get)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	exists, err = Exists(context.Background(), "missing", get)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+	exists, err = Exists(context.Background(), "empty", get)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+	_, err = Exists(context.Background(), "", get)
+	assert.True(t, errors.IsInvalidInput(err))
+}
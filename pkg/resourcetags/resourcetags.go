@@ -0,0 +1,120 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+// Package resourcetags provides helpers to manipulate resource tags. Tag keys
+// are compared case-insensitively, and keys under the reserved moc. prefix are
+// owned by the system and protected from callers.
+package resourcetags
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// ReservedPrefix is the prefix of tag keys reserved for system use
+const ReservedPrefix = "moc."
+
+// IsReserved returns true if the key is reserved for system use
+func IsReserved(key string) bool {
+	return strings.HasPrefix(strings.ToLower(key), ReservedPrefix)
+}
+
+// Get returns the value of the tag matching key case-insensitively
+func Get(tags map[string]*string, key string) (*string, bool) {
+	if v, ok := tags[key]; ok {
+		return v, true
+	}
+	for k, v := range tags {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Merge returns a new map holding the tags of base overlaid with overrides. A key
+// in overrides replaces any key in base that matches it case-insensitively.
+func Merge(base, overrides map[string]*string) map[string]*string {
+	merged := make(map[string]*string, len(base)+len(overrides))
+	index := map[string]string{}
+	for k, v := range base {
+		merged[k] = v
+		index[strings.ToLower(k)] = k
+	}
+	for k, v := range overrides {
+		if existing, ok := index[strings.ToLower(k)]; ok {
+			delete(merged, existing)
+		}
+		merged[k] = v
+		index[strings.ToLower(k)] = k
+	}
+	return merged
+}
+
+// Diff returns the tags that have to be set and the keys that have to be removed
+// to turn current into desired
+func Diff(current, desired map[string]*string) (set map[string]*string, removed []string) {
+	set = map[string]*string{}
+	for k, v := range desired {
+		existing, ok := Get(current, k)
+		if !ok || !equalValues(existing, v) {
+			set[k] = v
+		}
+	}
+	for k := range current {
+		if _, ok := Get(desired, k); !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+	return
+}
+
+// ValidateUserTags returns an error if the tags set any reserved key
+func ValidateUserTags(tags map[string]*string) error {
+	for k := range tags {
+		if IsReserved(k) {
+			return errors.Wrapf(errors.InvalidInput, "Tag [%s] uses the reserved prefix [%s]", k, ReservedPrefix)
+		}
+	}
+	return nil
+}
+
+// MergeUserTags merges caller provided tags into the current tags of a
+// resource. Reserved tags on the resource are always preserved, and an error is
+// returned if the caller attempts to set a reserved tag.
+func MergeUserTags(current, user map[string]*string) (map[string]*string, error) {
+	if err := ValidateUserTags(user); err != nil {
+		return nil, err
+	}
+	return Merge(current, user), nil
+}
+
+// ReplaceUserTags replaces all caller owned tags of a resource with user, keeping
+// the reserved tags already on the resource
+func ReplaceUserTags(current, user map[string]*string) (map[string]*string, error) {
+	if err := ValidateUserTags(user); err != nil {
+		return nil, err
+	}
+	return Merge(Reserved(current), user), nil
+}
+
+// Reserved returns the reserved tags in tags
+func Reserved(tags map[string]*string) map[string]*string {
+	reserved := map[string]*string{}
+	for k, v := range tags {
+		if IsReserved(k) {
+			reserved[k] = v
+		}
+	}
+	return reserved
+}
+
+func equalValues(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
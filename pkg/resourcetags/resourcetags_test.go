@@ -0,0 +1,50 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package resourcetags
+
+import (
+	"testing"
+
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func Test_Merge(t *testing.T) {
+	base := map[string]*string{"Env": strPtr("dev"), "owner": strPtr("a")}
+	merged := Merge(base, map[string]*string{"env": strPtr("prod")})
+
+	assert.Equal(t, 2, len(merged))
+	assert.Equal(t, "prod", *merged["env"])
+	_, ok := merged["Env"]
+	assert.False(t, ok)
+	assert.Equal(t, "dev", *base["Env"])
+}
+
+func Test_Diff(t *testing.T) {
+	current := map[string]*string{"Env": strPtr("dev"), "owner": strPtr("a"), "stale": strPtr("x")}
+	desired := map[string]*string{"env": strPtr("prod"), "Owner": strPtr("a"), "new": strPtr("y")}
+
+	set, removed := Diff(current, desired)
+	assert.Equal(t, 2, len(set))
+	assert.Equal(t, "prod", *set["env"])
+	assert.Equal(t, "y", *set["new"])
+	assert.Equal(t, []string{"stale"}, removed)
+}
+
+func Test_ReservedTags(t *testing.T) {
+	current := map[string]*string{"moc.managedBy": strPtr("agent"), "env": strPtr("dev")}
+
+	_, err := MergeUserTags(current, map[string]*string{"MOC.managedBy": strPtr("me")})
+	assert.True(t, errors.IsInvalidInput(err))
+
+	replaced, err := ReplaceUserTags(current, map[string]*string{"team": strPtr("net")})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(replaced))
+	assert.Equal(t, "agent", *replaced["moc.managedBy"])
+	assert.Equal(t, "net", *replaced["team"])
+}
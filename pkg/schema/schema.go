@@ -0,0 +1,197 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON schema dialect of the generated documents
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON schema definition
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// Generator builds JSON schema definitions for Go types, following their json struct tags
+type Generator struct {
+	definitions map[string]*Schema
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// NewGenerator returns a generator with no definitions
+func NewGenerator() *Generator {
+	return &Generator{definitions: map[string]*Schema{}}
+}
+
+// Add adds the definition of the type of v, and of every named struct type it references.
+// Definitions are named after the package and type name, such as compute.VirtualMachine.
+func (g *Generator) Add(v interface{}) error {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return fmt.Errorf("cannot generate a schema for nil")
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot add %s: only struct types can be added", t)
+	}
+	g.typeSchema(t)
+	return nil
+}
+
+// Document returns a schema document holding all the definitions
+func (g *Generator) Document() *Schema {
+	return &Schema{Schema: Draft, Definitions: g.definitions}
+}
+
+// Names returns the sorted names of the definitions
+func (g *Generator) Names() []string {
+	names := make([]string, 0, len(g.definitions))
+	for name := range g.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// For returns a standalone schema document for the type of v
+func For(v interface{}) (*Schema, error) {
+	g := NewGenerator()
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("cannot generate a schema for nil")
+	}
+	s := g.typeSchema(t)
+	s.Schema = Draft
+	if len(g.definitions) > 0 {
+		s.Definitions = g.definitions
+	}
+	return s, nil
+}
+
+func (g *Generator) typeSchema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes byte slices as base64 strings
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := definitionName(t)
+		if _, ok := g.definitions[name]; !ok {
+			// Reserve the name first so recursive types terminate
+			g.definitions[name] = &Schema{}
+			*g.definitions[name] = *g.structSchema(t)
+		}
+		return &Schema{Ref: "#/definitions/" + name}
+	}
+	// Interfaces and other kinds accept any value
+	return &Schema{}
+}
+
+func (g *Generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.addFields(s, t)
+	sort.Strings(s.Required)
+	return s
+}
+
+func (g *Generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options := parseTag(tag)
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				// encoding/json promotes the fields of embedded structs
+				g.addFields(s, ft)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, exists := s.Properties[name]; exists {
+			continue
+		}
+		s.Properties[name] = g.typeSchema(field.Type)
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+func parseTag(tag string) (string, string) {
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+func definitionName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	if pkg == "" {
+		return t.Name()
+	}
+	return pkg + "." + t.Name()
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type TestBase struct {
+	ID *string `json:"id,omitempty"`
+}
+
+type TestChild struct {
+	Name   string     `json:"name"`
+	Parent *TestChild `json:"parent,omitempty"`
+}
+
+type TestResource struct {
+	Hidden string `json:"-"`
+	TestBase
+	Name     *string            `json:"name,omitempty"`
+	Count    int32              `json:"count"`
+	Data     []byte             `json:"data,omitempty"`
+	Tags     map[string]*string `json:"tags"`
+	Created  time.Time          `json:"created"`
+	Children []TestChild        `json:"children,omitempty"`
+}
+
+func Test_Generator(t *testing.T) {
+	g := NewGenerator()
+	if err := g.Add(&TestResource{}); err != nil {
+		t.Fatalf("Test_Generator failed: %v", err)
+	}
+	if err := g.Add("TestResource"); err == nil {
+		t.Fatalf("Test_Generator failed: expected an error for a non struct type")
+	}
+	if !reflect.DeepEqual(g.Names(), []string{"schema.TestChild", "schema.TestResource"}) {
+		t.Fatalf("Test_Generator failed: unexpected definitions %v", g.Names())
+	}
+
+	s := g.Document().Definitions["schema.TestResource"]
+	for _, name := range []string{"id", "name", "count", "data", "tags", "created", "children"} {
+		if _, ok := s.Properties[name]; !ok {
+			t.Fatalf("Test_Generator failed: missing property %s", name)
+		}
+	}
+	if _, ok := s.Properties["Hidden"]; ok {
+		t.Fatalf("Test_Generator failed: ignored field present")
+	}
+	if !reflect.DeepEqual(s.Required, []string{"count", "created", "tags"}) {
+		t.Fatalf("Test_Generator failed: unexpected required properties %v", s.Required)
+	}
+	if s.Properties["data"].Format != "byte" || s.Properties["created"].Format != "date-time" {
+		t.Fatalf("Test_Generator failed: unexpected formats")
+	}
+	if s.Properties["children"].Items.Ref != "#/definitions/schema.TestChild" {
+		t.Fatalf("Test_Generator failed: unexpected children items %+v", s.Properties["children"].Items)
+	}
+	child := g.Document().Definitions["schema.TestChild"]
+	if child.Properties["parent"].Ref != "#/definitions/schema.TestChild" {
+		t.Fatalf("Test_Generator failed: recursive reference not resolved")
+	}
+}
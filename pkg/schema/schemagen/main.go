@@ -0,0 +1,94 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+//
+// schemagen writes the JSON schema definitions of the SDK resource types
+//
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/schema"
+	"github.com/microsoft/moc-sdk-for-go/services/cloud"
+	"github.com/microsoft/moc-sdk-for-go/services/cloud/etcdcluster"
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/security"
+	"github.com/microsoft/moc-sdk-for-go/services/security/keyvault"
+	"github.com/microsoft/moc-sdk-for-go/services/storage"
+)
+
+// resources lists the types exposed by the service clients
+var resources = []interface{}{
+	// cloud
+	cloud.Location{},
+	cloud.Group{},
+	cloud.Node{},
+	cloud.Zone{},
+	cloud.Kubernetes{},
+	cloud.Cluster{},
+	cloud.ControlPlaneInfo{},
+	cloud.EtcdCluster{},
+	etcdcluster.EtcdServer{},
+	// compute
+	compute.VirtualMachine{},
+	compute.VirtualMachineScaleSet{},
+	compute.VirtualMachineImage{},
+	compute.GalleryImage{},
+	compute.AvailabilitySet{},
+	compute.BareMetalMachine{},
+	compute.BareMetalHost{},
+	// network
+	network.VirtualNetwork{},
+	network.LogicalNetwork{},
+	network.Interface{},
+	network.LoadBalancer{},
+	network.SecurityGroup{},
+	network.MACPool{},
+	network.VipPool{},
+	// storage
+	storage.VirtualHardDisk{},
+	storage.Container{},
+	// security
+	security.KeyVault{},
+	security.Certificate{},
+	security.Identity{},
+	security.Role{},
+	security.RoleAssignment{},
+	keyvault.Secret{},
+	keyvault.Key{},
+}
+
+func main() {
+	output := flag.String("o", "", "file to write the schema to, defaults to stdout")
+	flag.Parse()
+
+	g := schema.NewGenerator()
+	for _, resource := range resources {
+		if err := g.Add(resource); err != nil {
+			fmt.Fprintf(os.Stderr, "schemagen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	data, err := json.MarshalIndent(g.Document(), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "schemagen: %v\n", err)
+		os.Exit(1)
+	}
+	data = append(data, '\n')
+
+	if len(*output) == 0 {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(*output, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "schemagen: %v\n", err)
+		os.Exit(1)
+	}
+}
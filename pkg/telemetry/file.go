@@ -0,0 +1,121 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileExporter writes events as json lines to a local file, rotating it once
+// it grows beyond the configured size
+type FileExporter struct {
+	mux        sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewFileExporter creates an exporter writing to path. When the file exceeds
+// maxSizeBytes it is rotated to path.1 .. path.<maxBackups>. A maxSizeBytes of
+// zero disables rotation.
+func NewFileExporter(path string, maxSizeBytes int64, maxBackups int) (*FileExporter, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("Telemetry file path is empty")
+	}
+	if maxSizeBytes < 0 || maxBackups < 0 {
+		return nil, fmt.Errorf("Invalid telemetry file rotation settings [%d, %d]", maxSizeBytes, maxBackups)
+	}
+	e := &FileExporter{
+		path:       path,
+		maxSize:    maxSizeBytes,
+		maxBackups: maxBackups,
+	}
+	if err := e.open(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Export writes the event to the file
+func (e *FileExporter) Export(event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	if e.file == nil {
+		return fmt.Errorf("Telemetry file [%s] is closed", e.path)
+	}
+	if e.maxSize > 0 && e.size+int64(len(data)) > e.maxSize && e.size > 0 {
+		if err := e.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := e.file.Write(data)
+	e.size += int64(n)
+	return err
+}
+
+// Close closes the underlying file
+func (e *FileExporter) Close() error {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
+
+func (e *FileExporter) open() error {
+	file, err := os.OpenFile(e.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	e.file = file
+	e.size = info.Size()
+	return nil
+}
+
+func (e *FileExporter) rotate() error {
+	if err := e.file.Close(); err != nil {
+		return err
+	}
+	e.file = nil
+
+	if e.maxBackups == 0 {
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return e.open()
+	}
+
+	for i := e.maxBackups - 1; i > 0; i-- {
+		src := fmt.Sprintf("%s.%d", e.path, i)
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		if err := os.Rename(src, fmt.Sprintf("%s.%d", e.path, i+1)); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(e.path, e.path+".1"); err != nil {
+		return err
+	}
+	return e.open()
+}
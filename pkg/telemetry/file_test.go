@@ -0,0 +1,44 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package telemetry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_FileExporterRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.log")
+	exporter, err := NewFileExporter(path, 100, 2)
+	if err != nil {
+		t.Fatalf("Test_FileExporterRotation failed: %v", err)
+	}
+	defer exporter.Close()
+
+	for i := 0; i < 5; i++ {
+		if err := exporter.Export(Event{Time: time.Now(), Method: "/moc.cloudagent.network.LoadBalancerAgent/Invoke"}); err != nil {
+			t.Fatalf("Test_FileExporterRotation failed: %v", err)
+		}
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Fatalf("Test_FileExporterRotation failed: expected file %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("Test_FileExporterRotation failed: more than maxBackups files were kept")
+	}
+}
+
+func Test_FileExporterInvalidSettings(t *testing.T) {
+	if _, err := NewFileExporter("", 0, 0); err == nil {
+		t.Fatalf("Test_FileExporterInvalidSettings failed: empty path should return an error")
+	}
+	if _, err := NewFileExporter("telemetry.log", -1, 0); err == nil {
+		t.Fatalf("Test_FileExporterInvalidSettings failed: negative size should return an error")
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	otlpLogsPath    = "/v1/logs"
+	otlpServiceName = "moc-sdk-for-go"
+)
+
+// OTLPExporter ships events to an OpenTelemetry collector as log records
+// using the OTLP/HTTP json encoding
+type OTLPExporter struct {
+	endpoint string
+	headers  map[string]string
+	client   *http.Client
+}
+
+// NewOTLPExporter creates an exporter posting to the collector at endpoint,
+// for example http://collector:4318. Headers are added to every request.
+// The timeout bounds each request and must be positive, so that a hung
+// collector cannot hold up the export of later events.
+func NewOTLPExporter(endpoint string, headers map[string]string, timeout time.Duration) (*OTLPExporter, error) {
+	if len(endpoint) == 0 {
+		return nil, fmt.Errorf("OTLP endpoint is empty")
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("Invalid OTLP timeout [%v], the timeout must be positive", timeout)
+	}
+	if !strings.HasSuffix(endpoint, otlpLogsPath) {
+		endpoint = strings.TrimSuffix(endpoint, "/") + otlpLogsPath
+	}
+	return &OTLPExporter{
+		endpoint: endpoint,
+		headers:  headers,
+		client:   &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Export posts the event to the collector
+func (e *OTLPExporter) Export(event Event) error {
+	body, err := json.Marshal(getOTLPLogsRequest(event))
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		request.Header.Set(k, v)
+	}
+	response, err := e.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("OTLP collector [%s] returned %s", e.endpoint, response.Status)
+	}
+	return nil
+}
+
+// Close releases idle connections to the collector
+func (e *OTLPExporter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue,omitempty"`
+	IntValue    string `json:"intValue,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           otlpAnyValue   `json:"body"`
+	Attributes     []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+func getOTLPLogsRequest(event Event) otlpLogsRequest {
+	// Severity numbers as defined by the OpenTelemetry log data model
+	severityNumber, severityText := 9, "INFO"
+	if len(event.Error) > 0 {
+		severityNumber, severityText = 17, "ERROR"
+	}
+	attributes := []otlpKeyValue{
+		{Key: "rpc.method", Value: otlpAnyValue{StringValue: event.Method}},
+		{Key: "duration_ms", Value: otlpAnyValue{IntValue: strconv.FormatInt(event.Duration.Milliseconds(), 10)}},
+	}
+	if len(event.Target) > 0 {
+		attributes = append(attributes, otlpKeyValue{Key: "server.address", Value: otlpAnyValue{StringValue: event.Target}})
+	}
+	if len(event.Error) > 0 {
+		attributes = append(attributes, otlpKeyValue{Key: "error", Value: otlpAnyValue{StringValue: event.Error}})
+	}
+	return otlpLogsRequest{
+		ResourceLogs: []otlpResourceLogs{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpKeyValue{
+						{Key: "service.name", Value: otlpAnyValue{StringValue: otlpServiceName}},
+					},
+				},
+				ScopeLogs: []otlpScopeLogs{
+					{
+						LogRecords: []otlpLogRecord{
+							{
+								TimeUnixNano:   strconv.FormatInt(event.Time.UnixNano(), 10),
+								SeverityNumber: severityNumber,
+								SeverityText:   severityText,
+								Body:           otlpAnyValue{StringValue: event.Method},
+								Attributes:     attributes,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package telemetry
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Event describes a single SDK call made against the agent
+type Event struct {
+	// Time - The time at which the call was started
+	Time time.Time `json:"time"`
+	// Method - The full gRPC method name that was invoked
+	Method string `json:"method"`
+	// Target - The agent endpoint the call was made against
+	Target string `json:"target,omitempty"`
+	// Duration - The time taken for the call to complete
+	Duration time.Duration `json:"duration"`
+	// Error - The error returned by the call, if any
+	Error string `json:"error,omitempty"`
+}
+
+// Exporter ships telemetry events to a destination
+type Exporter interface {
+	Export(event Event) error
+	Close() error
+}
+
+// QueueSize is the number of events buffered for the exporters. Events emitted while the buffer is full are dropped.
+const QueueSize = 1024
+
+var (
+	mux       sync.RWMutex
+	exporters []Exporter
+	queue     chan Event
+	done      chan struct{}
+	dropped   uint64
+)
+
+// RegisterExporter adds an exporter that receives every event emitted by the sdk. Events are exported in the
+// background, so a slow exporter delays other exporters but never an sdk call.
+func RegisterExporter(exporter Exporter) {
+	mux.Lock()
+	defer mux.Unlock()
+	exporters = append(exporters, exporter)
+	if queue == nil {
+		queue = make(chan Event, QueueSize)
+		done = make(chan struct{})
+		go export(queue, done)
+	}
+}
+
+// Enabled returns true if at least one exporter is registered
+func Enabled() bool {
+	mux.RLock()
+	defer mux.RUnlock()
+	return len(exporters) > 0
+}
+
+// Emit queues the event for the registered exporters without waiting for them. The event is dropped if the
+// queue is full. Export failures are ignored so that telemetry never fails an sdk call.
+func Emit(event Event) {
+	mux.RLock()
+	defer mux.RUnlock()
+	if queue == nil {
+		return
+	}
+	select {
+	case queue <- event:
+	default:
+		atomic.AddUint64(&dropped, 1)
+	}
+}
+
+// Dropped returns the number of events dropped because the queue was full
+func Dropped() uint64 {
+	return atomic.LoadUint64(&dropped)
+}
+
+// Shutdown exports the queued events, then closes and unregisters all exporters
+func Shutdown() (err error) {
+	mux.Lock()
+	q, d := queue, done
+	queue, done = nil, nil
+	mux.Unlock()
+	if q != nil {
+		close(q)
+		<-d
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+	for _, exporter := range exporters {
+		if cerr := exporter.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	exporters = nil
+	return
+}
+
+// export sends the events of q to the registered exporters until q is closed
+func export(q <-chan Event, done chan<- struct{}) {
+	defer close(done)
+	for event := range q {
+		mux.RLock()
+		current := append([]Exporter(nil), exporters...)
+		mux.RUnlock()
+		for _, exporter := range current {
+			_ = exporter.Export(event)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the Apache v2.0 License.
+
+package telemetry
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+type blockingExporter struct {
+	mux     sync.Mutex
+	release chan struct{}
+	events  []Event
+}
+
+func (e *blockingExporter) Export(event Event) error {
+	<-e.release
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	e.events = append(e.events, event)
+	return nil
+}
+
+func (e *blockingExporter) Close() error { return nil }
+
+func Test_EmitDoesNotWaitForExporters(t *testing.T) {
+	exporter := &blockingExporter{release: make(chan struct{})}
+	RegisterExporter(exporter)
+
+	start := time.Now()
+	for i := 0; i < QueueSize+10; i++ {
+		Emit(Event{Method: "/moc.cloudagent.network.VirtualNetworkAgent/Get"})
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Test_EmitDoesNotWaitForExporters failed: Emit blocked for %v", elapsed)
+	}
+	if Dropped() == 0 {
+		t.Fatalf("Test_EmitDoesNotWaitForExporters failed: events beyond the queue size should be dropped")
+	}
+
+	close(exporter.release)
+	if err := Shutdown(); err != nil {
+		t.Fatalf("Test_EmitDoesNotWaitForExporters failed: %v", err)
+	}
+	if len(exporter.events) == 0 || len(exporter.events) > QueueSize+1 {
+		t.Fatalf("Test_EmitDoesNotWaitForExporters failed: %d events exported", len(exporter.events))
+	}
+	if Enabled() {
+		t.Fatalf("Test_EmitDoesNotWaitForExporters failed: exporters should be unregistered after Shutdown")
+	}
+}
+
+func Test_OTLPExporterTimeout(t *testing.T) {
+	if _, err := NewOTLPExporter("http://collector:4318", nil, 0); err == nil {
+		t.Fatalf("Test_OTLPExporterTimeout failed: a zero timeout should return an error")
+	}
+	if _, err := NewOTLPExporter("http://collector:4318", nil, time.Second); err != nil {
+		t.Fatalf("Test_OTLPExporterTimeout failed: %v", err)
+	}
+}
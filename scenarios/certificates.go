@@ -0,0 +1,56 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package scenarios
+
+import (
+	"context"
+
+	"github.com/microsoft/moc-sdk-for-go/services/security"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// CertificateRotation is the outcome of renewing one certificate
+type CertificateRotation struct {
+	// Name - Name of the certificate
+	Name string
+	// Certificate - The renewed certificate, nil if the renewal failed
+	Certificate *security.Certificate
+	// PrivateKey - Private key of the renewed certificate
+	PrivateKey string
+	// Err - Why the renewal failed, nil if it succeeded
+	Err error
+}
+
+// RotateCertificates renews each of the named certificates of the group, replacing the current certificate with one
+// signed for a new private key. A certificate that fails to renew does not stop the others; the outcome of each is
+// returned in the order of names.
+func (c *Client) RotateCertificates(ctx context.Context, group string, names []string) []CertificateRotation {
+	rotations := make([]CertificateRotation, 0, len(names))
+	for _, name := range names {
+		rotation := CertificateRotation{Name: name}
+		rotation.Certificate, rotation.PrivateKey, rotation.Err = c.rotateCertificate(ctx, group, name)
+		rotations = append(rotations, rotation)
+	}
+	return rotations
+}
+
+func (c *Client) rotateCertificate(ctx context.Context, group, name string) (*security.Certificate, string, error) {
+	certs, err := c.certificates.Get(ctx, group, name)
+	if err != nil {
+		return nil, "", err
+	}
+	if certs == nil || len(*certs) == 0 {
+		return nil, "", errors.Wrapf(errors.NotFound, "Certificate [%s] not found", name)
+	}
+	current := (*certs)[0]
+	if current.Cer == nil || len(*current.Cer) == 0 {
+		return nil, "", errors.Wrapf(errors.InvalidConfiguration, "Certificate [%s] has no content to renew", name)
+	}
+
+	certName := name
+	return c.certificates.Renew(ctx, group, name, &security.CertificateRequest{
+		Name:           &certName,
+		OldCertificate: current.Cer,
+	})
+}
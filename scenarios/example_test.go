@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package scenarios_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/authorizer"
+	"github.com/microsoft/moc-sdk-for-go/scenarios"
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+)
+
+func ExampleClient_CreateWebTier() {
+	serverName := "cloudagent.contoso.com"
+	auth, err := authorizer.NewAuthorizerFromEnvironmentVariables(serverName)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	client, err := scenarios.NewScenarioClient(serverName, auth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	tier, err := client.CreateWebTier(context.Background(), "web-group", &scenarios.WebTierSpec{
+		Name:           "web",
+		Location:       "MocLocation",
+		AddressPrefix:  "10.0.0.0/24",
+		VirtualMachine: &compute.VirtualMachine{},
+		Ports:          []int32{80, 443},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(*tier.VirtualMachine.Name)
+}
+
+func ExampleClient_RotateCertificates() {
+	serverName := "cloudagent.contoso.com"
+	auth, err := authorizer.NewAuthorizerFromEnvironmentVariables(serverName)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	client, err := scenarios.NewScenarioClient(serverName, auth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, rotation := range client.RotateCertificates(context.Background(), "web-group", []string{"web-server"}) {
+		if rotation.Err != nil {
+			fmt.Printf("%s: %v\n", rotation.Name, rotation.Err)
+			continue
+		}
+		fmt.Printf("%s: renewed\n", rotation.Name)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package scenarios
+
+import (
+	"context"
+	"os"
+	"sort"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// CollectLogs downloads the log files of the agents of the location to directory, creating it if needed, and
+// returns the paths of the files, sorted
+func (c *Client) CollectLogs(ctx context.Context, location, directory string) ([]string, error) {
+	if len(directory) == 0 {
+		return nil, errors.Wrapf(errors.InvalidInput, "Log directory not specified")
+	}
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, errors.Wrapf(errors.Failed, "Failed to create log directory [%s]: %v", directory, err)
+	}
+	files, err := c.logs.GetLogFiles(ctx, location, directory)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+// Package scenarios holds end to end flows built on the service clients of the sdk: creating a virtual network,
+// virtual machine and load balancer together, rotating certificates and collecting logs. The flows are small
+// enough to read as documentation of how the clients fit together, and their tests run them against in-memory
+// services.
+package scenarios
+
+import (
+	"context"
+
+	"github.com/microsoft/moc-sdk-for-go/services/admin/logging"
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc-sdk-for-go/services/compute/virtualmachine"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/network/loadbalancer"
+	"github.com/microsoft/moc-sdk-for-go/services/network/networkinterface"
+	"github.com/microsoft/moc-sdk-for-go/services/network/virtualnetwork"
+	"github.com/microsoft/moc-sdk-for-go/services/security"
+	"github.com/microsoft/moc-sdk-for-go/services/security/certificate"
+	"github.com/microsoft/moc/pkg/auth"
+)
+
+type virtualNetworkService interface {
+	CreateOrUpdate(context.Context, string, string, *network.VirtualNetwork) (*network.VirtualNetwork, error)
+	Delete(context.Context, string, string) error
+}
+
+type interfaceService interface {
+	CreateOrUpdate(context.Context, string, string, *network.Interface) (*network.Interface, error)
+	Delete(context.Context, string, string) error
+}
+
+type loadBalancerService interface {
+	CreateOrUpdate(context.Context, string, string, *network.LoadBalancer) (*network.LoadBalancer, error)
+	Delete(context.Context, string, string) error
+}
+
+type virtualMachineService interface {
+	CreateOrUpdate(context.Context, string, string, *compute.VirtualMachine) (*compute.VirtualMachine, error)
+	Delete(context.Context, string, string) error
+}
+
+type certificateService interface {
+	Get(context.Context, string, string) (*[]security.Certificate, error)
+	Renew(context.Context, string, string, *security.CertificateRequest) (*security.Certificate, string, error)
+}
+
+type logService interface {
+	GetLogFiles(context.Context, string, string) ([]string, error)
+}
+
+// Client runs the scenarios against a cloud agent
+type Client struct {
+	vnets        virtualNetworkService
+	nics         interfaceService
+	lbs          loadBalancerService
+	vms          virtualMachineService
+	certificates certificateService
+	logs         logService
+}
+
+// NewScenarioClient returns a client running the scenarios against the cloud agent at cloudFQDN
+func NewScenarioClient(cloudFQDN string, authorizer auth.Authorizer) (*Client, error) {
+	vnets, err := virtualnetwork.NewVirtualNetworkClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	nics, err := networkinterface.NewInterfaceClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	lbs, err := loadbalancer.NewLoadBalancerClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	vms, err := virtualmachine.NewVirtualMachineClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	certificates, err := certificate.NewCertificateClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := logging.NewLoggingClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{vnets: vnets, nics: nics, lbs: lbs, vms: vms, certificates: certificates, logs: logs}, nil
+}
@@ -0,0 +1,175 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package scenarios
+
+import (
+	"context"
+	goerrors "errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/security"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAgent holds the resources created through the fake services, by kind and name
+type fakeAgent struct {
+	resources map[string]interface{}
+	calls     []string
+	failOn    string
+}
+
+func newFakeAgent() *fakeAgent {
+	return &fakeAgent{resources: map[string]interface{}{}}
+}
+
+func (f *fakeAgent) put(kind, name string, resource interface{}) error {
+	f.calls = append(f.calls, "create "+kind+"/"+name)
+	if f.failOn == kind {
+		return errors.Wrapf(errors.Failed, "Injected failure creating %s", name)
+	}
+	f.resources[kind+"/"+name] = resource
+	return nil
+}
+
+func (f *fakeAgent) delete(kind, name string) error {
+	f.calls = append(f.calls, "delete "+kind+"/"+name)
+	if _, ok := f.resources[kind+"/"+name]; !ok {
+		return errors.Wrapf(errors.NotFound, "%s not found", name)
+	}
+	delete(f.resources, kind+"/"+name)
+	return nil
+}
+
+type fakeVnets struct{ *fakeAgent }
+
+func (f fakeVnets) CreateOrUpdate(ctx context.Context, group, name string, vnet *network.VirtualNetwork) (*network.VirtualNetwork, error) {
+	return vnet, f.put("vnet", name, vnet)
+}
+
+func (f fakeVnets) Delete(ctx context.Context, group, name string) error {
+	return f.delete("vnet", name)
+}
+
+type fakeNics struct{ *fakeAgent }
+
+func (f fakeNics) CreateOrUpdate(ctx context.Context, group, name string, nic *network.Interface) (*network.Interface, error) {
+	return nic, f.put("nic", name, nic)
+}
+
+func (f fakeNics) Delete(ctx context.Context, group, name string) error { return f.delete("nic", name) }
+
+type fakeLbs struct{ *fakeAgent }
+
+func (f fakeLbs) CreateOrUpdate(ctx context.Context, group, name string, lb *network.LoadBalancer) (*network.LoadBalancer, error) {
+	return lb, f.put("lb", name, lb)
+}
+
+func (f fakeLbs) Delete(ctx context.Context, group, name string) error { return f.delete("lb", name) }
+
+type fakeVms struct{ *fakeAgent }
+
+func (f fakeVms) CreateOrUpdate(ctx context.Context, group, name string, vm *compute.VirtualMachine) (*compute.VirtualMachine, error) {
+	return vm, f.put("vm", name, vm)
+}
+
+func (f fakeVms) Delete(ctx context.Context, group, name string) error { return f.delete("vm", name) }
+
+type fakeCertificates struct{ *fakeAgent }
+
+func (f fakeCertificates) Get(ctx context.Context, group, name string) (*[]security.Certificate, error) {
+	cert, ok := f.resources["cert/"+name]
+	if !ok {
+		return nil, errors.Wrapf(errors.NotFound, "%s not found", name)
+	}
+	return &[]security.Certificate{*cert.(*security.Certificate)}, nil
+}
+
+func (f fakeCertificates) Renew(ctx context.Context, group, name string, csr *security.CertificateRequest) (*security.Certificate, string, error) {
+	renewed := "renewed-" + *csr.OldCertificate
+	cert := &security.Certificate{Name: csr.Name, Cer: &renewed}
+	return cert, "key", f.put("cert", name, cert)
+}
+
+type fakeLogs struct{ *fakeAgent }
+
+func (f fakeLogs) GetLogFiles(ctx context.Context, location, directory string) ([]string, error) {
+	return []string{filepath.Join(directory, "wssdagent.log"), filepath.Join(directory, "cloudagent.log")}, nil
+}
+
+func newFakeClient() (*Client, *fakeAgent) {
+	agent := newFakeAgent()
+	return &Client{
+		vnets:        fakeVnets{agent},
+		nics:         fakeNics{agent},
+		lbs:          fakeLbs{agent},
+		vms:          fakeVms{agent},
+		certificates: fakeCertificates{agent},
+		logs:         fakeLogs{agent},
+	}, agent
+}
+
+func newWebTierSpec() *WebTierSpec {
+	return &WebTierSpec{
+		Name:           "web",
+		Location:       "MocLocation",
+		AddressPrefix:  "10.0.0.0/24",
+		VirtualMachine: &compute.VirtualMachine{},
+		Ports:          []int32{80, 443},
+	}
+}
+
+func Test_CreateWebTier(t *testing.T) {
+	c, agent := newFakeClient()
+	tier, err := c.CreateWebTier(context.Background(), "group", newWebTierSpec())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"create vnet/web-vnet", "create lb/web-lb", "create nic/web-nic", "create vm/web-vm"}, agent.calls)
+
+	pools := *(*tier.NetworkInterface.IPConfigurations)[0].LoadBalancerBackendAddressPools
+	assert.Equal(t, *(*tier.LoadBalancer.BackendAddressPools)[0].Name, *pools[0].Name)
+	assert.Equal(t, "web-nic", *(*tier.VirtualMachine.NetworkProfile.NetworkInterfaces)[0].ID)
+	assert.Len(t, *tier.LoadBalancer.LoadBalancingRules, 2)
+
+	agent.calls = nil
+	assert.NoError(t, c.DeleteWebTier(context.Background(), "group", "web"))
+	assert.Equal(t, []string{"delete vm/web-vm", "delete nic/web-nic", "delete lb/web-lb", "delete vnet/web-vnet"}, agent.calls)
+	assert.Empty(t, agent.resources)
+}
+
+func Test_CreateWebTierRollback(t *testing.T) {
+	c, agent := newFakeClient()
+	agent.failOn = "nic"
+	_, err := c.CreateWebTier(context.Background(), "group", newWebTierSpec())
+	assert.True(t, goerrors.Is(err, errors.Failed))
+	assert.Equal(t, []string{"create vnet/web-vnet", "create lb/web-lb", "create nic/web-nic", "delete lb/web-lb", "delete vnet/web-vnet"}, agent.calls)
+	assert.Empty(t, agent.resources)
+
+	_, err = c.CreateWebTier(context.Background(), "group", &WebTierSpec{Name: "web"})
+	assert.True(t, errors.IsInvalidInput(err))
+}
+
+func Test_RotateCertificates(t *testing.T) {
+	c, agent := newFakeClient()
+	name, content := "server", "cert"
+	agent.resources["cert/server"] = &security.Certificate{Name: &name, Cer: &content}
+
+	rotations := c.RotateCertificates(context.Background(), "group", []string{"server", "missing"})
+	assert.Len(t, rotations, 2)
+	assert.NoError(t, rotations[0].Err)
+	assert.Equal(t, "renewed-cert", *rotations[0].Certificate.Cer)
+	assert.Equal(t, "key", rotations[0].PrivateKey)
+	assert.True(t, errors.IsNotFound(rotations[1].Err))
+}
+
+func Test_CollectLogs(t *testing.T) {
+	c, _ := newFakeClient()
+	directory := filepath.Join(t.TempDir(), "logs")
+	files, err := c.CollectLogs(context.Background(), "MocLocation", directory)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(directory, "cloudagent.log"), filepath.Join(directory, "wssdagent.log")}, files)
+	assert.DirExists(t, directory)
+}
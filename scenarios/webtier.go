@@ -0,0 +1,224 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package scenarios
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	log "k8s.io/klog"
+)
+
+// WebTierSpec describes a virtual machine serving ports behind an internal load balancer on its own virtual network
+type WebTierSpec struct {
+	// Name - Name of the web tier, used to name its resources
+	Name string
+	// Location - Location of the resources
+	Location string
+	// AddressPrefix - CIDR of the subnet of the virtual network
+	AddressPrefix string
+	// VirtualMachine - Hardware, storage and os profiles of the virtual machine. Its network profile is set by the
+	// scenario.
+	VirtualMachine *compute.VirtualMachine
+	// Ports - Ports forwarded from the load balancer to the virtual machine
+	Ports []int32
+	// Tags - Tags applied to all the resources
+	Tags map[string]*string
+}
+
+// WebTier are the resources created for a web tier
+type WebTier struct {
+	VirtualNetwork   *network.VirtualNetwork
+	LoadBalancer     *network.LoadBalancer
+	NetworkInterface *network.Interface
+	VirtualMachine   *compute.VirtualMachine
+}
+
+// CreateWebTier creates the virtual network, the load balancer, the network interface in its backend pool and the
+// virtual machine of a web tier, in that order. If any resource fails to be created, the resources created so far
+// are deleted and the creation error is returned.
+func (c *Client) CreateWebTier(ctx context.Context, group string, spec *WebTierSpec) (*WebTier, error) {
+	if err := validateWebTier(spec); err != nil {
+		return nil, err
+	}
+
+	tier := &WebTier{}
+	rollback := []func() error{}
+	fail := func(err error) (*WebTier, error) {
+		for i := len(rollback) - 1; i >= 0; i-- {
+			if rerr := rollback[i](); rerr != nil {
+				log.Errorf("[Scenarios] Failed to roll back resource of web tier %s: %v", spec.Name, rerr)
+			}
+		}
+		return nil, err
+	}
+
+	names := getWebTierNames(spec.Name)
+	vnet, err := c.vnets.CreateOrUpdate(ctx, group, names.vnet, getWebTierVirtualNetwork(names, spec))
+	if err != nil {
+		return fail(errors.Wrapf(err, "Failed to create virtual network for web tier %s", spec.Name))
+	}
+	tier.VirtualNetwork = vnet
+	rollback = append(rollback, func() error { return c.vnets.Delete(ctx, group, names.vnet) })
+
+	lb, err := c.lbs.CreateOrUpdate(ctx, group, names.lb, getWebTierLoadBalancer(names, spec))
+	if err != nil {
+		return fail(errors.Wrapf(err, "Failed to create load balancer for web tier %s", spec.Name))
+	}
+	tier.LoadBalancer = lb
+	rollback = append(rollback, func() error { return c.lbs.Delete(ctx, group, names.lb) })
+
+	nic, err := c.nics.CreateOrUpdate(ctx, group, names.nic, getWebTierInterface(names, spec))
+	if err != nil {
+		return fail(errors.Wrapf(err, "Failed to create network interface for web tier %s", spec.Name))
+	}
+	tier.NetworkInterface = nic
+	rollback = append(rollback, func() error { return c.nics.Delete(ctx, group, names.nic) })
+
+	vm, err := c.vms.CreateOrUpdate(ctx, group, names.vm, getWebTierVirtualMachine(names, spec))
+	if err != nil {
+		return fail(errors.Wrapf(err, "Failed to create virtual machine for web tier %s", spec.Name))
+	}
+	tier.VirtualMachine = vm
+
+	return tier, nil
+}
+
+// DeleteWebTier deletes the resources created by CreateWebTier, in reverse order. It attempts every deletion and
+// returns the first error.
+func (c *Client) DeleteWebTier(ctx context.Context, group, name string) error {
+	if len(name) == 0 {
+		return errors.Wrapf(errors.InvalidInput, "Web tier name not specified")
+	}
+	var firstErr error
+	record := func(err error) {
+		if err != nil && !errors.IsNotFound(err) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	names := getWebTierNames(name)
+	record(c.vms.Delete(ctx, group, names.vm))
+	record(c.nics.Delete(ctx, group, names.nic))
+	record(c.lbs.Delete(ctx, group, names.lb))
+	record(c.vnets.Delete(ctx, group, names.vnet))
+	return firstErr
+}
+
+func validateWebTier(spec *WebTierSpec) error {
+	if spec == nil || len(spec.Name) == 0 {
+		return errors.Wrapf(errors.InvalidInput, "Web tier name not specified")
+	}
+	if len(spec.AddressPrefix) == 0 {
+		return errors.Wrapf(errors.InvalidInput, "Address prefix of web tier %s not specified", spec.Name)
+	}
+	if spec.VirtualMachine == nil {
+		return errors.Wrapf(errors.InvalidInput, "Virtual machine of web tier %s not specified", spec.Name)
+	}
+	return nil
+}
+
+type webTierNames struct {
+	vnet, subnet, lb, frontend, backend, nic, vm string
+}
+
+func getWebTierNames(name string) webTierNames {
+	return webTierNames{
+		vnet:     fmt.Sprintf("%s-vnet", name),
+		subnet:   fmt.Sprintf("%s-subnet", name),
+		lb:       fmt.Sprintf("%s-lb", name),
+		frontend: fmt.Sprintf("%s-frontend", name),
+		backend:  fmt.Sprintf("%s-backend", name),
+		nic:      fmt.Sprintf("%s-nic", name),
+		vm:       fmt.Sprintf("%s-vm", name),
+	}
+}
+
+func getWebTierVirtualNetwork(names webTierNames, spec *WebTierSpec) *network.VirtualNetwork {
+	addressPrefix := spec.AddressPrefix
+	return &network.VirtualNetwork{
+		Name:     &names.vnet,
+		Location: &spec.Location,
+		Tags:     spec.Tags,
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
+			Subnets: &[]network.Subnet{{
+				Name: &names.subnet,
+				SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+					AddressPrefix:      &addressPrefix,
+					IPAllocationMethod: network.Dynamic,
+				},
+			}},
+		},
+	}
+}
+
+func getWebTierLoadBalancer(names webTierNames, spec *WebTierSpec) *network.LoadBalancer {
+	rules := []network.LoadBalancingRule{}
+	for _, port := range spec.Ports {
+		port := port
+		rules = append(rules, network.LoadBalancingRule{
+			LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+				FrontendPort: &port,
+				BackendPort:  &port,
+				Protocol:     network.TransportProtocolTCP,
+			},
+		})
+	}
+
+	return &network.LoadBalancer{
+		Name:     &names.lb,
+		Location: &spec.Location,
+		Tags:     spec.Tags,
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]network.FrontendIPConfiguration{{
+				Name: &names.frontend,
+				FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+					Subnet:                    &network.Subnet{ID: &names.vnet},
+					PrivateIPAllocationMethod: network.Dynamic,
+				},
+			}},
+			BackendAddressPools: &[]network.BackendAddressPool{{Name: &names.backend}},
+			LoadBalancingRules:  &rules,
+		},
+	}
+}
+
+func getWebTierInterface(names webTierNames, spec *WebTierSpec) *network.Interface {
+	ipconfigName := names.nic + "-ipconfig"
+	return &network.Interface{
+		Name:     &names.nic,
+		Location: &spec.Location,
+		Tags:     spec.Tags,
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			IPConfigurations: &[]network.InterfaceIPConfiguration{{
+				Name: &ipconfigName,
+				InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+					Subnet:                          &network.APIEntityReference{ID: &names.vnet},
+					LoadBalancerBackendAddressPools: &[]network.BackendAddressPool{{Name: &names.backend}},
+				},
+			}},
+		},
+	}
+}
+
+// getWebTierVirtualMachine returns a copy of the virtual machine of the spec attached to the network interface
+func getWebTierVirtualMachine(names webTierNames, spec *WebTierSpec) *compute.VirtualMachine {
+	vm := *spec.VirtualMachine
+	vm.Name = &names.vm
+	vm.Location = &spec.Location
+	if vm.Tags == nil {
+		vm.Tags = spec.Tags
+	}
+	props := compute.VirtualMachineProperties{}
+	if vm.VirtualMachineProperties != nil {
+		props = *vm.VirtualMachineProperties
+	}
+	props.NetworkProfile = &compute.NetworkProfile{
+		NetworkInterfaces: &[]compute.NetworkInterfaceReference{{ID: &names.nic}},
+	}
+	vm.VirtualMachineProperties = &props
+	return &vm
+}
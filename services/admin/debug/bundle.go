@@ -0,0 +1,331 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license
+
+package debug
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sdkdebug "github.com/microsoft/moc-sdk-for-go/pkg/debug"
+	"github.com/microsoft/moc-sdk-for-go/services/admin/health"
+	"github.com/microsoft/moc-sdk-for-go/services/admin/logging"
+	"github.com/microsoft/moc-sdk-for-go/services/admin/version"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// Components that can be collected into a debug bundle
+const (
+	ComponentStacktrace = "stacktrace"
+	ComponentVersion    = "version"
+	ComponentHealth     = "health"
+	ComponentLogs       = "logs"
+	ComponentResources  = "resources"
+)
+
+const (
+	bundleManifestName      = "manifest.json"
+	bundleHealthTimeoutSecs = 30
+)
+
+// AllComponents lists every component that can be collected
+var AllComponents = []string{ComponentStacktrace, ComponentVersion, ComponentHealth, ComponentLogs, ComponentResources}
+
+// BundleManifest describes the contents of a debug bundle
+type BundleManifest struct {
+	// CreatedAt - Time the bundle was collected
+	CreatedAt time.Time `json:"createdAt"`
+	// Since - Log entries older than Since are left out. Lines without a timestamp follow the entry before them.
+	Since time.Time `json:"since,omitempty"`
+	// Components - Result of collecting each component
+	Components []BundleComponent `json:"components"`
+}
+
+// BundleComponent describes a single component of a debug bundle
+type BundleComponent struct {
+	Name  string   `json:"name"`
+	Files []string `json:"files,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+// bundleCollector adds the files of a component to the bundle and returns their names. The files added before an
+// error are kept.
+type bundleCollector func(ctx context.Context, bundle *bundleWriter, since time.Time) ([]string, error)
+
+// CollectDebugBundle streams the selected components to destination as a gzipped tar archive, ending with a
+// manifest. A component that fails to collect is recorded in the manifest rather than failing the bundle. An empty
+// list of components collects all of them. Values of sensitive fields are redacted from the state dumps and resource
+// snapshots, but not from the logs.
+func (c *DebugClient) CollectDebugBundle(ctx context.Context, components []string, since time.Time, destination io.Writer) (*BundleManifest, error) {
+	return collectBundle(ctx, components, since, destination, c.getCollectors())
+}
+
+func (c *DebugClient) getCollectors() map[string]bundleCollector {
+	return map[string]bundleCollector{
+		ComponentStacktrace: c.collectStacktrace,
+		ComponentVersion:    c.collectVersion,
+		ComponentHealth:     c.collectHealth,
+		ComponentLogs:       c.collectLogs,
+		ComponentResources:  c.collectResources,
+	}
+}
+
+func collectBundle(ctx context.Context, components []string, since time.Time, destination io.Writer, collectors map[string]bundleCollector) (*BundleManifest, error) {
+	if destination == nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "Debug bundle destination not specified")
+	}
+	if len(components) == 0 {
+		components = AllComponents
+	}
+	for _, component := range components {
+		if _, ok := collectors[component]; !ok {
+			return nil, errors.Wrapf(errors.InvalidInput, "Unknown debug bundle component %s specified", component)
+		}
+	}
+
+	manifest := &BundleManifest{CreatedAt: time.Now().UTC(), Since: since}
+	bundle := newBundleWriter(destination, manifest.CreatedAt)
+	for _, component := range components {
+		files, err := collectors[component](ctx, bundle, since)
+		if bundle.err != nil {
+			// The archive cannot be written anymore
+			return nil, bundle.err
+		}
+		result := BundleComponent{Name: component, Files: files}
+		if err != nil {
+			result.Error = sdkdebug.Sanitize(err.Error())
+		}
+		manifest.Components = append(manifest.Components, result)
+	}
+
+	if err := bundle.addJSON(bundleManifestName, manifest); err != nil {
+		return nil, err
+	}
+	if err := bundle.close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (c *DebugClient) collectStacktrace(ctx context.Context, bundle *bundleWriter, since time.Time) ([]string, error) {
+	stacktrace, err := c.Stacktrace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := bundle.addFile("stacktrace.txt", []byte(sdkdebug.Sanitize(stacktrace))); err != nil {
+		return nil, err
+	}
+	return []string{"stacktrace.txt"}, nil
+}
+
+func (c *DebugClient) collectVersion(ctx context.Context, bundle *bundleWriter, since time.Time) ([]string, error) {
+	versionClient, err := version.NewVersionClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+	agentVersion, mocVersion, err := versionClient.GetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := map[string]string{"version": agentVersion, "mocversion": mocVersion}
+	if err := bundle.addJSON("version.json", report); err != nil {
+		return nil, err
+	}
+	return []string{"version.json"}, nil
+}
+
+func (c *DebugClient) collectHealth(ctx context.Context, bundle *bundleWriter, since time.Time) ([]string, error) {
+	healthClient, err := health.NewHealthClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+	report := map[string]interface{}{"healthy": true}
+	if err := healthClient.CheckHealth(ctx, bundleHealthTimeoutSecs); err != nil {
+		report["healthy"] = false
+		report["error"] = err.Error()
+	}
+	if info, err := healthClient.GetAgentInfo(ctx); err == nil {
+		report["agentInfo"] = info
+	}
+	if err := bundle.addJSON("health.json", report); err != nil {
+		return nil, err
+	}
+	return []string{"health.json"}, nil
+}
+
+// collectLogs receives the log files of the agent into a temporary directory, since the agent sends them whole, and
+// adds the entries newer than since to the bundle
+func (c *DebugClient) collectLogs(ctx context.Context, bundle *bundleWriter, since time.Time) ([]string, error) {
+	loggingClient, err := logging.NewLoggingClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+	staging, err := os.MkdirTemp("", "mocdebugbundle")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+
+	received, err := loggingClient.GetLogFiles(ctx, "", staging)
+	files := []string{}
+	for _, file := range received {
+		name := "logs/" + filepath.Base(file)
+		if addErr := addLogFile(bundle, name, file, since); addErr != nil {
+			return files, addErr
+		}
+		files = append(files, name)
+	}
+	return files, err
+}
+
+// addLogFile adds the entries of the log file newer than since to the bundle
+func addLogFile(bundle *bundleWriter, name, path string, since time.Time) error {
+	if !since.IsZero() {
+		filtered := path + ".since"
+		if err := filterLogFile(path, filtered, since); err != nil {
+			return err
+		}
+		path = filtered
+	}
+	return bundle.addLocalFile(name, path)
+}
+
+func filterLogFile(path, filtered string, since time.Time) (err error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(filtered, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	return filterLogSince(in, out, since)
+}
+
+// filterLogSince copies the lines of the log to w, leaving out the entries older than since. A line without a
+// timestamp is kept or left out with the entry before it; lines before the first timestamp are kept.
+func filterLogSince(r io.Reader, w io.Writer, since time.Time) error {
+	reader := bufio.NewReader(r)
+	keep := true
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			if t, ok := parseLogTime(line); ok {
+				keep = !t.Before(since)
+			}
+			if keep {
+				if _, werr := io.WriteString(w, line); werr != nil {
+					return werr
+				}
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// logTimeLayouts are the timestamp formats recognized at the start of a log line. Times without a zone are UTC.
+var logTimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006/01/02 15:04:05"}
+
+// parseLogTime returns the timestamp at the start of the log line, if any
+func parseLogTime(line string) (time.Time, bool) {
+	fields := strings.Fields(strings.TrimLeft(line, "["))
+	if len(fields) == 0 {
+		return time.Time{}, false
+	}
+	candidates := []string{strings.TrimRight(fields[0], "]")}
+	if len(fields) > 1 {
+		candidates = append(candidates, fields[0]+" "+strings.TrimRight(fields[1], "]"))
+	}
+	for _, layout := range logTimeLayouts {
+		for _, candidate := range candidates {
+			if t, err := time.Parse(layout, candidate); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// bundleWriter writes the files of a bundle to a gzipped tar archive as they are collected. The first error writing
+// the archive is kept in err, and the archive is not usable afterwards.
+type bundleWriter struct {
+	gz      *gzip.Writer
+	tw      *tar.Writer
+	modTime time.Time
+	err     error
+}
+
+func newBundleWriter(w io.Writer, modTime time.Time) *bundleWriter {
+	gz := gzip.NewWriter(w)
+	return &bundleWriter{gz: gz, tw: tar.NewWriter(gz), modTime: modTime}
+}
+
+func (b *bundleWriter) addFile(name string, data []byte) error {
+	return b.add(name, int64(len(data)), bytes.NewReader(data))
+}
+
+// addJSON adds value as indented JSON with the values of sensitive fields redacted
+func (b *bundleWriter) addJSON(name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	return b.addFile(name, []byte(sdkdebug.Sanitize(string(data))))
+}
+
+func (b *bundleWriter) addLocalFile(name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return b.add(name, info.Size(), f)
+}
+
+func (b *bundleWriter) add(name string, size int64, r io.Reader) error {
+	if b.err != nil {
+		return b.err
+	}
+	header := &tar.Header{Name: name, Mode: 0600, Size: size, ModTime: b.modTime}
+	if b.err = b.tw.WriteHeader(header); b.err != nil {
+		return b.err
+	}
+	if _, b.err = io.CopyN(b.tw, r, size); b.err != nil {
+		return b.err
+	}
+	return nil
+}
+
+func (b *bundleWriter) close() error {
+	if b.err != nil {
+		return b.err
+	}
+	if err := b.tw.Close(); err != nil {
+		return err
+	}
+	return b.gz.Close()
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license
+
+package debug
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// readBundle returns the files of a gzipped tar archive by name, in the order they were written
+func readBundle(t *testing.T, data []byte) ([]string, map[string]string) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	assert.NoError(t, err)
+	tr := tar.NewReader(gz)
+	names, files := []string{}, map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return names, files
+		}
+		assert.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		assert.NoError(t, err)
+		names = append(names, header.Name)
+		files[header.Name] = string(content)
+	}
+}
+
+func Test_collectBundle(t *testing.T) {
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	collectors := map[string]bundleCollector{
+		ComponentStacktrace: func(ctx context.Context, bundle *bundleWriter, s time.Time) ([]string, error) {
+			assert.Equal(t, since, s)
+			return []string{"stacktrace.txt"}, bundle.addFile("stacktrace.txt", []byte("goroutine 1"))
+		},
+		ComponentVersion: func(ctx context.Context, bundle *bundleWriter, s time.Time) ([]string, error) {
+			return nil, fmt.Errorf("agent unavailable, token: abc")
+		},
+	}
+
+	var out bytes.Buffer
+	manifest, err := collectBundle(context.Background(), []string{ComponentStacktrace, ComponentVersion}, since, &out, collectors)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(manifest.Components))
+	assert.Equal(t, []string{"stacktrace.txt"}, manifest.Components[0].Files)
+	assert.Empty(t, manifest.Components[0].Error)
+	assert.Contains(t, manifest.Components[1].Error, "agent unavailable")
+	assert.NotContains(t, manifest.Components[1].Error, "abc")
+
+	// The manifest is written last, once every component was collected
+	names, files := readBundle(t, out.Bytes())
+	assert.Equal(t, []string{"stacktrace.txt", bundleManifestName}, names)
+	assert.Equal(t, "goroutine 1", files["stacktrace.txt"])
+	written := BundleManifest{}
+	assert.NoError(t, json.Unmarshal([]byte(files[bundleManifestName]), &written))
+	assert.Equal(t, manifest.Components, written.Components)
+	assert.True(t, since.Equal(written.Since))
+
+	_, err = collectBundle(context.Background(), []string{"unknown"}, since, &out, collectors)
+	assert.True(t, errors.IsInvalidInput(err))
+	_, err = collectBundle(context.Background(), nil, since, nil, collectors)
+	assert.True(t, errors.IsInvalidInput(err))
+}
+
+func Test_filterLogSince(t *testing.T) {
+	log := strings.Join([]string{
+		"header without a timestamp",
+		"2024-05-31T23:59:59Z old entry",
+		"  continuation of the old entry",
+		"2024-06-01T00:00:00.5Z new entry",
+		"  continuation of the new entry",
+		"[2024/05/31 10:00:00] old entry",
+		"2024-06-02 08:00:00 new entry",
+	}, "\n")
+
+	var out bytes.Buffer
+	assert.NoError(t, filterLogSince(strings.NewReader(log), &out, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, strings.Join([]string{
+		"header without a timestamp",
+		"2024-06-01T00:00:00.5Z new entry",
+		"  continuation of the new entry",
+		"2024-06-02 08:00:00 new entry",
+	}, "\n"), out.String())
+}
+
+type testResource struct {
+	Name          string `json:"name"`
+	AdminPassword string `json:"adminPassword,omitempty"`
+}
+
+func Test_snapshotResources(t *testing.T) {
+	lister := &resourceLister{
+		locations: func(ctx context.Context) ([]string, interface{}, error) {
+			return []string{"location1"}, []testResource{{Name: "location1"}}, nil
+		},
+		nodes: func(ctx context.Context, location string) (interface{}, error) {
+			return []testResource{{Name: "node1"}}, nil
+		},
+		groups: func(ctx context.Context, location string) ([]string, interface{}, error) {
+			return []string{"group1"}, []testResource{{Name: "group1"}}, nil
+		},
+		virtualMachines: func(ctx context.Context, group string) (interface{}, error) {
+			return []testResource{{Name: "vm1", AdminPassword: "secretvalue"}}, nil
+		},
+		virtualNetworks: func(ctx context.Context, group string) (interface{}, error) {
+			return nil, errors.Wrapf(errors.Failed, "agent unavailable")
+		},
+		networkInterfaces: func(ctx context.Context, group string) (interface{}, error) {
+			return []testResource{}, nil
+		},
+	}
+
+	var out bytes.Buffer
+	bundle := newBundleWriter(&out, time.Now())
+	files, err := snapshotResources(context.Background(), bundle, lister)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "virtualnetworks.json")
+	assert.NoError(t, bundle.close())
+
+	// A kind that fails to list does not stop the others
+	assert.Equal(t, []string{
+		"resources/locations.json",
+		"resources/location1/nodes.json",
+		"resources/location1/groups.json",
+		"resources/location1/group1/virtualmachines.json",
+		"resources/location1/group1/networkinterfaces.json",
+	}, files)
+	_, written := readBundle(t, out.Bytes())
+	assert.Contains(t, written["resources/location1/group1/virtualmachines.json"], "vm1")
+	assert.NotContains(t, written["resources/location1/group1/virtualmachines.json"], "secretvalue")
+}
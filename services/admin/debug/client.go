@@ -17,13 +17,15 @@ type Service interface {
 
 // Client structure
 type DebugClient struct {
-	internal Service
+	internal   Service
+	cloudFQDN  string
+	authorizer auth.Authorizer
 }
 
 // NewClient method returns new client
 func NewDebugClient(cloudFQDN string, authorizer auth.Authorizer) (*DebugClient, error) {
 	c, err := internal.NewDebugClient(cloudFQDN, authorizer)
-	return &DebugClient{c}, err
+	return &DebugClient{internal: c, cloudFQDN: cloudFQDN, authorizer: authorizer}, err
 }
 
 // Stacktrace
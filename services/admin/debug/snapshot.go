@@ -0,0 +1,154 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/services/cloud/group"
+	"github.com/microsoft/moc-sdk-for-go/services/cloud/location"
+	"github.com/microsoft/moc-sdk-for-go/services/cloud/node"
+	"github.com/microsoft/moc-sdk-for-go/services/compute/virtualmachine"
+	"github.com/microsoft/moc-sdk-for-go/services/network/networkinterface"
+	"github.com/microsoft/moc-sdk-for-go/services/network/virtualnetwork"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// resourceLister lists the resources snapshotted into a debug bundle
+type resourceLister struct {
+	locations         func(ctx context.Context) ([]string, interface{}, error)
+	nodes             func(ctx context.Context, location string) (interface{}, error)
+	groups            func(ctx context.Context, location string) ([]string, interface{}, error)
+	virtualMachines   func(ctx context.Context, group string) (interface{}, error)
+	virtualNetworks   func(ctx context.Context, group string) (interface{}, error)
+	networkInterfaces func(ctx context.Context, group string) (interface{}, error)
+}
+
+// collectResources snapshots the locations, their nodes and groups, and the virtual machines, virtual networks and
+// network interfaces of each group
+func (c *DebugClient) collectResources(ctx context.Context, bundle *bundleWriter, since time.Time) ([]string, error) {
+	lister, err := c.getResourceLister()
+	if err != nil {
+		return nil, err
+	}
+	return snapshotResources(ctx, bundle, lister)
+}
+
+func (c *DebugClient) getResourceLister() (*resourceLister, error) {
+	locations, err := location.NewLocationClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := node.NewNodeClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := group.NewGroupClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+	vms, err := virtualmachine.NewVirtualMachineClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+	vnets, err := virtualnetwork.NewVirtualNetworkClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+	nics, err := networkinterface.NewInterfaceClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resourceLister{
+		locations: func(ctx context.Context) ([]string, interface{}, error) {
+			list, err := locations.List(ctx)
+			if err != nil || list == nil {
+				return nil, list, err
+			}
+			names := []string{}
+			for _, l := range *list {
+				if l.Name != nil {
+					names = append(names, *l.Name)
+				}
+			}
+			return names, list, nil
+		},
+		nodes: func(ctx context.Context, location string) (interface{}, error) {
+			return nodes.List(ctx, location)
+		},
+		groups: func(ctx context.Context, location string) ([]string, interface{}, error) {
+			list, err := groups.List(ctx, location)
+			if err != nil || list == nil {
+				return nil, list, err
+			}
+			names := []string{}
+			for _, g := range *list {
+				if g.Name != nil {
+					names = append(names, *g.Name)
+				}
+			}
+			return names, list, nil
+		},
+		virtualMachines: func(ctx context.Context, group string) (interface{}, error) {
+			return vms.List(ctx, group)
+		},
+		virtualNetworks: func(ctx context.Context, group string) (interface{}, error) {
+			return vnets.List(ctx, group)
+		},
+		networkInterfaces: func(ctx context.Context, group string) (interface{}, error) {
+			return nics.List(ctx, group)
+		},
+	}, nil
+}
+
+// snapshotResources adds a JSON file per kind of resource to the bundle. A kind that fails to list is left out and
+// reported in the error, without stopping the snapshot of the others.
+func snapshotResources(ctx context.Context, bundle *bundleWriter, lister *resourceLister) ([]string, error) {
+	files, failures := []string{}, []string{}
+	add := func(name string, value interface{}, err error) bool {
+		if err == nil {
+			err = bundle.addJSON(name, value)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			return false
+		}
+		files = append(files, name)
+		return true
+	}
+
+	locations, list, err := lister.locations(ctx)
+	if !add("resources/locations.json", list, err) {
+		return files, errors.Wrapf(errors.Failed, "%s", strings.Join(failures, "; "))
+	}
+	for _, location := range locations {
+		dir := path.Join("resources", location)
+		nodes, err := lister.nodes(ctx, location)
+		add(path.Join(dir, "nodes.json"), nodes, err)
+
+		groups, list, err := lister.groups(ctx, location)
+		if !add(path.Join(dir, "groups.json"), list, err) {
+			continue
+		}
+		for _, group := range groups {
+			groupDir := path.Join(dir, group)
+			vms, err := lister.virtualMachines(ctx, group)
+			add(path.Join(groupDir, "virtualmachines.json"), vms, err)
+			vnets, err := lister.virtualNetworks(ctx, group)
+			add(path.Join(groupDir, "virtualnetworks.json"), vnets, err)
+			nics, err := lister.networkInterfaces(ctx, group)
+			add(path.Join(groupDir, "networkinterfaces.json"), nics, err)
+		}
+	}
+
+	if len(failures) > 0 {
+		return files, errors.Wrapf(errors.Failed, "%s", strings.Join(failures, "; "))
+	}
+	return files, nil
+}
@@ -6,6 +6,7 @@ package logging
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
 	"github.com/microsoft/moc-sdk-for-go/services/admin/logging/internal"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -13,6 +14,7 @@ import (
 // Service interfacetype Service interface {
 type Service interface {
 	GetLogFile(context.Context, string, string) error
+	GetLogFiles(context.Context, string, string) ([]string, error)
 	SetVerbosityLevel(context.Context, int32, bool) error
 	GetVerbosityLevel(context.Context) (string, error)
 }
@@ -30,9 +32,16 @@ func NewLoggingClient(cloudFQDN string, authorizer auth.Authorizer) (*LoggingCli
 
 // gets a file from the corresponding node agent and writes it to filename
 func (c *LoggingClient) GetLogFile(ctx context.Context, location string, filename string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.GetLogFile(ctx, location, filename)
 }
 
+// gets the log files from the corresponding node agent, writes them to directory and returns their paths
+func (c *LoggingClient) GetLogFiles(ctx context.Context, location string, directory string) ([]string, error) {
+	location = moc.Location(ctx, location)
+	return c.internal.GetLogFiles(ctx, location, directory)
+}
+
 func (c *LoggingClient) SetVerbosityLevel(ctx context.Context, verbositylevel int32, include_nodeagents bool) error {
 	return c.internal.SetVerbosityLevel(ctx, verbositylevel, include_nodeagents)
 }
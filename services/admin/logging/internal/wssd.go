@@ -8,6 +8,7 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -33,13 +34,21 @@ func NewLoggingClient(subID string, authorizer auth.Authorizer) (*client, error)
 
 // Get
 func (c *client) GetLogFile(ctx context.Context, location, filename string) error {
+	_, err := c.GetLogFiles(ctx, location, "")
+	return err
+}
+
+// GetLogFiles receives the log files of the agent into directory and returns their paths.
+// An empty directory writes the files to the current working directory.
+func (c *client) GetLogFiles(ctx context.Context, location, directory string) ([]string, error) {
 	request := getLoggingRequest(location)
 	fileStreamClient, err := c.LogAgentClient.Get(ctx, request)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	doneErr := errors.New("done")
+	files := []string{}
 
 	for err == nil {
 		filename := "bad.log"
@@ -59,13 +68,26 @@ func (c *client) GetLogFile(ctx context.Context, location, filename string) erro
 
 		}
 		tempFilename := strconv.FormatInt(time.Now().Unix(), 16) + ".log"
+		if len(directory) > 0 {
+			tempFilename = filepath.Join(directory, tempFilename)
+		}
 		err = loggingHelpers.ReceiveFile(ctx, tempFilename, recFunc)
-		os.Rename(tempFilename, filename)
+		if err != nil {
+			os.Remove(tempFilename)
+			break
+		}
+		if len(directory) > 0 {
+			filename = filepath.Join(directory, filepath.Base(filename))
+		}
+		if err = os.Rename(tempFilename, filename); err != nil {
+			break
+		}
+		files = append(files, filename)
 	}
 	if err != doneErr {
-		return err
+		return files, err
 	}
-	return nil
+	return files, nil
 }
 
 func (c *client) SetVerbosityLevel(ctx context.Context, verbositylevel int32, include_nodeagents bool) error {
@@ -6,6 +6,7 @@ package version
 import (
 	"context"
 
+	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
 	"github.com/microsoft/moc-sdk-for-go/services/admin/version/internal"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -18,12 +19,17 @@ type Service interface {
 // Client structure
 type VersionClient struct {
 	internal Service
+	// mocVersion caches the moc version negotiated with the agent, shared by the clients of the cloud using the same authorizer
+	mocVersion *wssdcloudclient.MetadataCache[string]
 }
 
 // NewClient method returns new client
 func NewVersionClient(cloudFQDN string, authorizer auth.Authorizer) (*VersionClient, error) {
 	c, err := internal.NewVersionClient(cloudFQDN, authorizer)
-	return &VersionClient{c}, err
+	if err != nil {
+		return &VersionClient{internal: c}, err
+	}
+	return &VersionClient{internal: c, mocVersion: getMocVersionCache(cloudFQDN, authorizer, c)}, nil
 }
 
 // GetVersion
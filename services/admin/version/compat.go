@@ -0,0 +1,71 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license
+
+package version
+
+import (
+	"context"
+	"sync"
+
+	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc/pkg/auth"
+)
+
+// Features of the sdk that need a minimum moc agent version. Clients check them with RequireFeature before making
+// the calls.
+const (
+	// FeatureVirtualMachinePause - Pausing a virtual machine
+	FeatureVirtualMachinePause = "VirtualMachinePause"
+	// FeatureVirtualMachineSave - Saving the state of a virtual machine
+	FeatureVirtualMachineSave = "VirtualMachineSave"
+)
+
+// sdkFeatures holds the minimum moc agent version of each feature of the sdk
+var sdkFeatures = map[string]string{
+	FeatureVirtualMachinePause: "v0.17.0",
+	FeatureVirtualMachineSave:  "v0.17.0",
+}
+
+var (
+	versionCacheMux sync.Mutex
+	versionCaches   = map[wssdcloudclient.MetadataCacheKey]*wssdcloudclient.MetadataCache[string]{}
+)
+
+func getMocVersionCache(cloudFQDN string, authorizer auth.Authorizer, s Service) *wssdcloudclient.MetadataCache[string] {
+	newCache := func() *wssdcloudclient.MetadataCache[string] {
+		return wssdcloudclient.NewMetadataCache(wssdcloudclient.DefaultMetadataTTL, func(ctx context.Context) (string, error) {
+			_, mocVersion, err := s.GetVersion(ctx)
+			return mocVersion, err
+		})
+	}
+	key, ok := wssdcloudclient.NewMetadataCacheKey(cloudFQDN, authorizer)
+	if !ok {
+		return newCache()
+	}
+
+	versionCacheMux.Lock()
+	defer versionCacheMux.Unlock()
+	cache, ok := versionCaches[key]
+	if !ok {
+		cache = newCache()
+		versionCaches[key] = cache
+	}
+	return cache
+}
+
+// getMocVersion returns the moc version of the agent, negotiated once per cloud and authorizer and cached
+func (c *VersionClient) getMocVersion(ctx context.Context) (string, error) {
+	if c.mocVersion == nil {
+		_, mocVersion, err := c.GetVersion(ctx)
+		return mocVersion, err
+	}
+	return c.mocVersion.Get(ctx)
+}
+
+// RefreshVersion negotiates the moc version with the agent again, typically after the agent was upgraded
+func (c *VersionClient) RefreshVersion(ctx context.Context) (string, error) {
+	if c.mocVersion == nil {
+		return c.getMocVersion(ctx)
+	}
+	return c.mocVersion.Refresh(ctx)
+}
@@ -0,0 +1,212 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license
+
+package version
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// FeatureUnavailableError is returned by RequireFeature when the agent does not support a feature
+type FeatureUnavailableError struct {
+	Feature      string
+	MinVersion   string
+	AgentVersion string
+}
+
+func (e *FeatureUnavailableError) Error() string {
+	return fmt.Sprintf("Feature %s requires moc agent version %s or later, agent reports version %s", e.Feature, e.MinVersion, e.AgentVersion)
+}
+
+// Unwrap allows errors.Is(err, errors.NotSupported) checks on the error
+func (e *FeatureUnavailableError) Unwrap() error {
+	return errors.NotSupported
+}
+
+var (
+	featureMux sync.RWMutex
+	features   = getSdkFeatures()
+)
+
+func getSdkFeatures() map[string]string {
+	registered := map[string]string{}
+	for name, minVersion := range sdkFeatures {
+		registered[name] = minVersion
+	}
+	return registered
+}
+
+// RegisterFeature records the minimum moc agent version that supports a feature. The features of the sdk are
+// registered already.
+func RegisterFeature(name, minVersion string) error {
+	if len(name) == 0 {
+		return errors.Wrapf(errors.InvalidInput, "Feature name not specified")
+	}
+	if _, err := parseVersion(minVersion); err != nil {
+		return errors.Wrapf(errors.InvalidInput, "Invalid minimum version %s for feature %s", minVersion, name)
+	}
+	featureMux.Lock()
+	defer featureMux.Unlock()
+	features[name] = minVersion
+	return nil
+}
+
+// GetFeatureMinVersion returns the minimum moc agent version registered for a feature
+func GetFeatureMinVersion(name string) (string, bool) {
+	featureMux.RLock()
+	defer featureMux.RUnlock()
+	minVersion, ok := features[name]
+	return minVersion, ok
+}
+
+// ListFeatures returns the sorted names of the features of the sdk and those registered with RegisterFeature whose
+// minimum version the negotiated agent version meets. The agent does not report feature flags of its own, so features
+// are derived from its version only.
+func (c *VersionClient) ListFeatures(ctx context.Context) ([]string, error) {
+	mocVersion, err := c.getMocVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	featureMux.RLock()
+	defer featureMux.RUnlock()
+	enabled := []string{}
+	for name, minVersion := range features {
+		if ok, _ := IsVersionAtLeast(mocVersion, minVersion); ok {
+			enabled = append(enabled, name)
+		}
+	}
+	sort.Strings(enabled)
+	return enabled, nil
+}
+
+// RequireFeature returns a FeatureUnavailableError if the agent does not support the feature
+func (c *VersionClient) RequireFeature(ctx context.Context, name string) error {
+	minVersion, ok := GetFeatureMinVersion(name)
+	if !ok {
+		return errors.Wrapf(errors.NotFound, "Feature %s is not registered", name)
+	}
+	mocVersion, err := c.getMocVersion(ctx)
+	if err != nil {
+		return err
+	}
+	supported, err := IsVersionAtLeast(mocVersion, minVersion)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return &FeatureUnavailableError{Feature: name, MinVersion: minVersion, AgentVersion: mocVersion}
+	}
+	return nil
+}
+
+// IsVersionAtLeast reports whether version is greater than or equal to minVersion. Versions are dotted numbers with
+// an optional leading "v", optional pre-release suffix and optional build suffix. As in semantic versioning, a
+// pre-release is lower than its release, so v0.20.4-rc1 is lower than v0.20.4, and build suffixes are ignored.
+func IsVersionAtLeast(version, minVersion string) (bool, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return false, errors.Wrapf(errors.InvalidInput, "Invalid version %s", version)
+	}
+	m, err := parseVersion(minVersion)
+	if err != nil {
+		return false, errors.Wrapf(errors.InvalidInput, "Invalid version %s", minVersion)
+	}
+	return compareVersions(v, m) >= 0, nil
+}
+
+type parsedVersion struct {
+	numbers    []int
+	prerelease []string
+}
+
+func compareVersions(a, b parsedVersion) int {
+	for i := 0; i < len(a.numbers) || i < len(b.numbers); i++ {
+		var x, y int
+		if i < len(a.numbers) {
+			x = a.numbers[i]
+		}
+		if i < len(b.numbers) {
+			y = b.numbers[i]
+		}
+		if x != y {
+			return compareInts(x, y)
+		}
+	}
+
+	// A release is greater than any of its pre-releases
+	switch {
+	case len(a.prerelease) == 0 && len(b.prerelease) == 0:
+		return 0
+	case len(a.prerelease) == 0:
+		return 1
+	case len(b.prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(a.prerelease) && i < len(b.prerelease); i++ {
+		if c := comparePrereleaseIdentifiers(a.prerelease[i], b.prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(a.prerelease), len(b.prerelease))
+}
+
+// comparePrereleaseIdentifiers compares numeric identifiers numerically and others in ASCII order. Numeric
+// identifiers are lower than others.
+func comparePrereleaseIdentifiers(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return compareInts(x, y)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func parseVersion(version string) (parsedVersion, error) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.Index(version, "+"); i >= 0 {
+		version = version[:i]
+	}
+	result := parsedVersion{}
+	if i := strings.Index(version, "-"); i >= 0 {
+		prerelease := version[i+1:]
+		version = version[:i]
+		if len(prerelease) == 0 {
+			return result, fmt.Errorf("empty pre-release")
+		}
+		result.prerelease = strings.Split(prerelease, ".")
+	}
+	if len(version) == 0 {
+		return result, fmt.Errorf("empty version")
+	}
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return result, fmt.Errorf("invalid version component %q", part)
+		}
+		result.numbers = append(result.numbers, n)
+	}
+	return result, nil
+}
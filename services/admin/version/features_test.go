@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license
+
+package version
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeVersionService struct {
+	mocVersion string
+}
+
+func (f *fakeVersionService) GetVersion(context.Context) (string, string, error) {
+	return "1.0.0", f.mocVersion, nil
+}
+
+func Test_IsVersionAtLeast(t *testing.T) {
+	cases := []struct {
+		version    string
+		minVersion string
+		expected   bool
+	}{
+		{"v0.20.4", "0.20.4", true},
+		{"v0.20.4", "v0.20", true},
+		{"v0.20.4-rc1", "v0.21.0", false},
+		{"1.2", "1.2.1", false},
+		{"2.0.0+build", "1.99.99", true},
+		{"v0.20.4-rc1", "v0.20.4", false},
+		{"v0.20.4", "v0.20.4-rc1", true},
+		{"v0.20.4-rc1", "v0.20.4-rc1", true},
+		{"v0.20.4-rc.2", "v0.20.4-rc.10", false},
+		{"v0.20.4-rc", "v0.20.4-rc.1", false},
+		{"v0.20.4-alpha", "v0.20.4-1", true},
+		{"v0.20.5-rc1", "v0.20.4", true},
+		{"v0.20.4-rc1+build", "v0.20.4-rc1", true},
+	}
+	for _, c := range cases {
+		ok, err := IsVersionAtLeast(c.version, c.minVersion)
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, ok, "%s >= %s", c.version, c.minVersion)
+	}
+
+	_, err := IsVersionAtLeast("latest", "0.1")
+	assert.True(t, errors.IsInvalidInput(err))
+	_, err = IsVersionAtLeast("v0.20.4-", "0.1")
+	assert.True(t, errors.IsInvalidInput(err))
+}
+
+func Test_RequireFeature(t *testing.T) {
+	assert.Nil(t, RegisterFeature("test-old", "v0.10.0"))
+	assert.Nil(t, RegisterFeature("test-new", "v0.30.0"))
+	c := &VersionClient{internal: &fakeVersionService{mocVersion: "v0.20.4"}}
+
+	assert.Nil(t, c.RequireFeature(context.Background(), "test-old"))
+
+	err := c.RequireFeature(context.Background(), "test-new")
+	var unavailable *FeatureUnavailableError
+	assert.True(t, goerrors.As(err, &unavailable))
+	assert.Equal(t, "v0.30.0", unavailable.MinVersion)
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+
+	assert.True(t, errors.IsNotFound(c.RequireFeature(context.Background(), "test-missing")))
+
+	enabled, err := c.ListFeatures(context.Background())
+	assert.Nil(t, err)
+	assert.Contains(t, enabled, "test-old")
+	assert.NotContains(t, enabled, "test-new")
+}
+
+func Test_FeatureCompatibilityMatrix(t *testing.T) {
+	assert.Nil(t, RegisterFeature("test-matrix-a", "v0.18.0"))
+	assert.Nil(t, RegisterFeature("test-matrix-b", "v0.20.4"))
+	assert.Nil(t, RegisterFeature("test-matrix-c", "v1.0.0"))
+
+	matrix := map[string]map[string]bool{
+		"v0.17.9":     {"test-matrix-a": false, "test-matrix-b": false, "test-matrix-c": false},
+		"v0.18.0":     {"test-matrix-a": true, "test-matrix-b": false, "test-matrix-c": false},
+		"v0.20.4-rc1": {"test-matrix-a": true, "test-matrix-b": false, "test-matrix-c": false},
+		"v0.20.4":     {"test-matrix-a": true, "test-matrix-b": true, "test-matrix-c": false},
+		"v1.0.0":      {"test-matrix-a": true, "test-matrix-b": true, "test-matrix-c": true},
+	}
+	for agentVersion, expected := range matrix {
+		c := &VersionClient{internal: &fakeVersionService{mocVersion: agentVersion}}
+		for feature, supported := range expected {
+			err := c.RequireFeature(context.Background(), feature)
+			if supported {
+				assert.Nil(t, err, "%s on agent %s", feature, agentVersion)
+				continue
+			}
+			var unsupported *FeatureUnavailableError
+			assert.True(t, goerrors.As(err, &unsupported), "%s on agent %s", feature, agentVersion)
+			minVersion, _ := GetFeatureMinVersion(feature)
+			assert.Equal(t, minVersion, unsupported.MinVersion)
+		}
+	}
+}
+
+func Test_SdkFeatures(t *testing.T) {
+	for _, name := range []string{FeatureVirtualMachinePause, FeatureVirtualMachineSave} {
+		_, ok := GetFeatureMinVersion(name)
+		assert.True(t, ok, name)
+	}
+
+	c := &VersionClient{internal: &fakeVersionService{mocVersion: "v0.16.0"}}
+	err := c.RequireFeature(context.Background(), FeatureVirtualMachinePause)
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+
+	c = &VersionClient{internal: &fakeVersionService{mocVersion: "v0.20.4"}}
+	assert.Nil(t, c.RequireFeature(context.Background(), FeatureVirtualMachineSave))
+}
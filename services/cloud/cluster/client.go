@@ -5,6 +5,12 @@ package cluster
 
 import (
 	"context"
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -31,20 +37,63 @@ func NewClusterClient(cloudFQDN string, authorizer auth.Authorizer) (*ClusterCli
 
 // Get methods invokes the client Get method
 func (c *ClusterClient) Get(ctx context.Context, location, name string) (*[]cloud.Cluster, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *ClusterClient) List(ctx context.Context, location string, opts ...list.Options) (*[]cloud.Cluster, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *ClusterClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]cloud.Cluster] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]cloud.Cluster, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *ClusterClient) NewLister(location string) *list.Lister[cloud.Cluster] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]cloud.Cluster, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *ClusterClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]cloud.Cluster, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/Cluster/{name}, see resourceid.ID
+func (c *ClusterClient) GetByID(ctx context.Context, id string) (*cloud.Cluster, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.Cluster, c.Get)
+}
+
 // GetNodes methods invokes the client GetNodes method
 func (c *ClusterClient) GetNodes(ctx context.Context, location, name string) (*[]cloud.Node, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.GetNodes(ctx, location, name)
 }
 
 // Load methods invokes create or update on the client
 func (c *ClusterClient) Load(ctx context.Context, location, name string, cloud *cloud.Cluster) (*cloud.Cluster, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.Load(ctx, location, name, cloud)
 }
 
 // Unload methods invokes delete of the cloud resource
 func (c *ClusterClient) Unload(ctx context.Context, location, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Unload(ctx, location, name)
 }
@@ -4,6 +4,7 @@
 package cluster
 
 import (
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -52,7 +53,7 @@ func getCluster(gp *wssdcloud.Cluster) *cloud.Cluster {
 		Name: &gp.Name,
 		ClusterProperties: &cloud.ClusterProperties{
 			FQDN:     &gp.Fqdn,
-			Statuses: status.GetStatuses(gp.GetStatus()),
+			Statuses: provisioning.GetStatuses(gp.GetStatus()),
 		},
 		Nodes:    &nodes,
 		Location: &gp.LocationName,
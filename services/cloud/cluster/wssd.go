@@ -6,6 +6,7 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 
 	wssdclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
@@ -157,6 +158,7 @@ func (c *client) getClusterFromResponse(response *wssdcloud.ClusterResponse) *[]
 		gps = append(gps, *(getCluster(gp)))
 	}
 
+	list.SortResponse(&gps)
 	return &gps
 }
 
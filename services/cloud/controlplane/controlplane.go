@@ -6,6 +6,7 @@ package controlplane
 import (
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/convert"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -58,7 +59,7 @@ func getControlPlane(cp *wssdcloud.ControlPlane) *cloud.ControlPlaneInfo {
 }
 
 func getControlPlaneStatuses(cp *wssdcloud.ControlPlane) map[string]*string {
-	statuses := status.GetStatuses(cp.GetStatus())
+	statuses := provisioning.GetStatuses(cp.GetStatus())
 	statuses["State"] = convert.ToStringPtr(cp.GetState().String())
 	return statuses
 }
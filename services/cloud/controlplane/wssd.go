@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 
 	"github.com/microsoft/moc/pkg/auth"
@@ -126,6 +127,7 @@ func (c *client) getControlPlaneFromResponse(response *wssdcloud.ControlPlaneRes
 		gps = append(gps, *(getControlPlane(gp)))
 	}
 
+	list.SortResponse(&gps)
 	return &gps
 }
 
@@ -6,6 +6,12 @@ package etcdcluster
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -35,15 +41,57 @@ func NewEtcdClusterClient(cloudFQDN string, authorizer auth.Authorizer) (*EtcdCl
 
 // Get methods invokes the client Get method
 func (c *EtcdClusterClient) Get(ctx context.Context, group, name string) (*[]cloud.EtcdCluster, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *EtcdClusterClient) List(ctx context.Context, group string, opts ...list.Options) (*[]cloud.EtcdCluster, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *EtcdClusterClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]cloud.EtcdCluster] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]cloud.EtcdCluster, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *EtcdClusterClient) NewLister(group string) *list.Lister[cloud.EtcdCluster] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]cloud.EtcdCluster, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *EtcdClusterClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]cloud.EtcdCluster, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/EtcdCluster/{name}, see resourceid.ID
+func (c *EtcdClusterClient) GetByID(ctx context.Context, id string) (*cloud.EtcdCluster, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.EtcdCluster, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *EtcdClusterClient) CreateOrUpdate(ctx context.Context, group, name string, etcdcluster *cloud.EtcdCluster) (*cloud.EtcdCluster, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, etcdcluster)
 }
 
 // Delete methods invokes delete of the etcdcluster resource
 func (c *EtcdClusterClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
@@ -6,6 +6,7 @@ package etcdcluster
 import (
 	"github.com/Azure/go-autorest/autorest"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -51,7 +52,7 @@ func getEtcdCluster(cluster *wssdcloudcloud.EtcdCluster, group string) *cloud.Et
 		EtcdClusterProperties: &cloud.EtcdClusterProperties{
 			CaCertificate: &cluster.CaCertificate,
 			CaKey:         &cluster.CaKey,
-			Statuses:      status.GetStatuses(cluster.GetStatus()),
+			Statuses:      provisioning.GetStatuses(cluster.GetStatus()),
 		},
 	}
 }
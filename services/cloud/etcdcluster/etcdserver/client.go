@@ -6,6 +6,9 @@ package etcdserver
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud/etcdcluster"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -35,15 +38,31 @@ func NewEtcdServerClient(cloudFQDN string, authorizer auth.Authorizer) (*EtcdSer
 
 // Get methods invokes the client Get method
 func (c *EtcdServerClient) Get(ctx context.Context, group, name, clusterName string) (*[]etcdcluster.EtcdServer, error) {
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
+	group = moc.Group(ctx, group)
 	return c.internal.Get(ctx, group, name, clusterName)
 }
 
+// List methods returns all the resources in the cluster
+func (c *EtcdServerClient) List(ctx context.Context, group, clusterName string, opts ...list.Options) (*[]etcdcluster.EtcdServer, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "", clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *EtcdServerClient) CreateOrUpdate(ctx context.Context, group, name string, server *etcdcluster.EtcdServer) (*etcdcluster.EtcdServer, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, server)
 }
 
 // Delete methods invokes delete of the etcdcluster resource
 func (c *EtcdServerClient) Delete(ctx context.Context, group, name, clusterName string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name, clusterName)
 }
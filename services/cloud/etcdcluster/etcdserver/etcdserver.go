@@ -6,6 +6,7 @@ package etcdserver
 import (
 	"github.com/microsoft/moc-sdk-for-go/services/cloud/etcdcluster"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
 	wssdcloudcloud "github.com/microsoft/moc/rpc/cloudagent/cloud"
@@ -19,7 +20,7 @@ func getEtcdServer(sec *wssdcloudcloud.EtcdServer, clusterName string) *etcdclus
 		Version: &sec.Status.Version.Number,
 		EtcdServerProperties: &etcdcluster.EtcdServerProperties{
 			ClusterName: &clusterName,
-			Statuses:    status.GetStatuses(sec.GetStatus()),
+			Statuses:    provisioning.GetStatuses(sec.GetStatus()),
 			Fqdn:        &sec.Fqdn,
 			ClientPort:  sec.ClientPort,
 		},
@@ -0,0 +1,180 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package etcdserver
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
+	"github.com/microsoft/moc-sdk-for-go/services/cloud/etcdcluster"
+	"github.com/microsoft/moc/pkg/errors"
+	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
+)
+
+// QuorumHealth describes the membership and quorum state of an etcd cluster
+type QuorumHealth struct {
+	// Members - Names of all the members of the cluster
+	Members []string
+	// UnhealthyMembers - Names of the members the agent reports in a health state other than healthy
+	UnhealthyMembers []string
+	// UnknownMembers - Names of the members without a health state
+	UnknownMembers []string
+	// QuorumSize - Number of healthy members required for the cluster to make progress
+	QuorumSize int
+	// FaultTolerance - Number of further member failures the cluster can survive
+	FaultTolerance int
+	// HasQuorum - Whether enough members are healthy to maintain quorum
+	HasQuorum bool
+}
+
+// ListMembers returns the servers that are members of the etcd cluster
+func (c *EtcdServerClient) ListMembers(ctx context.Context, group, clusterName string) (*[]etcdcluster.EtcdServer, error) {
+	return c.List(ctx, group, clusterName)
+}
+
+// GetQuorumHealth returns the quorum state of the etcd cluster
+func (c *EtcdServerClient) GetQuorumHealth(ctx context.Context, group, clusterName string) (*QuorumHealth, error) {
+	servers, err := c.ListMembers(ctx, group, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return getQuorumHealth(servers), nil
+}
+
+// AddMember adds a server to the etcd cluster
+func (c *EtcdServerClient) AddMember(ctx context.Context, group string, server *etcdcluster.EtcdServer) (*etcdcluster.EtcdServer, error) {
+	if server == nil || server.Name == nil || server.EtcdServerProperties == nil || server.ClusterName == nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "Invalid Configuration")
+	}
+	servers, err := c.ListMembers(ctx, group, *server.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range *servers {
+		if member.Name != nil && strings.EqualFold(*member.Name, *server.Name) {
+			return nil, errors.Wrapf(errors.AlreadyExists, "Etcd server [%s] is already a member of cluster [%s]", *server.Name, *server.ClusterName)
+		}
+	}
+	return c.CreateOrUpdate(ctx, group, *server.Name, server)
+}
+
+// RemoveMember removes a server from the etcd cluster. Removing a healthy member that would leave the
+// cluster without quorum is refused unless force is set.
+func (c *EtcdServerClient) RemoveMember(ctx context.Context, group, clusterName, name string, force bool) error {
+	servers, err := c.ListMembers(ctx, group, clusterName)
+	if err != nil {
+		return err
+	}
+	if err := validateMemberRemoval(servers, clusterName, name, force); err != nil {
+		return err
+	}
+	return c.Delete(ctx, group, name, clusterName)
+}
+
+// RemoveUnhealthyMembers removes every member the agent reports unhealthy and returns their names. Members whose health
+// is unknown are kept. Nothing is removed if the cluster has no quorum, since etcd cannot change the membership of such
+// a cluster, and the healthy members that hold the quorum are never removed.
+func (c *EtcdServerClient) RemoveUnhealthyMembers(ctx context.Context, group, clusterName string) ([]string, error) {
+	health, err := c.GetQuorumHealth(ctx, group, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if !health.HasQuorum {
+		return nil, errors.Wrapf(errors.InvalidInput, "Etcd cluster [%s] has no quorum, unhealthy members cannot be removed", clusterName)
+	}
+	removed := []string{}
+	for _, name := range health.UnhealthyMembers {
+		if err := c.Delete(ctx, group, name, clusterName); err != nil {
+			return removed, err
+		}
+		removed = append(removed, name)
+	}
+	return removed, nil
+}
+
+// ReplaceMember removes a member from the etcd cluster and adds the replacement server in its place
+func (c *EtcdServerClient) ReplaceMember(ctx context.Context, group, clusterName, name string, replacement *etcdcluster.EtcdServer, force bool) (*etcdcluster.EtcdServer, error) {
+	if replacement == nil || replacement.Name == nil || replacement.EtcdServerProperties == nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "Invalid Configuration")
+	}
+	server := *replacement
+	properties := *replacement.EtcdServerProperties
+	properties.ClusterName = &clusterName
+	server.EtcdServerProperties = &properties
+	if err := c.RemoveMember(ctx, group, clusterName, name, force); err != nil {
+		return nil, err
+	}
+	return c.AddMember(ctx, group, &server)
+}
+
+func isHealthyMember(server *etcdcluster.EtcdServer) bool {
+	return getHealthState(server) == wssdcloudcommon.HealthState_OK
+}
+
+// getHealthState returns the current health state the agent reports for the server, NOTKNOWN if it reports none
+func getHealthState(server *etcdcluster.EtcdServer) wssdcloudcommon.HealthState {
+	if server.EtcdServerProperties == nil {
+		return wssdcloudcommon.HealthState_NOTKNOWN
+	}
+	return provisioning.GetHealthState(server.Statuses)
+}
+
+func getQuorumHealth(servers *[]etcdcluster.EtcdServer) *QuorumHealth {
+	health := &QuorumHealth{Members: []string{}, UnhealthyMembers: []string{}, UnknownMembers: []string{}}
+	healthy := 0
+	if servers != nil {
+		for i := range *servers {
+			server := &(*servers)[i]
+			if server.Name == nil {
+				continue
+			}
+			health.Members = append(health.Members, *server.Name)
+			switch {
+			case isHealthyMember(server):
+				healthy++
+			case getHealthState(server) == wssdcloudcommon.HealthState_NOTKNOWN:
+				health.UnknownMembers = append(health.UnknownMembers, *server.Name)
+			default:
+				health.UnhealthyMembers = append(health.UnhealthyMembers, *server.Name)
+			}
+		}
+	}
+	sort.Strings(health.Members)
+	sort.Strings(health.UnhealthyMembers)
+	sort.Strings(health.UnknownMembers)
+
+	health.QuorumSize = len(health.Members)/2 + 1
+	health.HasQuorum = healthy >= health.QuorumSize
+	if health.HasQuorum {
+		health.FaultTolerance = healthy - health.QuorumSize
+	}
+	return health
+}
+
+func validateMemberRemoval(servers *[]etcdcluster.EtcdServer, clusterName, name string, force bool) error {
+	var member *etcdcluster.EtcdServer
+	remaining := []etcdcluster.EtcdServer{}
+	if servers != nil {
+		for i := range *servers {
+			server := &(*servers)[i]
+			if server.Name != nil && strings.EqualFold(*server.Name, name) {
+				member = server
+				continue
+			}
+			remaining = append(remaining, *server)
+		}
+	}
+	if member == nil {
+		return errors.Wrapf(errors.NotFound, "Etcd server [%s] is not a member of cluster [%s]", name, clusterName)
+	}
+	if force || !isHealthyMember(member) {
+		return nil
+	}
+	if !getQuorumHealth(&remaining).HasQuorum {
+		return errors.Wrapf(errors.InvalidInput, "Removing healthy etcd server [%s] would leave cluster [%s] without quorum", name, clusterName)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package etcdserver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
+	"github.com/microsoft/moc-sdk-for-go/services/cloud/etcdcluster"
+	"github.com/microsoft/moc/pkg/errors"
+	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// getTestEtcdServer returns a server with the statuses the agent reports for the health state
+func getTestEtcdServer(name string, health wssdcloudcommon.HealthState) etcdcluster.EtcdServer {
+	return etcdcluster.EtcdServer{
+		Name: &name,
+		EtcdServerProperties: &etcdcluster.EtcdServerProperties{
+			Statuses: provisioning.GetStatuses(&wssdcloudcommon.Status{
+				Health: &wssdcloudcommon.Health{CurrentState: health},
+			}),
+		},
+	}
+}
+
+func Test_getQuorumHealth(t *testing.T) {
+	servers := []etcdcluster.EtcdServer{
+		getTestEtcdServer("etcd-0", wssdcloudcommon.HealthState_OK),
+		getTestEtcdServer("etcd-1", wssdcloudcommon.HealthState_OK),
+		getTestEtcdServer("etcd-2", wssdcloudcommon.HealthState_CRITICAL),
+	}
+	health := getQuorumHealth(&servers)
+	assert.Equal(t, []string{"etcd-0", "etcd-1", "etcd-2"}, health.Members)
+	assert.Equal(t, []string{"etcd-2"}, health.UnhealthyMembers)
+	assert.Equal(t, 2, health.QuorumSize)
+	assert.Equal(t, 0, health.FaultTolerance)
+	assert.True(t, health.HasQuorum)
+
+	servers[1] = getTestEtcdServer("etcd-1", wssdcloudcommon.HealthState_CRITICAL)
+	assert.False(t, getQuorumHealth(&servers).HasQuorum)
+
+	servers[1] = getTestEtcdServer("etcd-1", wssdcloudcommon.HealthState_NOTKNOWN)
+	servers = append(servers, etcdcluster.EtcdServer{Name: strPtr("etcd-3")})
+	health = getQuorumHealth(&servers)
+	assert.Equal(t, []string{"etcd-2"}, health.UnhealthyMembers)
+	assert.Equal(t, []string{"etcd-1", "etcd-3"}, health.UnknownMembers)
+	assert.Equal(t, 3, health.QuorumSize)
+	assert.False(t, health.HasQuorum)
+}
+
+func Test_validateMemberRemoval(t *testing.T) {
+	servers := []etcdcluster.EtcdServer{
+		getTestEtcdServer("etcd-0", wssdcloudcommon.HealthState_OK),
+		getTestEtcdServer("etcd-1", wssdcloudcommon.HealthState_OK),
+		getTestEtcdServer("etcd-2", wssdcloudcommon.HealthState_CRITICAL),
+	}
+	assert.Nil(t, validateMemberRemoval(&servers, "cluster", "etcd-2", false))
+	assert.True(t, errors.IsInvalidInput(validateMemberRemoval(&servers, "cluster", "etcd-0", false)))
+	assert.Nil(t, validateMemberRemoval(&servers, "cluster", "etcd-0", true))
+	assert.True(t, errors.IsNotFound(validateMemberRemoval(&servers, "cluster", "etcd-3", false)))
+}
+
+type fakeEtcdServerService struct {
+	Service
+	servers []etcdcluster.EtcdServer
+	created []*etcdcluster.EtcdServer
+}
+
+func (f *fakeEtcdServerService) Get(ctx context.Context, group, name, clusterName string) (*[]etcdcluster.EtcdServer, error) {
+	servers := []etcdcluster.EtcdServer{}
+	for _, server := range f.servers {
+		if len(name) == 0 || strings.EqualFold(*server.Name, name) {
+			servers = append(servers, server)
+		}
+	}
+	return &servers, nil
+}
+
+func (f *fakeEtcdServerService) CreateOrUpdate(ctx context.Context, group, name string, server *etcdcluster.EtcdServer) (*etcdcluster.EtcdServer, error) {
+	f.created = append(f.created, server)
+	f.servers = append(f.servers, *server)
+	return server, nil
+}
+
+func (f *fakeEtcdServerService) Delete(ctx context.Context, group, name, clusterName string) error {
+	for i, server := range f.servers {
+		if strings.EqualFold(*server.Name, name) {
+			f.servers = append(f.servers[:i], f.servers[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Wrapf(errors.NotFound, "Etcd server [%s] not found", name)
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func Test_RemoveUnhealthyMembers(t *testing.T) {
+	fake := &fakeEtcdServerService{servers: []etcdcluster.EtcdServer{
+		getTestEtcdServer("etcd-0", wssdcloudcommon.HealthState_OK),
+		getTestEtcdServer("etcd-1", wssdcloudcommon.HealthState_OK),
+		getTestEtcdServer("etcd-2", wssdcloudcommon.HealthState_CRITICAL),
+		getTestEtcdServer("etcd-3", wssdcloudcommon.HealthState_OK),
+		{Name: strPtr("etcd-4")},
+	}}
+	c := &EtcdServerClient{internal: fake}
+
+	// Members are listed through List, so strict Get does not affect them
+	naming.SetStrictGet(true)
+	defer naming.SetStrictGet(false)
+	removed, err := c.RemoveUnhealthyMembers(context.Background(), "group", "cluster")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"etcd-2"}, removed)
+	// The member without a health state is kept
+	assert.Equal(t, 4, len(fake.servers))
+
+	// Without quorum nothing is removed, so that the cluster is never emptied
+	fake.servers = []etcdcluster.EtcdServer{
+		getTestEtcdServer("etcd-0", wssdcloudcommon.HealthState_CRITICAL),
+		getTestEtcdServer("etcd-1", wssdcloudcommon.HealthState_CRITICAL),
+	}
+	removed, err = c.RemoveUnhealthyMembers(context.Background(), "group", "cluster")
+	assert.True(t, errors.IsInvalidInput(err))
+	assert.Equal(t, 0, len(removed))
+	assert.Equal(t, 2, len(fake.servers))
+}
+
+func Test_ReplaceMember(t *testing.T) {
+	fake := &fakeEtcdServerService{servers: []etcdcluster.EtcdServer{
+		getTestEtcdServer("etcd-0", wssdcloudcommon.HealthState_OK),
+		getTestEtcdServer("etcd-1", wssdcloudcommon.HealthState_OK),
+		getTestEtcdServer("etcd-2", wssdcloudcommon.HealthState_CRITICAL),
+	}}
+	c := &EtcdServerClient{internal: fake}
+	replacement := getTestEtcdServer("etcd-3", wssdcloudcommon.HealthState_NOTKNOWN)
+
+	_, err := c.ReplaceMember(context.Background(), "group", "cluster", "etcd-2", &replacement, false)
+	assert.NoError(t, err)
+	assert.Nil(t, replacement.ClusterName)
+	assert.Equal(t, 1, len(fake.created))
+	assert.Equal(t, "cluster", *fake.created[0].ClusterName)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud/etcdcluster"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
@@ -110,6 +111,7 @@ func getEtcdServersFromResponse(response *wssdcloudcloud.EtcdServerResponse, clu
 		etcdServers = append(etcdServers, *(getEtcdServer(etcdservers, clusterName)))
 	}
 
+	list.SortResponse(&etcdServers)
 	return &etcdServers
 }
 
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -85,6 +86,7 @@ func getEtcdClustersFromResponse(response *wssdcloudcloud.EtcdClusterResponse, g
 		vaults = append(vaults, *(getEtcdCluster(etcdclusters, group)))
 	}
 
+	list.SortResponse(&vaults)
 	return &vaults
 }
 
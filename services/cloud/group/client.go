@@ -5,6 +5,12 @@ package group
 
 import (
 	"context"
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -17,6 +23,7 @@ type Service interface {
 
 type GroupClient struct {
 	internal Service
+	defaults *networkDefaultsCaches
 }
 
 func NewGroupClient(cloudFQDN string, authorizer auth.Authorizer) (*GroupClient, error) {
@@ -25,20 +32,62 @@ func NewGroupClient(cloudFQDN string, authorizer auth.Authorizer) (*GroupClient,
 		return nil, err
 	}
 
-	return &GroupClient{internal: c}, nil
+	return &GroupClient{internal: c, defaults: getNetworkDefaultsCaches(cloudFQDN, authorizer)}, nil
 }
 
 // Get methods invokes the client Get method
 func (c *GroupClient) Get(ctx context.Context, location, name string) (*[]cloud.Group, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *GroupClient) List(ctx context.Context, location string, opts ...list.Options) (*[]cloud.Group, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *GroupClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]cloud.Group] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]cloud.Group, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *GroupClient) NewLister(location string) *list.Lister[cloud.Group] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]cloud.Group, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *GroupClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]cloud.Group, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/Group/{name}, see resourceid.ID
+func (c *GroupClient) GetByID(ctx context.Context, id string) (*cloud.Group, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.Group, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *GroupClient) CreateOrUpdate(ctx context.Context, location, name string, cloud *cloud.Group) (*cloud.Group, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.CreateOrUpdate(ctx, location, name, cloud)
 }
 
 // Delete methods invokes delete of the cloud resource
 func (c *GroupClient) Delete(ctx context.Context, location, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Delete(ctx, location, name)
 }
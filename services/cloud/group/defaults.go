@@ -0,0 +1,258 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourcetags"
+	"github.com/microsoft/moc-sdk-for-go/services/cloud"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/auth"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+const (
+	// DefaultNetworkSecurityGroupTag holds the name of the network security group associated with new
+	// subnets and network interfaces of the group
+	DefaultNetworkSecurityGroupTag = resourcetags.ReservedPrefix + "defaultnetworksecuritygroup"
+	// DefaultRouteTableTag holds the route table, in JSON, associated with new subnets of the group.
+	// The agent has no route table resource, so the routes are stored on the group.
+	DefaultRouteTableTag = resourcetags.ReservedPrefix + "defaultroutetable"
+)
+
+// NetworkDefaults are the references associated with new subnets and network interfaces of a group that do not
+// specify their own
+type NetworkDefaults struct {
+	// NetworkSecurityGroup - Name of a network security group of the group, empty for none
+	NetworkSecurityGroup string
+	// RouteTable - Routes of new subnets, nil for none
+	RouteTable *network.RouteTable
+}
+
+// IsEmpty returns true if the defaults associate nothing with new subnets and network interfaces
+func (d *NetworkDefaults) IsEmpty() bool {
+	return d == nil || (len(d.NetworkSecurityGroup) == 0 && d.RouteTable == nil)
+}
+
+type networkDefaultsKey struct {
+	location string
+	group    string
+}
+
+// networkDefaultsCaches holds the network defaults of the groups of a cloud, so that creating a subnet or network
+// interface does not read its group every time
+type networkDefaultsCaches struct {
+	mux    sync.Mutex
+	caches map[networkDefaultsKey]*wssdcloudclient.MetadataCache[*NetworkDefaults]
+}
+
+var (
+	defaultsCacheMux sync.Mutex
+	// defaultsCaches holds the network defaults of the groups of each cloud, shared by the clients of the cloud
+	// using the same authorizer
+	defaultsCaches = map[wssdcloudclient.MetadataCacheKey]*networkDefaultsCaches{}
+)
+
+func newNetworkDefaultsCaches() *networkDefaultsCaches {
+	return &networkDefaultsCaches{caches: map[networkDefaultsKey]*wssdcloudclient.MetadataCache[*NetworkDefaults]{}}
+}
+
+func getNetworkDefaultsCaches(cloudFQDN string, authorizer auth.Authorizer) *networkDefaultsCaches {
+	key, ok := wssdcloudclient.NewMetadataCacheKey(cloudFQDN, authorizer)
+	if !ok {
+		return newNetworkDefaultsCaches()
+	}
+
+	defaultsCacheMux.Lock()
+	defer defaultsCacheMux.Unlock()
+	caches, ok := defaultsCaches[key]
+	if !ok {
+		caches = newNetworkDefaultsCaches()
+		defaultsCaches[key] = caches
+	}
+	return caches
+}
+
+// get returns the cache of the network defaults of the group, fetching them with fetch
+func (c *networkDefaultsCaches) get(location, name string, fetch func(ctx context.Context) (*NetworkDefaults, error)) *wssdcloudclient.MetadataCache[*NetworkDefaults] {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	key := networkDefaultsKey{location: location, group: name}
+	cache, ok := c.caches[key]
+	if !ok {
+		cache = wssdcloudclient.NewMetadataCache(wssdcloudclient.DefaultMetadataTTL, fetch)
+		c.caches[key] = cache
+	}
+	return cache
+}
+
+// invalidate makes the next lookup of the network defaults of the group read the group
+func (c *networkDefaultsCaches) invalidate(location, name string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if cache, ok := c.caches[networkDefaultsKey{location: location, group: name}]; ok {
+		cache.Invalidate()
+	}
+}
+
+// SetNetworkDefaults stores the network defaults on the group. Empty defaults remove any stored ones.
+// Existing subnets and network interfaces are not changed.
+func (c *GroupClient) SetNetworkDefaults(ctx context.Context, location, name string, defaults *NetworkDefaults) error {
+	if defaults == nil {
+		defaults = &NetworkDefaults{}
+	}
+	tags, err := getNetworkDefaultsTags(defaults)
+	if err != nil {
+		return err
+	}
+
+	location = moc.Location(ctx, location)
+	for {
+		grp, err := c.getGroup(ctx, location, name)
+		if err != nil {
+			return err
+		}
+		grp.Tags = resourcetags.Merge(grp.Tags, tags)
+		for key, value := range tags {
+			if value == nil {
+				delete(grp.Tags, key)
+			}
+		}
+
+		_, err = c.CreateOrUpdate(ctx, location, name, grp)
+		if err == nil {
+			if c.defaults != nil {
+				c.defaults.invalidate(location, name)
+			}
+			return nil
+		}
+		// Retry only on invalid version
+		if !errors.IsInvalidVersion(err) {
+			return err
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// GetNetworkDefaults returns the network defaults of the group. A group without defaults returns empty defaults.
+func (c *GroupClient) GetNetworkDefaults(ctx context.Context, location, name string) (*NetworkDefaults, error) {
+	grp, err := c.getGroup(ctx, moc.Location(ctx, location), name)
+	if err != nil {
+		return nil, err
+	}
+	return GetNetworkDefaultsFromTags(grp.Tags)
+}
+
+// LookupNetworkDefaults returns the network defaults of the group, or empty defaults if the location is not known
+// or the group does not exist. The defaults are cached for DefaultMetadataTTL, so defaults set by another process
+// are seen once the cached copy expires.
+func (c *GroupClient) LookupNetworkDefaults(ctx context.Context, location, name string) (*NetworkDefaults, error) {
+	location = moc.Location(ctx, location)
+	if len(location) == 0 || len(name) == 0 {
+		return &NetworkDefaults{}, nil
+	}
+	fetch := func(ctx context.Context) (*NetworkDefaults, error) {
+		defaults, err := c.GetNetworkDefaults(ctx, location, name)
+		if errors.IsNotFound(err) {
+			return &NetworkDefaults{}, nil
+		}
+		return defaults, err
+	}
+	if c.defaults == nil {
+		return fetch(ctx)
+	}
+	defaults, err := c.defaults.get(location, name, fetch).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	copied := *defaults
+	return &copied, nil
+}
+
+// GetNetworkDefaultsFromTags returns the network defaults stored in the tags of a group
+func GetNetworkDefaultsFromTags(tags map[string]*string) (*NetworkDefaults, error) {
+	defaults := &NetworkDefaults{}
+	if nsg, ok := resourcetags.Get(tags, DefaultNetworkSecurityGroupTag); ok && nsg != nil {
+		defaults.NetworkSecurityGroup = *nsg
+	}
+	if table, ok := resourcetags.Get(tags, DefaultRouteTableTag); ok && table != nil && len(*table) > 0 {
+		defaults.RouteTable = &network.RouteTable{}
+		if err := json.Unmarshal([]byte(*table), defaults.RouteTable); err != nil {
+			return nil, errors.Wrapf(errors.InvalidConfiguration, "Invalid default route table: %v", err)
+		}
+	}
+	return defaults, nil
+}
+
+// ApplyToSubnet sets the network security group and route table of the subnet that it does not specify
+func (d *NetworkDefaults) ApplyToSubnet(subnet *network.Subnet) {
+	if subnet == nil || (len(d.NetworkSecurityGroup) == 0 && d.RouteTable == nil) {
+		return
+	}
+	if subnet.SubnetPropertiesFormat == nil {
+		subnet.SubnetPropertiesFormat = &network.SubnetPropertiesFormat{}
+	}
+	if subnet.NetworkSecurityGroup == nil && len(d.NetworkSecurityGroup) > 0 {
+		nsg := d.NetworkSecurityGroup
+		subnet.NetworkSecurityGroup = &network.SubResource{ID: &nsg}
+	}
+	if subnet.RouteTable == nil && d.RouteTable != nil {
+		table := *d.RouteTable
+		subnet.RouteTable = &table
+	}
+}
+
+// ApplyToInterface sets the network security group of the ip configurations of the network interface that do not
+// specify one
+func (d *NetworkDefaults) ApplyToInterface(nic *network.Interface) {
+	if nic == nil || nic.InterfacePropertiesFormat == nil || nic.IPConfigurations == nil || len(d.NetworkSecurityGroup) == 0 {
+		return
+	}
+	for i := range *nic.IPConfigurations {
+		ipconfig := &(*nic.IPConfigurations)[i]
+		if ipconfig.InterfaceIPConfigurationPropertiesFormat == nil || ipconfig.NetworkSecurityGroup != nil {
+			continue
+		}
+		nsg := d.NetworkSecurityGroup
+		ipconfig.NetworkSecurityGroup = &network.SubResource{ID: &nsg}
+	}
+}
+
+// getNetworkDefaultsTags returns the group tags holding defaults, with nil values for the defaults to remove
+func getNetworkDefaultsTags(defaults *NetworkDefaults) (map[string]*string, error) {
+	tags := map[string]*string{
+		DefaultNetworkSecurityGroupTag: nil,
+		DefaultRouteTableTag:           nil,
+	}
+	if len(defaults.NetworkSecurityGroup) > 0 {
+		nsg := defaults.NetworkSecurityGroup
+		tags[DefaultNetworkSecurityGroupTag] = &nsg
+	}
+	if defaults.RouteTable != nil {
+		table, err := json.Marshal(defaults.RouteTable)
+		if err != nil {
+			return nil, errors.Wrapf(errors.InvalidInput, "Invalid default route table: %v", err)
+		}
+		value := string(table)
+		tags[DefaultRouteTableTag] = &value
+	}
+	return tags, nil
+}
+
+func (c *GroupClient) getGroup(ctx context.Context, location, name string) (*cloud.Group, error) {
+	groups, err := c.Get(ctx, location, name)
+	if err != nil {
+		return nil, err
+	}
+	if groups == nil || len(*groups) == 0 {
+		return nil, errors.Wrapf(errors.NotFound, "Group [%s] not found in location [%s]", name, location)
+	}
+	return &(*groups)[0], nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package group
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/cloud"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NetworkDefaultsTags(t *testing.T) {
+	prefix := "10.0.0.0/8"
+	next := "192.168.0.1"
+	defaults := &NetworkDefaults{
+		NetworkSecurityGroup: "baseline",
+		RouteTable: &network.RouteTable{
+			RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+				Routes: &[]network.Route{{
+					RoutePropertiesFormat: &network.RoutePropertiesFormat{AddressPrefix: &prefix, NextHopIPAddress: &next},
+				}},
+			},
+		},
+	}
+	tags, err := getNetworkDefaultsTags(defaults)
+	assert.NoError(t, err)
+
+	parsed, err := GetNetworkDefaultsFromTags(tags)
+	assert.NoError(t, err)
+	assert.Equal(t, "baseline", parsed.NetworkSecurityGroup)
+	assert.Equal(t, prefix, *(*parsed.RouteTable.Routes)[0].AddressPrefix)
+
+	tags, err = getNetworkDefaultsTags(&NetworkDefaults{})
+	assert.NoError(t, err)
+	assert.Nil(t, tags[DefaultNetworkSecurityGroupTag])
+	assert.Nil(t, tags[DefaultRouteTableTag])
+}
+
+func Test_ApplyNetworkDefaults(t *testing.T) {
+	defaults := &NetworkDefaults{NetworkSecurityGroup: "baseline"}
+	own := "own"
+	subnets := []network.Subnet{
+		{},
+		{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{NetworkSecurityGroup: &network.SubResource{ID: &own}}},
+	}
+	for i := range subnets {
+		defaults.ApplyToSubnet(&subnets[i])
+	}
+	assert.Equal(t, "baseline", *subnets[0].NetworkSecurityGroup.ID)
+	assert.Equal(t, own, *subnets[1].NetworkSecurityGroup.ID)
+	assert.Nil(t, subnets[0].RouteTable)
+
+	nic := &network.Interface{
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+			IPConfigurations: &[]network.InterfaceIPConfiguration{
+				{InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{}},
+			},
+		},
+	}
+	defaults.ApplyToInterface(nic)
+	assert.Equal(t, "baseline", *(*nic.IPConfigurations)[0].NetworkSecurityGroup.ID)
+}
+
+type fakeGroupService struct {
+	Service
+	groups map[string]cloud.Group
+	gets   int
+}
+
+func (f *fakeGroupService) Get(ctx context.Context, location, name string) (*[]cloud.Group, error) {
+	f.gets++
+	grp, ok := f.groups[name]
+	if !ok {
+		return nil, errors.Wrapf(errors.NotFound, "Group [%s] not found", name)
+	}
+	return &[]cloud.Group{grp}, nil
+}
+
+func (f *fakeGroupService) CreateOrUpdate(ctx context.Context, location, name string, grp *cloud.Group) (*cloud.Group, error) {
+	f.groups[name] = *grp
+	return grp, nil
+}
+
+func Test_LookupNetworkDefaults(t *testing.T) {
+	groupName := "group1"
+	fake := &fakeGroupService{groups: map[string]cloud.Group{groupName: {Name: &groupName, Tags: map[string]*string{}}}}
+	c := &GroupClient{internal: fake, defaults: newNetworkDefaultsCaches()}
+	ctx := context.Background()
+
+	// The defaults are read from the group once
+	for i := 0; i < 3; i++ {
+		defaults, err := c.LookupNetworkDefaults(ctx, "location", groupName)
+		assert.NoError(t, err)
+		assert.True(t, defaults.IsEmpty())
+	}
+	assert.Equal(t, 1, fake.gets)
+
+	// Setting the defaults through the client reads them again
+	assert.NoError(t, c.SetNetworkDefaults(ctx, "location", groupName, &NetworkDefaults{NetworkSecurityGroup: "baseline"}))
+	gets := fake.gets
+	defaults, err := c.LookupNetworkDefaults(ctx, "location", groupName)
+	assert.NoError(t, err)
+	assert.Equal(t, "baseline", defaults.NetworkSecurityGroup)
+	assert.Equal(t, gets+1, fake.gets)
+
+	// A group that does not exist has empty defaults
+	defaults, err = c.LookupNetworkDefaults(ctx, "location", "group2")
+	assert.NoError(t, err)
+	assert.True(t, defaults.IsEmpty())
+}
@@ -4,10 +4,11 @@
 package group
 
 import (
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	"github.com/microsoft/moc/pkg/tags"
 	wssdcloud "github.com/microsoft/moc/rpc/cloudagent/cloud"
 )
 
@@ -21,7 +22,7 @@ func getWssdGroup(gp *cloud.Group, location string) (*wssdcloud.Group, error) {
 	group := &wssdcloud.Group{
 		Name:         *gp.Name,
 		LocationName: location,
-		Tags:         tags.MapToProto(gp.Tags),
+		Tags:         conversion.TagsToProto(conversion.Group, gp.Tags),
 	}
 
 	if gp.Version != nil {
@@ -41,8 +42,8 @@ func getGroup(gp *wssdcloud.Group) *cloud.Group {
 		Location: &gp.LocationName,
 		Version:  &gp.Status.Version.Number,
 		GroupProperties: &cloud.GroupProperties{
-			Statuses: status.GetStatuses(gp.GetStatus()),
+			Statuses: provisioning.GetStatuses(gp.GetStatus()),
 		},
-		Tags: tags.ProtoToMap(gp.Tags),
+		Tags: conversion.TagsFromProto(conversion.Group, gp.Tags),
 	}
 }
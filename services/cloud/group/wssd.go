@@ -6,6 +6,7 @@ package group
 import (
 	"context"
 	"fmt"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
 
@@ -87,6 +88,7 @@ func (c *client) getGroupFromResponse(response *wssdcloud.GroupResponse) *[]clou
 		gps = append(gps, *(getGroup(gp)))
 	}
 
+	list.SortResponse(&gps)
 	return &gps
 }
 
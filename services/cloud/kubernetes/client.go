@@ -5,8 +5,15 @@ package kubernetes
 
 import (
 	"context"
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
+	"github.com/microsoft/moc/pkg/errors"
 )
 
 // Service interface
@@ -33,15 +40,80 @@ func NewKubernetesClient(cloudFQDN string, authorizer auth.Authorizer) (*Kuberne
 
 // Get methods invokes the client Get method
 func (c *KubernetesClient) Get(ctx context.Context, group, name string) (*[]cloud.Kubernetes, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *KubernetesClient) List(ctx context.Context, group string, opts ...list.Options) (*[]cloud.Kubernetes, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *KubernetesClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]cloud.Kubernetes] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]cloud.Kubernetes, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *KubernetesClient) NewLister(group string) *list.Lister[cloud.Kubernetes] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]cloud.Kubernetes, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *KubernetesClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]cloud.Kubernetes, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/Kubernetes/{name}, see resourceid.ID
+func (c *KubernetesClient) GetByID(ctx context.Context, id string) (*cloud.Kubernetes, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.Kubernetes, c.Get)
+}
+
+// GetKubeconfig returns the kubeconfig of the cluster as stored by the cloud agent.
+// The agent only keeps the admin kubeconfig, so requesting a non-admin kubeconfig is not supported.
+func (c *KubernetesClient) GetKubeconfig(ctx context.Context, group, clusterName string, admin bool) ([]byte, error) {
+	if len(clusterName) == 0 {
+		return nil, errors.Wrapf(errors.InvalidInput, "Kubernetes cluster name not specified")
+	}
+	if !admin {
+		return nil, errors.Wrapf(errors.NotSupported, "Only the admin kubeconfig is available for cluster [%s]", clusterName)
+	}
+	clusters, err := c.Get(ctx, group, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if clusters == nil || len(*clusters) == 0 {
+		return nil, errors.Wrapf(errors.NotFound, "Kubernetes cluster [%s] not found", clusterName)
+	}
+	cluster := (*clusters)[0]
+	if cluster.KubernetesProperties == nil || len(cluster.KubeConfig) == 0 {
+		return nil, errors.Wrapf(errors.NotFound, "Kubeconfig for Kubernetes cluster [%s] is not available yet", clusterName)
+	}
+	return cluster.KubeConfig, nil
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *KubernetesClient) CreateOrUpdate(ctx context.Context, group, name string, cloud *cloud.Kubernetes) (*cloud.Kubernetes, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, cloud)
 }
 
 // Delete methods invokes delete of the cloud resource
 func (c *KubernetesClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
@@ -4,6 +4,7 @@
 package kubernetes
 
 import (
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -261,7 +262,7 @@ func (c *client) getKubernetes(gp *wssdcloud.Kubernetes) *cloud.Kubernetes {
 		Name:    &gp.Name,
 		Version: &gp.Status.Version.Number,
 		KubernetesProperties: &cloud.KubernetesProperties{
-			Statuses: status.GetStatuses(gp.GetStatus()),
+			Statuses: provisioning.GetStatuses(gp.GetStatus()),
 			Network: &cloud.NetworkConfiguration{
 				CNI:              &gp.Network.Cni,
 				PodCIDR:          &gp.Network.PodCidr,
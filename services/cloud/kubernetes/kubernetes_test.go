@@ -4,9 +4,11 @@
 package kubernetes
 
 import (
+	"context"
 	"testing"
 
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
+	"github.com/microsoft/moc/pkg/errors"
 	wssdcloud "github.com/microsoft/moc/rpc/cloudagent/cloud"
 )
 
@@ -42,3 +44,11 @@ func Test_getKubernetes(t *testing.T) {
 		t.Errorf("Name doesnt match post conversion")
 	}
 }
+
+func Test_GetKubeconfigWithoutName(t *testing.T) {
+	c := &KubernetesClient{}
+	_, err := c.GetKubeconfig(context.Background(), "group", "", true)
+	if !errors.IsInvalidInput(err) {
+		t.Errorf("Expected InvalidInput for an empty cluster name, got %v", err)
+	}
+}
@@ -6,6 +6,7 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
 
@@ -108,5 +109,6 @@ func (c *client) getKubernetessFromResponse(response *wssdcloudk8s.KubernetesRes
 		kubes = append(kubes, *(c.getKubernetes(k8s)))
 	}
 
+	list.SortResponse(&kubes)
 	return &kubes
 }
@@ -5,6 +5,11 @@ package location
 
 import (
 	"context"
+	"sync"
+
+	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -17,28 +22,86 @@ type Service interface {
 
 type LocationClient struct {
 	internal Service
+	cache    *wssdcloudclient.MetadataCache[*[]cloud.Location]
 }
 
+var (
+	cacheMux sync.Mutex
+	// caches holds the locations of each cloud, shared by the clients of the cloud using the same authorizer
+	caches = map[wssdcloudclient.MetadataCacheKey]*wssdcloudclient.MetadataCache[*[]cloud.Location]{}
+)
+
 func NewLocationClient(cloudFQDN string, authorizer auth.Authorizer) (*LocationClient, error) {
 	c, err := newLocationClient(cloudFQDN, authorizer)
 	if err != nil {
 		return nil, err
 	}
 
-	return &LocationClient{internal: c}, nil
+	return &LocationClient{internal: c, cache: getCache(cloudFQDN, authorizer, c)}, nil
+}
+
+func getCache(cloudFQDN string, authorizer auth.Authorizer, c Service) *wssdcloudclient.MetadataCache[*[]cloud.Location] {
+	newCache := func() *wssdcloudclient.MetadataCache[*[]cloud.Location] {
+		return wssdcloudclient.NewMetadataCache(wssdcloudclient.DefaultMetadataTTL, func(ctx context.Context) (*[]cloud.Location, error) {
+			return c.Get(ctx, "")
+		})
+	}
+	key, ok := wssdcloudclient.NewMetadataCacheKey(cloudFQDN, authorizer)
+	if !ok {
+		return newCache()
+	}
+
+	cacheMux.Lock()
+	defer cacheMux.Unlock()
+	cache, ok := caches[key]
+	if !ok {
+		cache = newCache()
+		caches[key] = cache
+	}
+	return cache
+}
+
+// copyLocations returns a copy of the cached locations that the caller may modify
+func copyLocations(locations *[]cloud.Location, err error) (*[]cloud.Location, error) {
+	if locations == nil || err != nil {
+		return locations, err
+	}
+	copied := make([]cloud.Location, len(*locations))
+	copy(copied, *locations)
+	return &copied, nil
 }
 
 // Get methods invokes the client Get method
 func (c *LocationClient) Get(ctx context.Context, name string) (*[]cloud.Location, error) {
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, name)
 }
 
+// List returns all the locations, served from a cache shared by the clients of the cloud using the same authorizer
+// until it expires
+func (c *LocationClient) List(ctx context.Context, opts ...list.Options) (*[]cloud.Location, error) {
+	result, err := copyLocations(c.cache.Get(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// Refresh fetches all the locations from the agent, updating the cache used by List
+func (c *LocationClient) Refresh(ctx context.Context) (*[]cloud.Location, error) {
+	return copyLocations(c.cache.Refresh(ctx))
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *LocationClient) CreateOrUpdate(ctx context.Context, name string, cloud *cloud.Location) (*cloud.Location, error) {
+	defer c.cache.Invalidate()
 	return c.internal.CreateOrUpdate(ctx, name, cloud)
 }
 
 // Delete methods invokes delete of the cloud resource
 func (c *LocationClient) Delete(ctx context.Context, name string) error {
+	defer c.cache.Invalidate()
 	return c.internal.Delete(ctx, name)
 }
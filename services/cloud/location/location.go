@@ -6,6 +6,7 @@ package location
 import (
 	"github.com/microsoft/moc/pkg/errors"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/status"
 	wssdcloud "github.com/microsoft/moc/rpc/cloudagent/cloud"
@@ -38,7 +39,7 @@ func getLocation(lcn *wssdcloud.Location) *cloud.Location {
 		Name:    &lcn.Name,
 		Version: &lcn.Status.Version.Number,
 		LocationProperties: &cloud.LocationProperties{
-			Statuses: status.GetStatuses(lcn.GetStatus()),
+			Statuses: provisioning.GetStatuses(lcn.GetStatus()),
 		},
 	}
 }
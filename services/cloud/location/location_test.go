@@ -4,9 +4,11 @@
 package location
 
 import (
+	"context"
 	"testing"
 
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
+	"github.com/microsoft/moc/pkg/auth"
 	wssdcloud "github.com/microsoft/moc/rpc/cloudagent/cloud"
 )
 
@@ -42,3 +44,46 @@ func Test_getLocation(t *testing.T) {
 		t.Errorf("Name doesnt match post conversion")
 	}
 }
+
+type fakeAuthorizer struct {
+	auth.Authorizer
+}
+
+type fakeLocationService struct {
+	Service
+	fetches int
+}
+
+func (f *fakeLocationService) Get(ctx context.Context, name string) (*[]cloud.Location, error) {
+	f.fetches++
+	return &[]cloud.Location{{Name: &name}}, nil
+}
+
+func Test_getCache(t *testing.T) {
+	first, second := &fakeAuthorizer{}, &fakeAuthorizer{}
+	service := &fakeLocationService{}
+
+	if getCache("cache.test", first, service) != getCache("cache.test", first, service) {
+		t.Errorf("Clients with the same authorizer do not share the cache")
+	}
+	if getCache("cache.test", first, service) == getCache("cache.test", second, service) {
+		t.Errorf("Clients with different authorizers share the cache")
+	}
+	if getCache("cache.test", first, service) == getCache("other.test", first, service) {
+		t.Errorf("Clients of different clouds share the cache")
+	}
+
+	c := &LocationClient{internal: service, cache: getCache("copy.test", first, service)}
+	locations, err := c.List(context.Background())
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	(*locations)[0].Name = nil
+	locations, _ = c.List(context.Background())
+	if (*locations)[0].Name == nil {
+		t.Errorf("List returned the cached locations instead of a copy")
+	}
+	if service.fetches != 1 {
+		t.Errorf("Expected a single fetch, got %d", service.fetches)
+	}
+}
@@ -6,6 +6,7 @@ package location
 import (
 	"context"
 	"fmt"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
 
@@ -87,6 +88,7 @@ func (c *client) getLocationFromResponse(response *wssdcloud.LocationResponse) *
 		lcns = append(lcns, *(getLocation(lcn)))
 	}
 
+	list.SortResponse(&lcns)
 	return &lcns
 }
 
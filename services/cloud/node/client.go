@@ -5,6 +5,12 @@ package node
 
 import (
 	"context"
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -30,15 +36,57 @@ func NewNodeClient(cloudFQDN string, authorizer auth.Authorizer) (*NodeClient, e
 
 // Get methods invokes the client Get method
 func (c *NodeClient) Get(ctx context.Context, location, name string) (*[]cloud.Node, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *NodeClient) List(ctx context.Context, location string, opts ...list.Options) (*[]cloud.Node, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *NodeClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]cloud.Node] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]cloud.Node, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *NodeClient) NewLister(location string) *list.Lister[cloud.Node] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]cloud.Node, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *NodeClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]cloud.Node, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/Node/{name}, see resourceid.ID
+func (c *NodeClient) GetByID(ctx context.Context, id string) (*cloud.Node, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.Node, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *NodeClient) CreateOrUpdate(ctx context.Context, location, name string, cloud *cloud.Node) (*cloud.Node, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.CreateOrUpdate(ctx, location, name, cloud)
 }
 
 // Delete methods invokes delete of the cloud resource
 func (c *NodeClient) Delete(ctx context.Context, location, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Delete(ctx, location, name)
 }
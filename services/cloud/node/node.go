@@ -9,6 +9,7 @@ import (
 	"github.com/microsoft/moc-sdk-for-go/pkg/constant"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/convert"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -73,7 +74,7 @@ func getNode(nd *wssdcloud.Node) *cloud.Node {
 }
 
 func getNodeStatuses(node *wssdcloud.Node) map[string]*string {
-	statuses := status.GetStatuses(node.GetStatus())
+	statuses := provisioning.GetStatuses(node.GetStatus())
 	statuses["RunningState"] = convert.ToStringPtr(node.GetRunningState().String())
 	statuses["Info"] = convert.ToStringPtr(node.GetInfo().String())
 	return statuses
@@ -6,6 +6,7 @@ package node
 import (
 	"context"
 	"fmt"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 
 	"github.com/microsoft/moc/pkg/auth"
@@ -129,6 +130,7 @@ func (c *client) getNodeFromResponse(response *wssdcloud.NodeResponse) *[]cloud.
 		gps = append(gps, *(getNode(gp)))
 	}
 
+	list.SortResponse(&gps)
 	return &gps
 }
 
@@ -6,6 +6,12 @@ package zone
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -30,16 +36,58 @@ func NewZoneClient(cloudFQDN string, authorizer auth.Authorizer) (*ZoneClient, e
 }
 
 // Get methods invokes the client Get method
-func (c *ZoneClient) Get(ctx context.Context, location string, name string) (*[]cloud.Zone, error) {
+func (c *ZoneClient) Get(ctx context.Context, location, name string) (*[]cloud.Zone, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *ZoneClient) List(ctx context.Context, location string, opts ...list.Options) (*[]cloud.Zone, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *ZoneClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]cloud.Zone] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]cloud.Zone, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *ZoneClient) NewLister(location string) *list.Lister[cloud.Zone] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]cloud.Zone, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *ZoneClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]cloud.Zone, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/Zone/{name}, see resourceid.ID
+func (c *ZoneClient) GetByID(ctx context.Context, id string) (*cloud.Zone, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.Zone, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *ZoneClient) CreateOrUpdate(ctx context.Context, location string, name string, cloud *cloud.Zone) (*cloud.Zone, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.CreateOrUpdate(ctx, location, name, cloud)
 }
 
 // Delete methods invokes delete of the cloud resource
 func (c *ZoneClient) Delete(ctx context.Context, location string, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Delete(ctx, location, name)
 }
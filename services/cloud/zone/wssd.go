@@ -13,6 +13,7 @@ import (
 	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	wssdcloudcompute "github.com/microsoft/moc/rpc/cloudagent/cloud"
 )
@@ -99,6 +100,7 @@ func (c *client) getZoneFromResponse(response *wssdcloudcompute.ZoneResponse) (*
 		}
 		avzonesRet = append(avzonesRet, *cavzone)
 	}
+	list.SortResponse(&avzonesRet)
 	return &avzonesRet, nil
 
 }
@@ -4,6 +4,7 @@
 package zone
 
 import (
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/cloud"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -55,7 +56,7 @@ func getWssdZone(s *wssdcloudcompute.Zone) (*cloud.Zone, error) {
 		Location: &s.LocationName,
 		Version:  &s.Status.Version.Number,
 		ZoneProperties: &cloud.ZoneProperties{
-			Statuses: status.GetStatuses(s.Status),
+			Statuses: provisioning.GetStatuses(s.Status),
 			Nodes:    &s.Nodes,
 		},
 	}
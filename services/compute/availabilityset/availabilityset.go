@@ -4,10 +4,11 @@
 package availabilityset
 
 import (
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	prototags "github.com/microsoft/moc/pkg/tags"
 	wssdcloudcompute "github.com/microsoft/moc/rpc/cloudagent/compute"
 	wssdcloudproto "github.com/microsoft/moc/rpc/common"
 )
@@ -38,7 +39,7 @@ func getRpcAvailabilitySet(s *compute.AvailabilitySet, group string) (*wssdcloud
 }
 
 func getRpcWssdTags(tags map[string]*string) *wssdcloudproto.Tags {
-	return prototags.MapToProto(tags)
+	return conversion.TagsToProto(conversion.AvailabilitySet, tags)
 }
 
 func getRpcVirtualMachineReferences(resources []*compute.VirtualMachineReference) []*wssdcloudcompute.VirtualMachineReference {
@@ -75,13 +76,13 @@ func getWssdAvailabilitySet(s *wssdcloudcompute.AvailabilitySet) (*compute.Avail
 		Tags:                     getWssdTags(s.Tags),
 		Version:                  &s.Status.Version.Number,
 		VirtualMachines:          getWssdVirtualMachineReferences(s.VirtualMachines),
-		Statuses:                 status.GetStatuses(s.Status),
+		Statuses:                 provisioning.GetStatuses(s.Status),
 	}
 	return availabilitySet, nil
 }
 
 func getWssdTags(tags *wssdcloudproto.Tags) map[string]*string {
-	return prototags.ProtoToMap(tags)
+	return conversion.TagsFromProto(conversion.AvailabilitySet, tags)
 }
 
 func getWssdVirtualMachineReferences(cs []*wssdcloudcompute.VirtualMachineReference) []*compute.VirtualMachineReference {
@@ -6,6 +6,12 @@ package availabilityset
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -33,21 +39,67 @@ func NewAvailabilitySetClient(cloudFQDN string, authorizer auth.Authorizer) (*Av
 
 // Get methods invokes the client Get method
 func (c *AvailabilitySetClient) Get(ctx context.Context, group, name string) (*[]compute.AvailabilitySet, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *AvailabilitySetClient) List(ctx context.Context, group string, opts ...list.Options) (*[]compute.AvailabilitySet, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *AvailabilitySetClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]compute.AvailabilitySet] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]compute.AvailabilitySet, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *AvailabilitySetClient) NewLister(group string) *list.Lister[compute.AvailabilitySet] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]compute.AvailabilitySet, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *AvailabilitySetClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]compute.AvailabilitySet, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/AvailabilitySet/{name}, see resourceid.ID
+func (c *AvailabilitySetClient) GetByID(ctx context.Context, id string) (*compute.AvailabilitySet, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.AvailabilitySet, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *AvailabilitySetClient) Create(ctx context.Context, group, name string, compute *compute.AvailabilitySet) (*compute.AvailabilitySet, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Create(ctx, group, name, compute)
 }
 
 // Delete methods invokes delete of the compute resource
 func (c *AvailabilitySetClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
 
 // Prechecks whether the system is able to create specified availability sets.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *AvailabilitySetClient) Precheck(ctx context.Context, group string, avsets []*compute.AvailabilitySet) (bool, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckDuplicateNames(avsets); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, group, avsets)
 }
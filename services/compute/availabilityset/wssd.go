@@ -13,6 +13,7 @@ import (
 	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	wssdcloudcompute "github.com/microsoft/moc/rpc/cloudagent/compute"
 )
@@ -121,6 +122,7 @@ func (c *client) getAvailabilitySetFromResponse(response *wssdcloudcompute.Avail
 		avsetsRet = append(avsetsRet, *cavset)
 	}
 
+	list.SortResponse(&avsetsRet)
 	return &avsetsRet, nil
 
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/status"
 	wssdcloudcompute "github.com/microsoft/moc/rpc/cloudagent/compute"
 )
@@ -184,7 +185,7 @@ func (c *client) getBareMetalHost(bmh *wssdcloudcompute.BareMetalHost, location
 }
 
 func (c *client) getBareMetalHostStatuses(bmh *wssdcloudcompute.BareMetalHost) map[string]*string {
-	statuses := status.GetStatuses(bmh.GetStatus())
+	statuses := provisioning.GetStatuses(bmh.GetStatus())
 	statuses["PowerState"] = convert.ToStringPtr(bmh.GetPowerState().String())
 	return statuses
 }
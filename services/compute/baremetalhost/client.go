@@ -6,6 +6,12 @@ package baremetalhost
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -33,20 +39,63 @@ func NewBareMetalHostClient(cloudFQDN string, authorizer auth.Authorizer) (*Bare
 
 // Get methods invokes the client Get method
 func (c *BareMetalHostClient) Get(ctx context.Context, location, name string) (*[]compute.BareMetalHost, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *BareMetalHostClient) List(ctx context.Context, location string, opts ...list.Options) (*[]compute.BareMetalHost, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *BareMetalHostClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]compute.BareMetalHost] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]compute.BareMetalHost, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *BareMetalHostClient) NewLister(location string) *list.Lister[compute.BareMetalHost] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]compute.BareMetalHost, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *BareMetalHostClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]compute.BareMetalHost, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/BareMetalHost/{name}, see resourceid.ID
+func (c *BareMetalHostClient) GetByID(ctx context.Context, id string) (*compute.BareMetalHost, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.BareMetalHost, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *BareMetalHostClient) CreateOrUpdate(ctx context.Context, location, name string, compute *compute.BareMetalHost) (*compute.BareMetalHost, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.CreateOrUpdate(ctx, location, name, compute)
 }
 
 // Delete methods invokes delete of the compute resource
 func (c *BareMetalHostClient) Delete(ctx context.Context, location string, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Delete(ctx, location, name)
 }
 
 // Query method invokes the client Get method and uses the provided query to filter the returned results
 func (c *BareMetalHostClient) Query(ctx context.Context, location, query string) (*[]compute.BareMetalHost, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.Query(ctx, location, query)
 }
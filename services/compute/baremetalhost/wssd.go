@@ -7,11 +7,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/config"
 	"github.com/microsoft/moc/pkg/marshal"
-	prototags "github.com/microsoft/moc/pkg/tags"
 	wssdcloudproto "github.com/microsoft/moc/rpc/common"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
@@ -108,6 +109,7 @@ func (c *client) getBareMetalHostFromResponse(response *wssdcloudcompute.BareMet
 		bmhs = append(bmhs, *(c.getBareMetalHost(bmh, location)))
 	}
 
+	list.SortResponse(&bmhs)
 	return &bmhs
 }
 
@@ -132,9 +134,9 @@ func (c *client) getBareMetalHostRequest(opType wssdcloudproto.Operation, locati
 }
 
 func getComputeTags(tags *wssdcloudproto.Tags) map[string]*string {
-	return prototags.ProtoToMap(tags)
+	return conversion.TagsFromProto(conversion.BareMetalHost, tags)
 }
 
 func getWssdTags(tags map[string]*string) *wssdcloudproto.Tags {
-	return prototags.MapToProto(tags)
+	return conversion.TagsToProto(conversion.BareMetalHost, tags)
 }
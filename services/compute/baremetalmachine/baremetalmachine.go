@@ -8,6 +8,7 @@ import (
 
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/status"
 	wssdcloudcompute "github.com/microsoft/moc/rpc/cloudagent/compute"
 )
@@ -184,7 +185,7 @@ func (c *client) getBareMetalMachine(bmm *wssdcloudcompute.BareMetalMachine, gro
 		Tags: getComputeTags(bmm.GetTags()),
 		BareMetalMachineProperties: &compute.BareMetalMachineProperties{
 			ProvisioningState: status.GetProvisioningState(bmm.GetStatus().GetProvisioningStatus()),
-			Statuses:          status.GetStatuses(bmm.GetStatus()),
+			Statuses:          provisioning.GetStatuses(bmm.GetStatus()),
 			StorageProfile:    c.getBareMetalMachineStorageProfile(bmm.Storage),
 			SecurityProfile:   c.getBareMetalMachineSecurityProfile(bmm),
 			OsProfile:         c.getBareMetalMachineOSProfile(bmm.Os),
@@ -7,6 +7,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -34,21 +40,64 @@ func NewBareMetalMachineClient(cloudFQDN string, authorizer auth.Authorizer) (*B
 
 // Get methods invokes the client Get method
 func (c *BareMetalMachineClient) Get(ctx context.Context, group, name string) (*[]compute.BareMetalMachine, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *BareMetalMachineClient) List(ctx context.Context, group string, opts ...list.Options) (*[]compute.BareMetalMachine, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *BareMetalMachineClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]compute.BareMetalMachine] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]compute.BareMetalMachine, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *BareMetalMachineClient) NewLister(group string) *list.Lister[compute.BareMetalMachine] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]compute.BareMetalMachine, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *BareMetalMachineClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]compute.BareMetalMachine, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/BareMetalMachine/{name}, see resourceid.ID
+func (c *BareMetalMachineClient) GetByID(ctx context.Context, id string) (*compute.BareMetalMachine, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.BareMetalMachine, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *BareMetalMachineClient) CreateOrUpdate(ctx context.Context, group, name string, compute *compute.BareMetalMachine) (*compute.BareMetalMachine, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, compute)
 }
 
 // Delete methods invokes delete of the compute resource
 func (c *BareMetalMachineClient) Delete(ctx context.Context, group string, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
 
 // Query method invokes the client Get method and uses the provided query to filter the returned results
 func (c *BareMetalMachineClient) Query(ctx context.Context, group, query string) (*[]compute.BareMetalMachine, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Query(ctx, group, query)
 }
 
@@ -7,8 +7,15 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/auth"
+	wssdcloudcompute "github.com/microsoft/moc/rpc/cloudagent/compute"
 	"github.com/microsoft/moc/rpc/common"
 )
 
@@ -23,7 +30,9 @@ type Service interface {
 // Client structure
 type GalleryImageClient struct {
 	compute.BaseClient
-	internal Service
+	internal   Service
+	cloudFQDN  string
+	authorizer auth.Authorizer
 }
 
 // NewClient method returns new client
@@ -33,43 +42,99 @@ func NewGalleryImageClient(cloudFQDN string, authorizer auth.Authorizer) (*Galle
 		return nil, err
 	}
 
-	return &GalleryImageClient{internal: c}, nil
+	return &GalleryImageClient{internal: c, cloudFQDN: cloudFQDN, authorizer: authorizer}, nil
 }
 
 // Get methods invokes the client Get method
 func (c *GalleryImageClient) Get(ctx context.Context, location, name string) (*[]compute.GalleryImage, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *GalleryImageClient) List(ctx context.Context, location string, opts ...list.Options) (*[]compute.GalleryImage, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *GalleryImageClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]compute.GalleryImage] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]compute.GalleryImage, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *GalleryImageClient) NewLister(location string) *list.Lister[compute.GalleryImage] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]compute.GalleryImage, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *GalleryImageClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]compute.GalleryImage, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/GalleryImage/{name}, see resourceid.ID
+func (c *GalleryImageClient) GetByID(ctx context.Context, id string) (*compute.GalleryImage, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.GalleryImage, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *GalleryImageClient) CreateOrUpdate(ctx context.Context, location, imagePath, name string, compute *compute.GalleryImage) (*compute.GalleryImage, error) {
+	location = moc.Location(ctx, location)
 	if compute != nil && compute.GalleryImageProperties != nil {
 		compute.SourceType = common.ImageSource_LOCAL_SOURCE
 	}
+	compute, err := c.withStoredCreatedAt(ctx, location, name, compute)
+	if err != nil {
+		return nil, err
+	}
 	return c.internal.CreateOrUpdate(ctx, location, imagePath, name, compute)
 }
 
 // Delete methods invokes delete of the compute resource
 func (c *GalleryImageClient) Delete(ctx context.Context, location, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Delete(ctx, location, name)
 }
 
 // Prechecks whether the system is able to create specified resources.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *GalleryImageClient) Precheck(ctx context.Context, location, imagePath string, galleryImages []*compute.GalleryImage) (bool, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckDuplicateNames(galleryImages); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, location, imagePath, galleryImages)
 }
 
 // UploadImageFromLocal   methods invokes  UploadImageFromLocal  on the client
 func (c *GalleryImageClient) UploadImageFromLocal(ctx context.Context, location, imagePath, name string, compute *compute.GalleryImage) (*compute.GalleryImage, error) {
+	location = moc.Location(ctx, location)
 	if compute != nil && compute.GalleryImageProperties != nil {
 		compute.SourceType = common.ImageSource_LOCAL_SOURCE
 	}
+	compute, err := c.withStoredCreatedAt(ctx, location, name, compute)
+	if err != nil {
+		return nil, err
+	}
 	return c.internal.CreateOrUpdate(ctx, location, imagePath, name, compute)
 }
 
 // UploadImageFromSFS   methods invokes  UploadImageFromSFS  on the client
 func (c *GalleryImageClient) UploadImageFromSFS(ctx context.Context, location, name string, galImage *compute.GalleryImage, sfsImg *compute.SFSImageProperties) (*compute.GalleryImage, error) {
+	location = moc.Location(ctx, location)
 	// convert sfsImg struct to json string and use it as image-path
 	data, err := json.Marshal(sfsImg)
 	if err != nil {
@@ -79,11 +144,16 @@ func (c *GalleryImageClient) UploadImageFromSFS(ctx context.Context, location, n
 	if galImage != nil && galImage.GalleryImageProperties != nil {
 		galImage.SourceType = common.ImageSource_SFS_SOURCE
 	}
+	galImage, err = c.withStoredCreatedAt(ctx, location, name, galImage)
+	if err != nil {
+		return nil, err
+	}
 
 	return c.internal.CreateOrUpdate(ctx, location, string(data), name, galImage)
 }
 
 func (c *GalleryImageClient) UploadImageFromHttp(ctx context.Context, location, name string, galImage *compute.GalleryImage, azHttpImg *compute.AzureGalleryImageProperties) (*compute.GalleryImage, error) {
+	location = moc.Location(ctx, location)
 	// convert httpImg struct to json string and use it as image-path
 	data, err := json.Marshal(azHttpImg)
 	if err != nil {
@@ -92,5 +162,28 @@ func (c *GalleryImageClient) UploadImageFromHttp(ctx context.Context, location,
 	if galImage != nil && galImage.GalleryImageProperties != nil {
 		galImage.SourceType = common.ImageSource_HTTP_SOURCE
 	}
+	galImage, err = c.withStoredCreatedAt(ctx, location, name, galImage)
+	if err != nil {
+		return nil, err
+	}
 	return c.internal.CreateOrUpdate(ctx, location, string(data), name, galImage)
 }
+
+// Raw returns the underlying agent client, giving access to agent fields not yet
+// exposed by the sdk. Returns nil if the client is not backed by the agent.
+func (c *GalleryImageClient) Raw() wssdcloudcompute.GalleryImageAgentClient {
+	if rc, ok := c.internal.(*client); ok {
+		return rc.GalleryImageAgentClient
+	}
+	return nil
+}
+
+// ToProto converts a gallery image to the message sent to the agent
+func ToProto(image *compute.GalleryImage, location, imagePath string) (*wssdcloudcompute.GalleryImage, error) {
+	return getWssdGalleryImage(image, location, imagePath)
+}
+
+// FromProto converts a gallery image message returned by the agent
+func FromProto(image *wssdcloudcompute.GalleryImage, location string) *compute.GalleryImage {
+	return getGalleryImage(image, location)
+}
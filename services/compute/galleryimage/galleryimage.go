@@ -3,10 +3,11 @@
 package galleryimage
 
 import (
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	"github.com/microsoft/moc/pkg/tags"
 	wssdcloudcompute "github.com/microsoft/moc/rpc/cloudagent/compute"
 )
 
@@ -23,7 +24,7 @@ func getWssdGalleryImage(c *compute.GalleryImage, locationName, imagePath string
 		Name:         *c.Name,
 		LocationName: locationName,
 		SourcePath:   imagePath,
-		Tags:         tags.MapToProto(c.Tags),
+		Tags:         conversion.TagsToProto(conversion.GalleryImage, withCreatedAtTag(c.Tags)),
 	}
 
 	if c.GalleryImageProperties != nil && c.GalleryImageProperties.ContainerName != nil {
@@ -53,10 +54,10 @@ func getGalleryImage(c *wssdcloudcompute.GalleryImage, location string) *compute
 		ID:      &c.Id,
 		Version: &c.Status.Version.Number,
 		GalleryImageProperties: &compute.GalleryImageProperties{
-			Statuses:         status.GetStatuses(c.GetStatus()),
+			Statuses:         provisioning.GetStatuses(c.GetStatus()),
 			ContainerName:    &c.ContainerName,
 			HyperVGeneration: c.HyperVGeneration,
 		},
-		Tags: tags.ProtoToMap(c.Tags),
+		Tags: conversion.TagsFromProto(conversion.GalleryImage, c.Tags),
 	}
 }
@@ -0,0 +1,209 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package galleryimage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourcetags"
+	"github.com/microsoft/moc-sdk-for-go/services/cloud/group"
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc-sdk-for-go/services/compute/virtualmachine"
+	"github.com/microsoft/moc-sdk-for-go/services/compute/virtualmachinescaleset"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// CreatedAtTag is the reserved tag recording when the sdk first sent a gallery image to the agent
+const CreatedAtTag = resourcetags.ReservedPrefix + "createdAt"
+
+// withCreatedAtTag returns tags holding the creation time tag, set to now if tags do not have one. Images sent again
+// carry the tag already stored by the agent, see withStoredCreatedAt.
+func withCreatedAtTag(tags map[string]*string) map[string]*string {
+	if _, ok := resourcetags.Get(tags, CreatedAtTag); ok {
+		return tags
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	return resourcetags.Merge(tags, map[string]*string{CreatedAtTag: &now})
+}
+
+// withStoredCreatedAt returns the image with the creation time tag stored by the agent for the image, if any, so that
+// updating an image does not reset its creation time. The image of the caller is not modified.
+func (c *GalleryImageClient) withStoredCreatedAt(ctx context.Context, location, name string, image *compute.GalleryImage) (*compute.GalleryImage, error) {
+	if image == nil {
+		return image, nil
+	}
+	stored, err := c.internal.Get(ctx, location, name)
+	if errors.IsNotFound(err) {
+		return image, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil || len(*stored) == 0 {
+		return image, nil
+	}
+	createdAt, ok := resourcetags.Get((*stored)[0].Tags, CreatedAtTag)
+	if !ok || createdAt == nil {
+		return image, nil
+	}
+	withTag := *image
+	withTag.Tags = resourcetags.Merge(image.Tags, map[string]*string{CreatedAtTag: createdAt})
+	return &withTag, nil
+}
+
+// getCreatedAt returns the creation time recorded in the tags of the image, if any
+func getCreatedAt(image *compute.GalleryImage) *time.Time {
+	value, ok := resourcetags.Get(image.Tags, CreatedAtTag)
+	if !ok || value == nil {
+		return nil
+	}
+	createdAt, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil
+	}
+	return &createdAt
+}
+
+// CollectedImage is a gallery image found unreferenced by GC
+type CollectedImage struct {
+	Name      string
+	Container string
+	// CreatedAt - When the image was created, nil for images created before the sdk recorded it
+	CreatedAt *time.Time
+	// Err - Error deleting the image, nil if it was deleted or in a dry run
+	Err error
+}
+
+// GCReport is the outcome of GC
+type GCReport struct {
+	DryRun bool
+	// Collected - Unreferenced images older than the threshold, deleted unless in a dry run, sorted by name
+	Collected []CollectedImage
+	// Referenced - Names of the images used by virtual machines or scale sets, sorted
+	Referenced []string
+}
+
+// GC finds the gallery images of the location that are not referenced by any virtual machine or scale set
+// of the groups of the location and were created more than olderThan ago, and deletes them unless dryRun is set.
+// Images created before the sdk recorded creation times have no known age and are never deleted. olderThan must be
+// positive unless in a dry run, where zero reports every unreferenced image. The agent does not report image sizes,
+// so the space reclaimed is not reported.
+func (c *GalleryImageClient) GC(ctx context.Context, location string, olderThan time.Duration, dryRun bool) (*GCReport, error) {
+	if olderThan <= 0 && !dryRun {
+		return nil, errors.Wrapf(errors.InvalidInput, "Gallery image garbage collection requires a positive age, or a dry run")
+	}
+	referenced, err := c.getReferencedImages(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	images, err := c.List(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &GCReport{DryRun: dryRun, Collected: []CollectedImage{}, Referenced: []string{}}
+	for name := range referenced {
+		report.Referenced = append(report.Referenced, name)
+	}
+	sort.Strings(report.Referenced)
+	if images == nil {
+		return report, nil
+	}
+
+	for _, collected := range getCollectableImages(*images, referenced, olderThan, time.Now()) {
+		if !dryRun {
+			collected.Err = c.Delete(ctx, location, collected.Name)
+		}
+		report.Collected = append(report.Collected, collected)
+	}
+
+	sort.SliceStable(report.Collected, func(i, j int) bool {
+		return report.Collected[i].Name < report.Collected[j].Name
+	})
+	return report, nil
+}
+
+// getCollectableImages returns the images that are not referenced and were created more than olderThan before now.
+// Images without a creation time are only returned when olderThan is zero.
+func getCollectableImages(images []compute.GalleryImage, referenced map[string]bool, olderThan time.Duration, now time.Time) []CollectedImage {
+	collectable := []CollectedImage{}
+	cutoff := now.Add(-olderThan)
+	for i := range images {
+		image := &images[i]
+		if image.Name == nil || referenced[*image.Name] {
+			continue
+		}
+		createdAt := getCreatedAt(image)
+		if olderThan > 0 && (createdAt == nil || createdAt.After(cutoff)) {
+			continue
+		}
+		collected := CollectedImage{Name: *image.Name, CreatedAt: createdAt}
+		if image.GalleryImageProperties != nil && image.ContainerName != nil {
+			collected.Container = *image.ContainerName
+		}
+		collectable = append(collectable, collected)
+	}
+	return collectable
+}
+
+// getReferencedImages returns the names of the images used by the virtual machines and scale sets of the location
+func (c *GalleryImageClient) getReferencedImages(ctx context.Context, location string) (map[string]bool, error) {
+	groupClient, err := group.NewGroupClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+	vmClient, err := virtualmachine.NewVirtualMachineClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+	vmssClient, err := virtualmachinescaleset.NewVirtualMachineScaleSetClient(c.cloudFQDN, c.authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := groupClient.List(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	referenced := map[string]bool{}
+	if groups == nil {
+		return referenced, nil
+	}
+	for _, g := range *groups {
+		if g.Name == nil {
+			continue
+		}
+		vms, err := vmClient.List(ctx, *g.Name)
+		if err != nil {
+			return nil, err
+		}
+		if vms != nil {
+			for _, vm := range *vms {
+				if vm.VirtualMachineProperties != nil && vm.StorageProfile != nil {
+					addImageReference(referenced, vm.StorageProfile.ImageReference)
+				}
+			}
+		}
+
+		scaleSets := vmssClient.NewLister(*g.Name)
+		for scaleSets.Next(ctx) {
+			vmss := scaleSets.Item()
+			if vmss.VirtualMachineScaleSetProperties != nil && vmss.VirtualMachineProfile != nil && vmss.VirtualMachineProfile.StorageProfile != nil {
+				addImageReference(referenced, vmss.VirtualMachineProfile.StorageProfile.ImageReference)
+			}
+		}
+		if err := scaleSets.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return referenced, nil
+}
+
+func addImageReference(referenced map[string]bool, ref *compute.ImageReference) {
+	if ref != nil && ref.Name != nil && len(*ref.Name) > 0 {
+		referenced[*ref.Name] = true
+	}
+}
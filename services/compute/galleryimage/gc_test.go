@@ -0,0 +1,91 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package galleryimage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeGalleryImageService struct {
+	Service
+	images []compute.GalleryImage
+}
+
+func (f *fakeGalleryImageService) Get(ctx context.Context, location, name string) (*[]compute.GalleryImage, error) {
+	for _, image := range f.images {
+		if *image.Name == name {
+			return &[]compute.GalleryImage{image}, nil
+		}
+	}
+	return nil, errors.Wrapf(errors.NotFound, "Gallery image [%s] not found", name)
+}
+
+func getTestGalleryImage(name, createdAt string) compute.GalleryImage {
+	image := compute.GalleryImage{Name: &name, Tags: map[string]*string{}}
+	if len(createdAt) > 0 {
+		image.Tags[CreatedAtTag] = &createdAt
+	}
+	return image
+}
+
+func Test_withCreatedAtTag(t *testing.T) {
+	stored := "2020-01-02T03:04:05Z"
+	tags := withCreatedAtTag(map[string]*string{CreatedAtTag: &stored})
+	assert.Equal(t, stored, *tags[CreatedAtTag])
+
+	tags = withCreatedAtTag(nil)
+	createdAt, err := time.Parse(time.RFC3339, *tags[CreatedAtTag])
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), createdAt, time.Minute)
+}
+
+func Test_withStoredCreatedAt(t *testing.T) {
+	stored := "2020-01-02T03:04:05Z"
+	c := &GalleryImageClient{internal: &fakeGalleryImageService{images: []compute.GalleryImage{getTestGalleryImage("image1", stored)}}}
+
+	// Updating an image keeps the creation time stored by the agent, without changing the image of the caller
+	update := getTestGalleryImage("image1", "")
+	image, err := c.withStoredCreatedAt(context.Background(), "location", "image1", &update)
+	assert.NoError(t, err)
+	assert.Equal(t, stored, *image.Tags[CreatedAtTag])
+	assert.Equal(t, stored, *withCreatedAtTag(image.Tags)[CreatedAtTag])
+	assert.Equal(t, 0, len(update.Tags))
+
+	// A new image is sent unchanged
+	create := getTestGalleryImage("image2", "")
+	image, err = c.withStoredCreatedAt(context.Background(), "location", "image2", &create)
+	assert.NoError(t, err)
+	assert.Equal(t, &create, image)
+}
+
+func Test_getCollectableImages(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	images := []compute.GalleryImage{
+		getTestGalleryImage("old", "2024-01-01T00:00:00Z"),
+		getTestGalleryImage("recent", "2024-05-31T00:00:00Z"),
+		getTestGalleryImage("unknown", ""),
+		getTestGalleryImage("used", "2024-01-01T00:00:00Z"),
+	}
+	referenced := map[string]bool{"used": true}
+
+	collectable := getCollectableImages(images, referenced, 7*24*time.Hour, now)
+	assert.Equal(t, 1, len(collectable))
+	assert.Equal(t, "old", collectable[0].Name)
+
+	// Without an age every unreferenced image is reported, including those without a creation time
+	collectable = getCollectableImages(images, referenced, 0, now)
+	assert.Equal(t, 3, len(collectable))
+}
+
+func Test_GCRequiresAge(t *testing.T) {
+	c := &GalleryImageClient{}
+	_, err := c.GC(context.Background(), "location", 0, false)
+	assert.True(t, errors.IsInvalidInput(err))
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -130,6 +131,7 @@ func getGalleryImagesFromResponse(response *wssdcloudcompute.GalleryImageRespons
 		virtualHardDisks = append(virtualHardDisks, *(getGalleryImage(galleryimage, location)))
 	}
 
+	list.SortResponse(&virtualHardDisks)
 	return &virtualHardDisks
 }
 
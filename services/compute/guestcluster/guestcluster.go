@@ -0,0 +1,246 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package guestcluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc-sdk-for-go/services/compute/availabilityset"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/network/loadbalancer"
+	"github.com/microsoft/moc-sdk-for-go/services/storage"
+	"github.com/microsoft/moc-sdk-for-go/services/storage/virtualharddisk"
+	"github.com/microsoft/moc/pkg/auth"
+	"github.com/microsoft/moc/pkg/errors"
+	log "k8s.io/klog"
+)
+
+const defaultFaultDomainCount int32 = 2
+
+// DiskSpec describes a disk shared by the nodes of the guest cluster, such as the witness disk
+type DiskSpec struct {
+	// Name - Name of the disk
+	Name string
+	// SizeBytes - Size of the disk
+	SizeBytes int64
+}
+
+// Spec describes the resources of a guest failover cluster
+type Spec struct {
+	// Name - Name of the cluster, used to name the availability set and load balancer
+	Name string
+	// Location - Location of the resources
+	Location string
+	// FaultDomainCount - Number of fault domains the nodes are spread across. Defaults to 2.
+	FaultDomainCount int32
+	// Container - Storage container holding the shared disks
+	Container string
+	// SharedDisks - Disks shared by the nodes of the cluster
+	SharedDisks []DiskSpec
+	// SubnetID - ID of the virtual network subnet the cluster ip is allocated from
+	SubnetID string
+	// ClusterIP - Static private ip of the cluster. The agent allocates one when empty.
+	ClusterIP string
+	// Ports - Ports forwarded from the cluster ip to the nodes
+	Ports []int32
+	// Tags - Tags applied to all the resources
+	Tags map[string]*string
+}
+
+// Resources are the resources provisioned for a guest failover cluster
+type Resources struct {
+	AvailabilitySet *compute.AvailabilitySet
+	SharedDisks     []*storage.VirtualHardDisk
+	LoadBalancer    *network.LoadBalancer
+}
+
+type availabilitySetService interface {
+	Create(context.Context, string, string, *compute.AvailabilitySet) (*compute.AvailabilitySet, error)
+	Delete(context.Context, string, string) error
+}
+
+type diskService interface {
+	CreateOrUpdate(context.Context, string, string, string, *storage.VirtualHardDisk) (*storage.VirtualHardDisk, error)
+	Delete(context.Context, string, string, string) error
+}
+
+type loadBalancerService interface {
+	CreateOrUpdate(context.Context, string, string, *network.LoadBalancer) (*network.LoadBalancer, error)
+	Delete(context.Context, string, string) error
+}
+
+// Client provisions the resources of guest failover clusters
+type Client struct {
+	availabilitySets availabilitySetService
+	disks            diskService
+	loadBalancers    loadBalancerService
+}
+
+// NewGuestClusterClient returns a client provisioning guest failover clusters
+func NewGuestClusterClient(cloudFQDN string, authorizer auth.Authorizer) (*Client, error) {
+	avsets, err := availabilityset.NewAvailabilitySetClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	disks, err := virtualharddisk.NewVirtualHardDiskClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	lbs, err := loadbalancer.NewLoadBalancerClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{availabilitySets: avsets, disks: disks, loadBalancers: lbs}, nil
+}
+
+// Provision creates the availability set spreading the cluster nodes across fault domains, the shared
+// disks and the internal load balancer holding the cluster ip. If any resource fails to be created,
+// the resources created so far are deleted and the creation error is returned.
+// The nodes are created by the caller in the returned availability set and attached to the shared disks.
+func (c *Client) Provision(ctx context.Context, group string, spec *Spec) (*Resources, error) {
+	if err := validate(spec); err != nil {
+		return nil, err
+	}
+
+	resources := &Resources{}
+	rollback := []func() error{}
+	fail := func(err error) (*Resources, error) {
+		for i := len(rollback) - 1; i >= 0; i-- {
+			if rerr := rollback[i](); rerr != nil {
+				log.Errorf("[GuestCluster] Failed to roll back resource of cluster %s: %v", spec.Name, rerr)
+			}
+		}
+		return nil, err
+	}
+
+	faultDomainCount := spec.FaultDomainCount
+	if faultDomainCount == 0 {
+		faultDomainCount = defaultFaultDomainCount
+	}
+	avsetName := getAvailabilitySetName(spec.Name)
+	avset, err := c.availabilitySets.Create(ctx, group, avsetName, &compute.AvailabilitySet{
+		Name:                     &avsetName,
+		Location:                 &spec.Location,
+		PlatformFaultDomainCount: &faultDomainCount,
+		Tags:                     spec.Tags,
+	})
+	if err != nil {
+		return fail(errors.Wrapf(err, "Failed to create availability set for guest cluster %s", spec.Name))
+	}
+	resources.AvailabilitySet = avset
+	rollback = append(rollback, func() error { return c.availabilitySets.Delete(ctx, group, avsetName) })
+
+	for _, diskSpec := range spec.SharedDisks {
+		name, size := diskSpec.Name, diskSpec.SizeBytes
+		disk, err := c.disks.CreateOrUpdate(ctx, group, spec.Container, name, &storage.VirtualHardDisk{
+			Name: &name,
+			Tags: spec.Tags,
+			VirtualHardDiskProperties: &storage.VirtualHardDiskProperties{
+				DiskSizeBytes: &size,
+			},
+		})
+		if err != nil {
+			return fail(errors.Wrapf(err, "Failed to create shared disk %s for guest cluster %s", name, spec.Name))
+		}
+		resources.SharedDisks = append(resources.SharedDisks, disk)
+		rollback = append(rollback, func() error { return c.disks.Delete(ctx, group, spec.Container, name) })
+	}
+
+	lbName := getLoadBalancerName(spec.Name)
+	lb, err := c.loadBalancers.CreateOrUpdate(ctx, group, lbName, getLoadBalancer(lbName, spec))
+	if err != nil {
+		return fail(errors.Wrapf(err, "Failed to create load balancer for guest cluster %s", spec.Name))
+	}
+	resources.LoadBalancer = lb
+
+	return resources, nil
+}
+
+// Deprovision deletes the resources created by Provision. It attempts every deletion and returns the first error.
+func (c *Client) Deprovision(ctx context.Context, group string, spec *Spec) error {
+	if spec == nil || len(spec.Name) == 0 {
+		return errors.Wrapf(errors.InvalidInput, "Guest cluster name not specified")
+	}
+	var firstErr error
+	record := func(err error) {
+		if err != nil && !errors.IsNotFound(err) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	record(c.loadBalancers.Delete(ctx, group, getLoadBalancerName(spec.Name)))
+	for _, disk := range spec.SharedDisks {
+		record(c.disks.Delete(ctx, group, spec.Container, disk.Name))
+	}
+	record(c.availabilitySets.Delete(ctx, group, getAvailabilitySetName(spec.Name)))
+	return firstErr
+}
+
+func validate(spec *Spec) error {
+	if spec == nil || len(spec.Name) == 0 {
+		return errors.Wrapf(errors.InvalidInput, "Guest cluster name not specified")
+	}
+	if len(spec.SubnetID) == 0 {
+		return errors.Wrapf(errors.InvalidInput, "Subnet for the cluster ip of guest cluster %s not specified", spec.Name)
+	}
+	if len(spec.SharedDisks) > 0 && len(spec.Container) == 0 {
+		return errors.Wrapf(errors.InvalidInput, "Container for the shared disks of guest cluster %s not specified", spec.Name)
+	}
+	for _, disk := range spec.SharedDisks {
+		if len(disk.Name) == 0 || disk.SizeBytes <= 0 {
+			return errors.Wrapf(errors.InvalidInput, "Shared disk of guest cluster %s requires a name and a size", spec.Name)
+		}
+	}
+	return nil
+}
+
+func getLoadBalancer(name string, spec *Spec) *network.LoadBalancer {
+	subnetID := spec.SubnetID
+	frontendName := name + "-frontend"
+	frontend := network.FrontendIPConfiguration{
+		Name: &frontendName,
+		FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+			Subnet:                    &network.Subnet{ID: &subnetID},
+			PrivateIPAllocationMethod: network.Dynamic,
+		},
+	}
+	if len(spec.ClusterIP) > 0 {
+		clusterIP := spec.ClusterIP
+		frontend.PrivateIPAddress = &clusterIP
+		frontend.PrivateIPAllocationMethod = network.Static
+	}
+
+	backendName := name + "-backend"
+	rules := []network.LoadBalancingRule{}
+	for _, port := range spec.Ports {
+		port := port
+		rules = append(rules, network.LoadBalancingRule{
+			LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+				FrontendPort: &port,
+				BackendPort:  &port,
+				Protocol:     network.TransportProtocolAll,
+			},
+		})
+	}
+
+	return &network.LoadBalancer{
+		Name:     &name,
+		Location: &spec.Location,
+		Tags:     spec.Tags,
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &[]network.FrontendIPConfiguration{frontend},
+			BackendAddressPools:      &[]network.BackendAddressPool{{Name: &backendName}},
+			LoadBalancingRules:       &rules,
+		},
+	}
+}
+
+func getAvailabilitySetName(clusterName string) string {
+	return fmt.Sprintf("%s-avset", clusterName)
+}
+
+func getLoadBalancerName(clusterName string) string {
+	return fmt.Sprintf("%s-lb", clusterName)
+}
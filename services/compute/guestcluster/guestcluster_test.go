@@ -0,0 +1,118 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package guestcluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/storage"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeServices struct {
+	calls   []string
+	failLB  bool
+	failOn  string
+	deleted []string
+}
+
+type fakeAvsets struct{ *fakeServices }
+
+func (f fakeAvsets) Create(ctx context.Context, group, name string, avset *compute.AvailabilitySet) (*compute.AvailabilitySet, error) {
+	f.calls = append(f.calls, "avset/"+name)
+	return avset, nil
+}
+
+func (f fakeAvsets) Delete(ctx context.Context, group, name string) error {
+	f.deleted = append(f.deleted, "avset/"+name)
+	return nil
+}
+
+type fakeDisks struct{ *fakeServices }
+
+func (f fakeDisks) CreateOrUpdate(ctx context.Context, group, container, name string, disk *storage.VirtualHardDisk) (*storage.VirtualHardDisk, error) {
+	if f.failOn == name {
+		return nil, errors.Wrapf(errors.Failed, "disk failure")
+	}
+	f.calls = append(f.calls, "disk/"+name)
+	return disk, nil
+}
+
+func (f fakeDisks) Delete(ctx context.Context, group, container, name string) error {
+	f.deleted = append(f.deleted, "disk/"+name)
+	return nil
+}
+
+type fakeLoadBalancers struct{ *fakeServices }
+
+func (f fakeLoadBalancers) CreateOrUpdate(ctx context.Context, group, name string, lb *network.LoadBalancer) (*network.LoadBalancer, error) {
+	if f.failLB {
+		return nil, errors.Wrapf(errors.Failed, "lb failure")
+	}
+	f.calls = append(f.calls, "lb/"+name)
+	return lb, nil
+}
+
+func (f fakeLoadBalancers) Delete(ctx context.Context, group, name string) error {
+	f.deleted = append(f.deleted, "lb/"+name)
+	return nil
+}
+
+func newTestClient(f *fakeServices) *Client {
+	return &Client{
+		availabilitySets: fakeAvsets{f},
+		disks:            fakeDisks{f},
+		loadBalancers:    fakeLoadBalancers{f},
+	}
+}
+
+func getTestSpec() *Spec {
+	return &Spec{
+		Name:        "sql",
+		Location:    "loc",
+		Container:   "shared",
+		SharedDisks: []DiskSpec{{Name: "witness", SizeBytes: 1 << 30}, {Name: "data", SizeBytes: 10 << 30}},
+		SubnetID:    "/subnets/cluster",
+		ClusterIP:   "10.0.0.10",
+		Ports:       []int32{1433},
+	}
+}
+
+func Test_Provision(t *testing.T) {
+	f := &fakeServices{}
+	resources, err := newTestClient(f).Provision(context.Background(), "group", getTestSpec())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"avset/sql-avset", "disk/witness", "disk/data", "lb/sql-lb"}, f.calls)
+	assert.Empty(t, f.deleted)
+	assert.Equal(t, int32(2), *resources.AvailabilitySet.PlatformFaultDomainCount)
+	assert.Len(t, resources.SharedDisks, 2)
+	frontend := (*resources.LoadBalancer.FrontendIPConfigurations)[0]
+	assert.Equal(t, "10.0.0.10", *frontend.PrivateIPAddress)
+	assert.Equal(t, network.Static, frontend.PrivateIPAllocationMethod)
+}
+
+func Test_ProvisionRollback(t *testing.T) {
+	f := &fakeServices{failLB: true}
+	_, err := newTestClient(f).Provision(context.Background(), "group", getTestSpec())
+	assert.Error(t, err)
+	assert.Equal(t, []string{"disk/data", "disk/witness", "avset/sql-avset"}, f.deleted)
+
+	f = &fakeServices{failOn: "data"}
+	_, err = newTestClient(f).Provision(context.Background(), "group", getTestSpec())
+	assert.Error(t, err)
+	assert.Equal(t, []string{"disk/witness", "avset/sql-avset"}, f.deleted)
+}
+
+func Test_ProvisionInvalidSpec(t *testing.T) {
+	f := &fakeServices{}
+	spec := getTestSpec()
+	spec.SubnetID = ""
+	_, err := newTestClient(f).Provision(context.Background(), "group", spec)
+	assert.True(t, errors.IsInvalidInput(err))
+	assert.Empty(t, f.calls)
+}
@@ -9,10 +9,19 @@ import (
 	"log"
 	"time"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/maintenance"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
+	"github.com/microsoft/moc-sdk-for-go/services/admin/version"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc-sdk-for-go/services/network/networkinterface"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
+	wssdcloudcompute "github.com/microsoft/moc/rpc/cloudagent/compute"
 )
 
 type Service interface {
@@ -30,9 +39,15 @@ type Service interface {
 	Precheck(context.Context, string, []*compute.VirtualMachine) (bool, error)
 }
 
+// featureChecker checks that the agent supports a feature before the client uses it
+type featureChecker interface {
+	RequireFeature(context.Context, string) error
+}
+
 type VirtualMachineClient struct {
 	compute.BaseClient
 	internal   Service
+	features   featureChecker
 	cloudFQDN  string
 	authorizer auth.Authorizer
 }
@@ -42,8 +57,13 @@ func NewVirtualMachineClient(cloudFQDN string, authorizer auth.Authorizer) (*Vir
 	if err != nil {
 		return nil, err
 	}
+	versions, err := version.NewVersionClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
 
 	return &VirtualMachineClient{internal: c,
+		features:   versions,
 		cloudFQDN:  cloudFQDN,
 		authorizer: authorizer,
 	}, nil
@@ -51,38 +71,93 @@ func NewVirtualMachineClient(cloudFQDN string, authorizer auth.Authorizer) (*Vir
 
 // Get methods invokes the client Get method
 func (c *VirtualMachineClient) Get(ctx context.Context, group, name string) (*[]compute.VirtualMachine, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *VirtualMachineClient) List(ctx context.Context, group string, opts ...list.Options) (*[]compute.VirtualMachine, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *VirtualMachineClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]compute.VirtualMachine] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]compute.VirtualMachine, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *VirtualMachineClient) NewLister(group string) *list.Lister[compute.VirtualMachine] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]compute.VirtualMachine, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *VirtualMachineClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]compute.VirtualMachine, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/VirtualMachine/{name}, see resourceid.ID
+func (c *VirtualMachineClient) GetByID(ctx context.Context, id string) (*compute.VirtualMachine, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.VirtualMachine, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *VirtualMachineClient) CreateOrUpdate(ctx context.Context, group, name string, compute *compute.VirtualMachine) (*compute.VirtualMachine, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, compute)
 }
 
 // Delete methods invokes delete of the compute resource
 func (c *VirtualMachineClient) Delete(ctx context.Context, group string, name string) error {
+	group = moc.Group(ctx, group)
+	if err := c.checkMaintenanceWindow(ctx, group, name, "Delete"); err != nil {
+		return err
+	}
 	return c.internal.Delete(ctx, group, name)
 }
 
 // Query method invokes the client Get method and uses the provided query to filter the returned results
 func (c *VirtualMachineClient) Query(ctx context.Context, group, query string) (*[]compute.VirtualMachine, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Query(ctx, group, query)
 }
 
 // Start the Virtual Machine
 func (c *VirtualMachineClient) Start(ctx context.Context, group string, name string) (err error) {
+	group = moc.Group(ctx, group)
 	err = c.internal.Start(ctx, group, name)
 	return
 }
 
 // Stop the Virtual Machine
 func (c *VirtualMachineClient) Stop(ctx context.Context, group string, name string) (err error) {
+	group = moc.Group(ctx, group)
+	if err = c.checkMaintenanceWindow(ctx, group, name, "Stop"); err != nil {
+		return
+	}
 	err = c.internal.Stop(ctx, group, name)
 	return
 }
 
 // Restart the Virtual Machine
 func (c *VirtualMachineClient) Restart(ctx context.Context, group string, name string) (err error) {
+	group = moc.Group(ctx, group)
+	if err = c.checkMaintenanceWindow(ctx, group, name, "Restart"); err != nil {
+		return
+	}
 	err = c.internal.Stop(ctx, group, name)
 	if err != nil {
 		return
@@ -93,16 +168,60 @@ func (c *VirtualMachineClient) Restart(ctx context.Context, group string, name s
 
 // Pause the Virtual Machine
 func (c *VirtualMachineClient) Pause(ctx context.Context, group string, name string) (err error) {
+	group = moc.Group(ctx, group)
+	if err = c.requireFeature(ctx, version.FeatureVirtualMachinePause); err != nil {
+		return
+	}
+	if err = c.checkMaintenanceWindow(ctx, group, name, "Pause"); err != nil {
+		return
+	}
 	err = c.internal.Pause(ctx, group, name)
 	return
 }
 
 // Save the Virtual Machine
 func (c *VirtualMachineClient) Save(ctx context.Context, group string, name string) (err error) {
+	group = moc.Group(ctx, group)
+	if err = c.requireFeature(ctx, version.FeatureVirtualMachineSave); err != nil {
+		return
+	}
+	if err = c.checkMaintenanceWindow(ctx, group, name, "Save"); err != nil {
+		return
+	}
 	err = c.internal.Save(ctx, group, name)
 	return
 }
 
+// requireFeature returns a version.FeatureUnavailableError if the agent is older than the minimum version of the
+// feature
+func (c *VirtualMachineClient) requireFeature(ctx context.Context, name string) error {
+	if c.features == nil {
+		return nil
+	}
+	return c.features.RequireFeature(ctx, name)
+}
+
+// checkMaintenanceWindow returns an error if the disruptive operation is not allowed by the maintenance
+// policy of the Virtual Machine's group or location. A Virtual Machine that does not exist is left to the
+// operation to report.
+func (c *VirtualMachineClient) checkMaintenanceWindow(ctx context.Context, group, name, operation string) error {
+	if !maintenance.Enabled() || maintenance.IsOverridden(ctx) {
+		return nil
+	}
+	location := ""
+	vms, err := c.Get(ctx, group, name)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if vms != nil && len(*vms) > 0 && (*vms)[0].Location != nil {
+		location = *(*vms)[0].Location
+	}
+	return maintenance.Check(ctx, group, location, operation)
+}
+
 type UpdateFunctor interface {
 	Update(context.Context, *compute.VirtualMachine) (*compute.VirtualMachine, error)
 }
@@ -324,10 +443,12 @@ func (c *VirtualMachineClient) GetByComputerName(ctx context.Context, group stri
 }
 
 func (c *VirtualMachineClient) RunCommand(ctx context.Context, group, vmName string, request *compute.VirtualMachineRunCommandRequest) (response *compute.VirtualMachineRunCommandResponse, err error) {
+	group = moc.Group(ctx, group)
 	return c.internal.RunCommand(ctx, group, vmName, request)
 }
 
 func (c *VirtualMachineClient) RepairGuestAgent(ctx context.Context, group, vmName string) (err error) {
+	group = moc.Group(ctx, group)
 	return c.internal.RepairGuestAgent(ctx, group, vmName)
 }
 
@@ -375,11 +496,35 @@ func (c *VirtualMachineClient) ListIPs(ctx context.Context, group, name string)
 
 // Validate methods invokes the validate Get method
 func (c *VirtualMachineClient) Validate(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Validate(ctx, group, name)
 }
 
 // Prechecks whether the system is able to create specified virtual machines.
 // Returns true with virtual machine placement in mapping from virtual machine names to node names; or false with reason in error message.
 func (c *VirtualMachineClient) Precheck(ctx context.Context, group string, vms []*compute.VirtualMachine) (bool, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckDuplicateNames(vms); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, group, vms)
 }
+
+// Raw returns the underlying agent client, giving access to agent fields not yet
+// exposed by the sdk. Returns nil if the client is not backed by the agent.
+func (c *VirtualMachineClient) Raw() wssdcloudcompute.VirtualMachineAgentClient {
+	if rc, ok := c.internal.(*client); ok {
+		return rc.VirtualMachineAgentClient
+	}
+	return nil
+}
+
+// ToProto converts a virtual machine to the message sent to the agent
+func ToProto(vm *compute.VirtualMachine, group string) (*wssdcloudcompute.VirtualMachine, error) {
+	return (&client{}).getWssdVirtualMachine(vm, group)
+}
+
+// FromProto converts a virtual machine message returned by the agent
+func FromProto(vm *wssdcloudcompute.VirtualMachine, group string) *compute.VirtualMachine {
+	return (&client{}).getVirtualMachine(vm, group)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualmachine
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/maintenance"
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeVirtualMachineService stores virtual machines by name and records the operations run on them, failing those
+// with an error in failures
+type fakeVirtualMachineService struct {
+	Service
+	mux        sync.Mutex
+	vms        map[string]compute.VirtualMachine
+	operations []string
+	failures   map[string]error
+}
+
+func (f *fakeVirtualMachineService) Get(ctx context.Context, group, name string) (*[]compute.VirtualMachine, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if len(name) == 0 {
+		vms := []compute.VirtualMachine{}
+		for _, vm := range f.vms {
+			vms = append(vms, vm)
+		}
+		return &vms, nil
+	}
+	vm, ok := f.vms[name]
+	if !ok {
+		return nil, errors.Wrapf(errors.NotFound, "Virtual Machine [%s] not found", name)
+	}
+	return &[]compute.VirtualMachine{vm}, nil
+}
+
+func (f *fakeVirtualMachineService) record(operation, name string) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.operations = append(f.operations, operation+" "+name)
+	return f.failures[operation]
+}
+
+func (f *fakeVirtualMachineService) CreateOrUpdate(ctx context.Context, group, name string, vm *compute.VirtualMachine) (*compute.VirtualMachine, error) {
+	if err := f.record("CreateOrUpdate", name); err != nil {
+		return nil, err
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	f.vms[name] = *vm
+	return vm, nil
+}
+
+func (f *fakeVirtualMachineService) Delete(ctx context.Context, group, name string) error {
+	if err := f.record("Delete", name); err != nil {
+		return err
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	delete(f.vms, name)
+	return nil
+}
+
+func (f *fakeVirtualMachineService) Start(ctx context.Context, group, name string) error {
+	return f.record("Start", name)
+}
+
+func (f *fakeVirtualMachineService) Stop(ctx context.Context, group, name string) error {
+	return f.record("Stop", name)
+}
+
+func (f *fakeVirtualMachineService) Pause(ctx context.Context, group, name string) error {
+	return f.record("Pause", name)
+}
+
+func (f *fakeVirtualMachineService) Save(ctx context.Context, group, name string) error {
+	return f.record("Save", name)
+}
+
+func newMaintenanceTestVirtualMachine(name, node string) compute.VirtualMachine {
+	return compute.VirtualMachine{Name: &name, VirtualMachineProperties: &compute.VirtualMachineProperties{Host: &compute.SubResource{ID: &node}}}
+}
+
+func Test_checkMaintenanceWindow(t *testing.T) {
+	defer maintenance.ClearPolicies()
+	// A policy without windows never allows disruptive operations
+	maintenance.SetGroupPolicy("group1", &maintenance.Policy{})
+	fake := &fakeVirtualMachineService{vms: map[string]compute.VirtualMachine{"vm1": newMaintenanceTestVirtualMachine("vm1", "node1")}}
+	c := &VirtualMachineClient{internal: fake}
+	ctx := context.Background()
+
+	operations := map[string]func(context.Context, string, string) error{
+		"Delete":  c.Delete,
+		"Stop":    c.Stop,
+		"Restart": c.Restart,
+		"Pause":   c.Pause,
+		"Save":    c.Save,
+	}
+	for operation, run := range operations {
+		err := run(ctx, "group1", "vm1")
+		assert.True(t, maintenance.IsOutsideWindow(err), operation)
+	}
+	assert.Equal(t, 0, len(fake.operations))
+
+	for operation, run := range operations {
+		assert.NoError(t, run(maintenance.WithOverride(ctx), "group1", "vm1"), operation)
+	}
+	assert.Equal(t, 6, len(fake.operations))
+
+	// Starting is not disruptive
+	assert.NoError(t, c.Start(ctx, "group1", "vm1"))
+}
+
+func Test_DrainNode(t *testing.T) {
+	defer maintenance.ClearPolicies()
+	fake := &fakeVirtualMachineService{vms: map[string]compute.VirtualMachine{
+		"vm1": newMaintenanceTestVirtualMachine("vm1", "node1"),
+		"vm2": newMaintenanceTestVirtualMachine("vm2", "Node1"),
+		"vm3": newMaintenanceTestVirtualMachine("vm3", "node2"),
+	}}
+	c := &VirtualMachineClient{internal: fake}
+
+	maintenance.SetGroupPolicy("group1", &maintenance.Policy{})
+	_, err := c.DrainNode(context.Background(), "group1", "node1", PowerOptions{})
+	assert.True(t, maintenance.IsOutsideWindow(err))
+	assert.Equal(t, 0, len(fake.operations))
+
+	maintenance.ClearPolicies()
+	outcomes, err := c.DrainNode(context.Background(), "group1", "node1", PowerOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(outcomes))
+	sort.Strings(fake.operations)
+	assert.Equal(t, []string{"Stop vm1", "Stop vm2"}, fake.operations)
+
+	_, err = c.DrainNode(context.Background(), "group1", "", PowerOptions{})
+	assert.True(t, errors.IsInvalidInput(err))
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualmachine
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/maintenance"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// PowerBatch is a set of virtual machines started or stopped together
+type PowerBatch struct {
+	Names []string
+	// DelayAfter - Wait after the batch completes before the next batch starts
+	DelayAfter time.Duration
+}
+
+// FailurePolicy decides whether the remaining batches run after a virtual machine of a batch fails
+type FailurePolicy string
+
+const (
+	// ContinueOnFailure runs the remaining batches
+	ContinueOnFailure FailurePolicy = "Continue"
+	// StopOnFailure skips the remaining batches, the virtual machines of which report ErrBatchSkipped
+	StopOnFailure FailurePolicy = "Stop"
+)
+
+// ErrBatchSkipped is reported for the virtual machines of batches skipped by StopOnFailure or by cancellation
+var ErrBatchSkipped = errors.New("Skipped after an earlier batch failed or was cancelled")
+
+// PowerOptions configures StartMany and StopMany
+type PowerOptions struct {
+	// Parallelism - Operations running concurrently within a batch, parallel.DefaultParallelism if zero
+	Parallelism int
+	// FailurePolicy - StopOnFailure if empty
+	FailurePolicy FailurePolicy
+}
+
+// StartMany starts the virtual machines batch after batch, in order. The virtual machines of a batch are started
+// concurrently. The outcome for each virtual machine is returned keyed by its name, and the error is set if any
+// virtual machine failed or was skipped.
+func (c *VirtualMachineClient) StartMany(ctx context.Context, group string, batches []PowerBatch, options PowerOptions) (map[string]error, error) {
+	return runPowerBatches(ctx, batches, options, func(ctx context.Context, name string) error {
+		return c.Start(ctx, group, name)
+	})
+}
+
+// StopMany stops the virtual machines batch after batch, in order, as StartMany starts them
+func (c *VirtualMachineClient) StopMany(ctx context.Context, group string, batches []PowerBatch, options PowerOptions) (map[string]error, error) {
+	return runPowerBatches(ctx, batches, options, func(ctx context.Context, name string) error {
+		return c.Stop(ctx, group, name)
+	})
+}
+
+// DrainNode stops the virtual machines of the group running on the node, so that the node can be taken down. The
+// agent cannot move a virtual machine to another node, so the virtual machines stay stopped until they are started
+// again. The drain is checked against the maintenance policy once, rather than for each virtual machine.
+func (c *VirtualMachineClient) DrainNode(ctx context.Context, group, nodeName string, options PowerOptions) (map[string]error, error) {
+	group = moc.Group(ctx, group)
+	if len(nodeName) == 0 {
+		return nil, errors.Wrapf(errors.InvalidInput, "Node name not specified")
+	}
+	vms, err := c.List(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+
+	names, location := []string{}, ""
+	if vms != nil {
+		for _, vm := range *vms {
+			if vm.Name == nil || vm.Host == nil || vm.Host.ID == nil || !strings.EqualFold(*vm.Host.ID, nodeName) {
+				continue
+			}
+			names = append(names, *vm.Name)
+			if vm.Location != nil {
+				location = *vm.Location
+			}
+		}
+	}
+	if len(names) == 0 {
+		return map[string]error{}, nil
+	}
+
+	if err := maintenance.Check(ctx, group, location, "DrainNode"); err != nil {
+		return nil, err
+	}
+	return c.StopMany(maintenance.WithOverride(ctx), group, []PowerBatch{{Names: names}}, options)
+}
+
+func runPowerBatches(ctx context.Context, batches []PowerBatch, options PowerOptions, operation func(context.Context, string) error) (map[string]error, error) {
+	outcomes := map[string]error{}
+	failed, skip := 0, false
+	for i, batch := range batches {
+		if skip || ctx.Err() != nil {
+			for _, name := range batch.Names {
+				outcomes[name] = ErrBatchSkipped
+				failed++
+			}
+			continue
+		}
+
+		results := parallel.GetMany(ctx, batch.Names, options.Parallelism, func(ctx context.Context, name string) (struct{}, error) {
+			return struct{}{}, operation(ctx, name)
+		})
+		batchFailed := false
+		for name, result := range results {
+			outcomes[name] = result.Err
+			if result.Err != nil {
+				failed++
+				batchFailed = true
+			}
+		}
+		if batchFailed && options.FailurePolicy != ContinueOnFailure {
+			skip = true
+			continue
+		}
+
+		if batch.DelayAfter > 0 && i < len(batches)-1 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(batch.DelayAfter):
+			}
+		}
+	}
+
+	if failed > 0 {
+		return outcomes, errors.Wrapf(errors.Failed, "%d of %d virtual machines failed or were skipped", failed, len(outcomes))
+	}
+	return outcomes, nil
+}
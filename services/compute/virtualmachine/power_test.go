@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualmachine
+
+import (
+	"context"
+	goerrors "errors"
+	"sync"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/admin/version"
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_runPowerBatches(t *testing.T) {
+	var mux sync.Mutex
+	started := []string{}
+	operation := func(ctx context.Context, name string) error {
+		mux.Lock()
+		defer mux.Unlock()
+		started = append(started, name)
+		if name == "vm2" {
+			return errors.Wrapf(errors.Failed, "vm2 did not start")
+		}
+		return nil
+	}
+	batches := []PowerBatch{{Names: []string{"vm1"}}, {Names: []string{"vm2", "vm3"}}, {Names: []string{"vm4"}}}
+
+	outcomes, err := runPowerBatches(context.Background(), batches, PowerOptions{}, operation)
+	assert.Error(t, err)
+	assert.NoError(t, outcomes["vm1"])
+	assert.Error(t, outcomes["vm2"])
+	assert.NoError(t, outcomes["vm3"])
+	assert.Equal(t, ErrBatchSkipped, outcomes["vm4"])
+	assert.Equal(t, "vm1", started[0])
+	assert.Equal(t, 3, len(started))
+
+	started = []string{}
+	outcomes, err = runPowerBatches(context.Background(), batches, PowerOptions{FailurePolicy: ContinueOnFailure}, operation)
+	assert.Error(t, err)
+	assert.NoError(t, outcomes["vm4"])
+	assert.Equal(t, 4, len(started))
+}
+
+// fakeFeatureChecker supports the features set to true
+type fakeFeatureChecker map[string]bool
+
+func (f fakeFeatureChecker) RequireFeature(ctx context.Context, name string) error {
+	if !f[name] {
+		return &version.FeatureUnavailableError{Feature: name, MinVersion: "v1.0.0", AgentVersion: "v0.1.0"}
+	}
+	return nil
+}
+
+func Test_PauseSaveRequireFeature(t *testing.T) {
+	fake := &fakeVirtualMachineService{vms: map[string]compute.VirtualMachine{"vm1": newMaintenanceTestVirtualMachine("vm1", "node1")}}
+	c := &VirtualMachineClient{internal: fake, features: fakeFeatureChecker{version.FeatureVirtualMachineSave: true}}
+
+	err := c.Pause(context.Background(), "group", "vm1")
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+	assert.NoError(t, c.Save(context.Background(), "group", "vm1"))
+	assert.Equal(t, []string{"Save vm1"}, fake.operations)
+}
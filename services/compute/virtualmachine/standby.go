@@ -0,0 +1,195 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualmachine
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/microsoft/moc-sdk-for-go/pkg/maintenance"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourcetags"
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// Tags marking the virtual machines of a standby pool
+const (
+	// StandbyPoolTag - Name of the template of the pool the virtual machine belongs to
+	StandbyPoolTag = resourcetags.ReservedPrefix + "standbypool"
+	// StandbyStateTag - StandbyStateReady for stopped virtual machines ready to be acquired, StandbyStateProvisioning
+	// for virtual machines being stopped by Fill, StandbyStateAcquired otherwise
+	StandbyStateTag = resourcetags.ReservedPrefix + "standbystate"
+
+	StandbyStateProvisioning = "Provisioning"
+	StandbyStateReady        = "Ready"
+	StandbyStateAcquired     = "Acquired"
+)
+
+// StandbyTemplate returns the definition of a new virtual machine of the pool, including its network interfaces and
+// disks, which must already exist or be created by the template
+type StandbyTemplate func(ctx context.Context, name string) (*compute.VirtualMachine, error)
+
+// StandbyPool keeps stopped virtual machines of a template provisioned in a group, so that scaling out only has to
+// start a virtual machine instead of creating one. The agent cannot rename a virtual machine, so acquired virtual
+// machines keep the name they were provisioned with and are identified by the tags set by Acquire.
+type StandbyPool struct {
+	vms      *VirtualMachineClient
+	group    string
+	name     string
+	size     int
+	template StandbyTemplate
+}
+
+// NewStandbyPool returns a pool of size stopped virtual machines named after name and defined by template
+func NewStandbyPool(vms *VirtualMachineClient, group, name string, size int, template StandbyTemplate) *StandbyPool {
+	return &StandbyPool{vms: vms, group: group, name: name, size: size, template: template}
+}
+
+// Fill provisions and stops virtual machines until the pool holds size ready virtual machines, and returns the
+// number of virtual machines provisioned. A virtual machine is tagged ready only once it is stopped; one that fails
+// to stop or to be tagged is deleted.
+func (p *StandbyPool) Fill(ctx context.Context) (int, error) {
+	ready, err := p.list(ctx, StandbyStateReady)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	for i := len(ready); i < p.size; i++ {
+		name := p.name + "-" + uuid.New().String()[:8]
+		vm, err := p.template(ctx, name)
+		if err != nil {
+			return created, err
+		}
+		vm.Name = &name
+		vm.Tags = resourcetags.Merge(vm.Tags, p.tags(StandbyStateProvisioning))
+		if _, err := p.vms.CreateOrUpdate(ctx, p.group, name, vm); err != nil {
+			return created, err
+		}
+		if err := p.makeReady(ctx, name); err != nil {
+			if deleteErr := p.vms.Delete(maintenance.WithOverride(ctx), p.group, name); deleteErr != nil {
+				return created, errors.Wrapf(err, "Virtual Machine [%s] could not be deleted: %v", name, deleteErr)
+			}
+			return created, err
+		}
+		created++
+	}
+	return created, nil
+}
+
+// makeReady stops a virtual machine provisioned by Fill and tags it ready. Nothing runs on the virtual machine yet,
+// so stopping it is not checked against the maintenance policy.
+func (p *StandbyPool) makeReady(ctx context.Context, name string) error {
+	if err := p.vms.Stop(maintenance.WithOverride(ctx), p.group, name); err != nil {
+		return err
+	}
+	return p.vms.Update(ctx, p.group, name, standbyUpdate(func(vm *compute.VirtualMachine) (*compute.VirtualMachine, error) {
+		if !p.inState(vm, StandbyStateProvisioning) {
+			return nil, errors.Wrapf(errors.InvalidInput, "Virtual Machine [%s] is not being provisioned by standby pool [%s]", name, p.name)
+		}
+		vm.Tags = resourcetags.Merge(vm.Tags, p.tags(StandbyStateReady))
+		return vm, nil
+	}))
+}
+
+// Acquire takes a ready virtual machine from the pool, sets tags on it and starts it. Acquire returns a NotFound
+// error if the pool has no ready virtual machine; call Fill to replenish it.
+func (p *StandbyPool) Acquire(ctx context.Context, tags map[string]*string) (*compute.VirtualMachine, error) {
+	if err := resourcetags.ValidateUserTags(tags); err != nil {
+		return nil, err
+	}
+	ready, err := p.list(ctx, StandbyStateReady)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range ready {
+		// Another caller may acquire the same virtual machine, the update fails for the one that reads it second
+		err := p.vms.Update(ctx, p.group, name, standbyUpdate(func(vm *compute.VirtualMachine) (*compute.VirtualMachine, error) {
+			if !p.inState(vm, StandbyStateReady) {
+				return nil, errors.Wrapf(errors.AlreadyExists, "Virtual Machine [%s] is already acquired", name)
+			}
+			vm.Tags = resourcetags.Merge(vm.Tags, resourcetags.Merge(tags, p.tags(StandbyStateAcquired)))
+			return vm, nil
+		}))
+		if errors.IsAlreadyExists(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := p.vms.Start(ctx, p.group, name); err != nil {
+			return nil, err
+		}
+		vms, err := p.vms.Get(ctx, p.group, name)
+		if err != nil {
+			return nil, err
+		}
+		if vms == nil || len(*vms) == 0 {
+			return nil, errors.Wrapf(errors.NotFound, "Virtual Machine [%s] not found", name)
+		}
+		return &(*vms)[0], nil
+	}
+	return nil, errors.Wrapf(errors.NotFound, "Standby pool [%s] has no ready virtual machine", p.name)
+}
+
+// Release stops an acquired virtual machine and returns it to the pool, removing the tags set by Acquire
+func (p *StandbyPool) Release(ctx context.Context, name string) error {
+	if err := p.vms.Stop(ctx, p.group, name); err != nil {
+		return err
+	}
+	return p.vms.Update(ctx, p.group, name, standbyUpdate(func(vm *compute.VirtualMachine) (*compute.VirtualMachine, error) {
+		if !p.inState(vm, StandbyStateAcquired) {
+			return nil, errors.Wrapf(errors.InvalidInput, "Virtual Machine [%s] is not an acquired virtual machine of standby pool [%s]", name, p.name)
+		}
+		tags, err := resourcetags.ReplaceUserTags(vm.Tags, nil)
+		if err != nil {
+			return nil, err
+		}
+		vm.Tags = resourcetags.Merge(tags, p.tags(StandbyStateReady))
+		return vm, nil
+	}))
+}
+
+// list returns the names of the virtual machines of the pool in the state, sorted
+func (p *StandbyPool) list(ctx context.Context, state string) ([]string, error) {
+	vms, err := p.vms.List(ctx, p.group)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	names := []string{}
+	if vms == nil {
+		return names, nil
+	}
+	for i := range *vms {
+		vm := &(*vms)[i]
+		if vm.Name != nil && p.inState(vm, state) {
+			names = append(names, *vm.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (p *StandbyPool) inState(vm *compute.VirtualMachine, state string) bool {
+	pool, ok := resourcetags.Get(vm.Tags, StandbyPoolTag)
+	if !ok || pool == nil || !strings.EqualFold(*pool, p.name) {
+		return false
+	}
+	current, ok := resourcetags.Get(vm.Tags, StandbyStateTag)
+	return ok && current != nil && strings.EqualFold(*current, state)
+}
+
+func (p *StandbyPool) tags(state string) map[string]*string {
+	name := p.name
+	return map[string]*string{StandbyPoolTag: &name, StandbyStateTag: &state}
+}
+
+type standbyUpdate func(*compute.VirtualMachine) (*compute.VirtualMachine, error)
+
+func (f standbyUpdate) Update(_ context.Context, vm *compute.VirtualMachine) (*compute.VirtualMachine, error) {
+	return f(vm)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualmachine
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/maintenance"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourcetags"
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStandbyTestPool(fake *fakeVirtualMachineService, size int) *StandbyPool {
+	return NewStandbyPool(&VirtualMachineClient{internal: fake}, "group1", "pool1", size, func(ctx context.Context, name string) (*compute.VirtualMachine, error) {
+		return &compute.VirtualMachine{}, nil
+	})
+}
+
+func getStandbyState(vm compute.VirtualMachine) string {
+	state, _ := resourcetags.Get(vm.Tags, StandbyStateTag)
+	if state == nil {
+		return ""
+	}
+	return *state
+}
+
+func Test_StandbyPoolFill(t *testing.T) {
+	defer maintenance.ClearPolicies()
+	// Stopping a new virtual machine of the pool is not held back by the maintenance policy
+	maintenance.SetGroupPolicy("group1", &maintenance.Policy{})
+	fake := &fakeVirtualMachineService{vms: map[string]compute.VirtualMachine{}}
+	pool := newStandbyTestPool(fake, 2)
+
+	created, err := pool.Fill(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, created)
+	assert.Equal(t, 2, len(fake.vms))
+	for _, vm := range fake.vms {
+		assert.Equal(t, StandbyStateReady, getStandbyState(vm))
+	}
+
+	// The pool is full
+	created, err = pool.Fill(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, created)
+}
+
+func Test_StandbyPoolFillDeletesOnFailure(t *testing.T) {
+	stopErr := errors.Wrapf(errors.Failed, "Stop failed")
+	fake := &fakeVirtualMachineService{
+		vms:      map[string]compute.VirtualMachine{},
+		failures: map[string]error{"Stop": stopErr},
+	}
+	pool := newStandbyTestPool(fake, 1)
+
+	created, err := pool.Fill(context.Background())
+	assert.Equal(t, stopErr, err)
+	assert.Equal(t, 0, created)
+	// The virtual machine that could not be stopped is not left in the pool
+	assert.Equal(t, 0, len(fake.vms))
+	assert.True(t, strings.HasPrefix(fake.operations[len(fake.operations)-1], "Delete "))
+
+	ready, err := pool.list(context.Background(), StandbyStateReady)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(ready))
+}
+
+func Test_StandbyPoolAcquireAndRelease(t *testing.T) {
+	fake := &fakeVirtualMachineService{vms: map[string]compute.VirtualMachine{}}
+	pool := newStandbyTestPool(fake, 1)
+	_, err := pool.Fill(context.Background())
+	assert.NoError(t, err)
+
+	owner := "team"
+	vm, err := pool.Acquire(context.Background(), map[string]*string{"owner": &owner})
+	assert.NoError(t, err)
+	assert.Equal(t, StandbyStateAcquired, getStandbyState(*vm))
+	assert.Equal(t, owner, *vm.Tags["owner"])
+
+	_, err = pool.Acquire(context.Background(), nil)
+	assert.True(t, errors.IsNotFound(err))
+
+	assert.NoError(t, pool.Release(context.Background(), *vm.Name))
+	released := fake.vms[*vm.Name]
+	assert.Equal(t, StandbyStateReady, getStandbyState(released))
+	assert.Nil(t, released.Tags["owner"])
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/microsoft/moc/pkg/convert"
 	"github.com/microsoft/moc/pkg/errors"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/status"
 	wssdcloudcompute "github.com/microsoft/moc/rpc/cloudagent/compute"
 	wssdcloudproto "github.com/microsoft/moc/rpc/common"
@@ -567,7 +568,7 @@ func (c *client) getVirtualMachine(vm *wssdcloudcompute.VirtualMachine, group st
 }
 
 func (c *client) getVirtualMachineStatuses(vm *wssdcloudcompute.VirtualMachine) map[string]*string {
-	statuses := status.GetStatuses(vm.GetStatus())
+	statuses := provisioning.GetStatuses(vm.GetStatus())
 	statuses["PowerState"] = convert.ToStringPtr(vm.GetPowerState().String())
 	return statuses
 }
@@ -7,12 +7,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/config"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/marshal"
-	prototags "github.com/microsoft/moc/pkg/tags"
 	"github.com/microsoft/moc/pkg/validations"
 	wssdcloudproto "github.com/microsoft/moc/rpc/common"
 
@@ -319,6 +320,7 @@ func (c *client) getVirtualMachineFromResponse(response *wssdcloudcompute.Virtua
 		vms = append(vms, *(c.getVirtualMachine(vm, group)))
 	}
 
+	list.SortResponse(&vms)
 	return &vms
 }
 
@@ -363,11 +365,11 @@ func (c *client) getVirtualMachineOperationRequest(ctx context.Context,
 }
 
 func getComputeTags(tags *wssdcloudproto.Tags) map[string]*string {
-	return prototags.ProtoToMap(tags)
+	return conversion.TagsFromProto(conversion.VirtualMachine, tags)
 }
 
 func getWssdTags(tags map[string]*string) *wssdcloudproto.Tags {
-	return prototags.MapToProto(tags)
+	return conversion.TagsToProto(conversion.VirtualMachine, tags)
 }
 
 func (c *client) virtualMachineValidations(opType wssdcloudproto.Operation, vmss *compute.VirtualMachine) error {
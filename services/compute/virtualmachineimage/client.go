@@ -6,6 +6,12 @@ package virtualmachineimage
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -36,21 +42,67 @@ func NewVirtualMachineImageClient(cloudFQDN string, authorizer auth.Authorizer)
 
 // Get methods invokes the client Get method
 func (c *VirtualMachineImageClient) Get(ctx context.Context, group, name string) (*[]compute.VirtualMachineImage, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *VirtualMachineImageClient) List(ctx context.Context, group string, opts ...list.Options) (*[]compute.VirtualMachineImage, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *VirtualMachineImageClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]compute.VirtualMachineImage] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]compute.VirtualMachineImage, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *VirtualMachineImageClient) NewLister(group string) *list.Lister[compute.VirtualMachineImage] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]compute.VirtualMachineImage, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *VirtualMachineImageClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]compute.VirtualMachineImage, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/VirtualMachineImage/{name}, see resourceid.ID
+func (c *VirtualMachineImageClient) GetByID(ctx context.Context, id string) (*compute.VirtualMachineImage, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.VirtualMachineImage, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *VirtualMachineImageClient) CreateOrUpdate(ctx context.Context, group, name string, compute *compute.VirtualMachineImage) (*compute.VirtualMachineImage, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, compute)
 }
 
 // Delete methods invokes delete of the compute resource
 func (c *VirtualMachineImageClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
 
 // Prechecks whether the system is able to create specified virtualMachineImages.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *VirtualMachineImageClient) Precheck(ctx context.Context, group string, virtualMachineImages []*compute.VirtualMachineImage) (bool, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckDuplicateNames(virtualMachineImages); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, group, virtualMachineImages)
 }
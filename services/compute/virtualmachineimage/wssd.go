@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -121,6 +122,7 @@ func getVirtualMachineImagesFromResponse(response *wssdcloudcompute.VirtualMachi
 		virtualHardDisks = append(virtualHardDisks, *(getVirtualMachineImage(vhd, group)))
 	}
 
+	list.SortResponse(&virtualHardDisks)
 	return &virtualHardDisks
 }
 
@@ -6,6 +6,12 @@ package virtualmachinescaleset
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -33,20 +39,57 @@ func NewVirtualMachineScaleSetClient(cloudFQDN string, authorizer auth.Authorize
 
 // Get methods invokes the client Get method
 func (c *VirtualMachineScaleSetClient) Get(ctx context.Context, group, name string) (*[]compute.VirtualMachineScaleSet, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *VirtualMachineScaleSetClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]compute.VirtualMachineScaleSet] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]compute.VirtualMachineScaleSet, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *VirtualMachineScaleSetClient) NewLister(group string) *list.Lister[compute.VirtualMachineScaleSet] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]compute.VirtualMachineScaleSet, error) {
+		return c.internal.Get(ctx, group, "")
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *VirtualMachineScaleSetClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]compute.VirtualMachineScaleSet, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/VirtualMachineScaleSet/{name}, see resourceid.ID
+func (c *VirtualMachineScaleSetClient) GetByID(ctx context.Context, id string) (*compute.VirtualMachineScaleSet, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.VirtualMachineScaleSet, c.Get)
+}
+
 // Get methods invokes the client Get method
-func (c *VirtualMachineScaleSetClient) List(ctx context.Context, group, name string) (*[]compute.VirtualMachine, error) {
-	return c.internal.GetVirtualMachines(ctx, group, name)
+func (c *VirtualMachineScaleSetClient) List(ctx context.Context, group, name string, opts ...list.Options) (*[]compute.VirtualMachine, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.GetVirtualMachines(ctx, group, name)
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
 }
 
 // CreateOrUpdate methods invokes create or update on the client
 func (c *VirtualMachineScaleSetClient) CreateOrUpdate(ctx context.Context, group, name string, compute *compute.VirtualMachineScaleSet) (*compute.VirtualMachineScaleSet, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, compute)
 }
 
 // Delete methods invokes delete of the compute resource
 func (c *VirtualMachineScaleSetClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
@@ -4,6 +4,7 @@
 package virtualmachinescaleset
 
 import (
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -28,7 +29,7 @@ func (c *client) getVirtualMachineScaleSet(vmss *wssdcloudcompute.VirtualMachine
 		},
 		VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
 			ProvisioningState:     status.GetProvisioningState(vmss.GetStatus().GetProvisioningStatus()),
-			Statuses:              status.GetStatuses(vmss.GetStatus()),
+			Statuses:              provisioning.GetStatuses(vmss.GetStatus()),
 			VirtualMachineProfile: vmprofile,
 		},
 	}, nil
@@ -13,6 +13,7 @@ import (
 	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/compute"
 	"github.com/microsoft/moc-sdk-for-go/services/compute/virtualmachine"
 	wssdcloudcompute "github.com/microsoft/moc/rpc/cloudagent/compute"
@@ -135,6 +136,7 @@ func (c *client) getVirtualMachineScaleSetFromResponse(response *wssdcloudcomput
 		vmsss = append(vmsss, *cvmss)
 	}
 
+	list.SortResponse(&vmsss)
 	return &vmsss, nil
 
 }
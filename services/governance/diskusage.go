@@ -0,0 +1,173 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/services/storage"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// DiskUsage is the placement and size of a virtual hard disk
+type DiskUsage struct {
+	Group     string `json:"group"`
+	Container string `json:"container"`
+	Name      string `json:"name"`
+	// VirtualMachine - Virtual machine the disk is attached to, empty if the disk is not attached
+	VirtualMachine string `json:"virtualMachine,omitempty"`
+	// SizeBytes - Provisioned size of the disk. The agent does not report the space a dynamic disk uses on
+	// disk, so for dynamic disks this is an upper bound.
+	SizeBytes int64 `json:"sizeBytes"`
+	Dynamic   bool  `json:"dynamic"`
+}
+
+// UsageTotal is the size of the disks owned by a virtual machine or a group
+type UsageTotal struct {
+	Group string `json:"group"`
+	// VirtualMachine - Owning virtual machine, empty in group totals and for the unattached disks of a group
+	VirtualMachine string `json:"virtualMachine,omitempty"`
+	DiskCount      int    `json:"diskCount"`
+	SizeBytes      int64  `json:"sizeBytes"`
+}
+
+// DiskUsageReport attributes the virtual hard disks of a location to their virtual machines and groups
+type DiskUsageReport struct {
+	Location    string    `json:"location"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Disks - Disks sorted by group, container and name
+	Disks []DiskUsage `json:"disks"`
+	// VirtualMachines - Totals per virtual machine, largest first
+	VirtualMachines []UsageTotal `json:"virtualMachines"`
+	// Groups - Totals per group, largest first
+	Groups []UsageTotal `json:"groups"`
+}
+
+// JSON returns the report in JSON
+func (r *DiskUsageReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// GetDiskUsageReport returns the virtual hard disks in all the groups and containers of the location, with their
+// owning virtual machine, and the total size of the disks of each virtual machine and group.
+func (c *Client) GetDiskUsageReport(ctx context.Context, location string) (*DiskUsageReport, error) {
+	groups, err := c.groups.List(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := c.containers.List(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	disks := []DiskUsage{}
+	if groups != nil && containers != nil {
+		for _, group := range *groups {
+			if group.Name == nil {
+				continue
+			}
+			for _, ct := range *containers {
+				if ct.Name == nil {
+					continue
+				}
+				vhds, err := c.vhds.List(ctx, *group.Name, *ct.Name)
+				if err != nil {
+					if errors.IsNotFound(err) {
+						continue
+					}
+					return nil, err
+				}
+				if vhds == nil {
+					continue
+				}
+				for i := range *vhds {
+					if (*vhds)[i].Name == nil {
+						continue
+					}
+					disks = append(disks, getDiskUsage(*group.Name, *ct.Name, &(*vhds)[i]))
+				}
+			}
+		}
+	}
+
+	return newDiskUsageReport(location, disks), nil
+}
+
+func getDiskUsage(group, containerName string, vhd *storage.VirtualHardDisk) DiskUsage {
+	usage := DiskUsage{Group: group, Container: containerName, Name: *vhd.Name}
+	if props := vhd.VirtualHardDiskProperties; props != nil {
+		if props.DiskSizeBytes != nil {
+			usage.SizeBytes = *props.DiskSizeBytes
+		}
+		if props.Dynamic != nil {
+			usage.Dynamic = *props.Dynamic
+		}
+		if props.VirtualMachineName != nil {
+			usage.VirtualMachine = *props.VirtualMachineName
+		}
+	}
+	return usage
+}
+
+func newDiskUsageReport(location string, disks []DiskUsage) *DiskUsageReport {
+	sort.SliceStable(disks, func(i, j int) bool {
+		if disks[i].Group != disks[j].Group {
+			return disks[i].Group < disks[j].Group
+		}
+		if disks[i].Container != disks[j].Container {
+			return disks[i].Container < disks[j].Container
+		}
+		return disks[i].Name < disks[j].Name
+	})
+
+	type key struct{ group, vm string }
+	vmTotals := map[key]*UsageTotal{}
+	groupTotals := map[string]*UsageTotal{}
+	for _, disk := range disks {
+		k := key{disk.Group, disk.VirtualMachine}
+		if vmTotals[k] == nil {
+			vmTotals[k] = &UsageTotal{Group: disk.Group, VirtualMachine: disk.VirtualMachine}
+		}
+		vmTotals[k].DiskCount++
+		vmTotals[k].SizeBytes += disk.SizeBytes
+
+		if groupTotals[disk.Group] == nil {
+			groupTotals[disk.Group] = &UsageTotal{Group: disk.Group}
+		}
+		groupTotals[disk.Group].DiskCount++
+		groupTotals[disk.Group].SizeBytes += disk.SizeBytes
+	}
+
+	report := &DiskUsageReport{
+		Location:        location,
+		GeneratedAt:     time.Now().UTC(),
+		Disks:           disks,
+		VirtualMachines: []UsageTotal{},
+		Groups:          []UsageTotal{},
+	}
+	for _, total := range vmTotals {
+		report.VirtualMachines = append(report.VirtualMachines, *total)
+	}
+	for _, total := range groupTotals {
+		report.Groups = append(report.Groups, *total)
+	}
+	sortUsageTotals(report.VirtualMachines)
+	sortUsageTotals(report.Groups)
+	return report
+}
+
+func sortUsageTotals(totals []UsageTotal) {
+	sort.SliceStable(totals, func(i, j int) bool {
+		if totals[i].SizeBytes != totals[j].SizeBytes {
+			return totals[i].SizeBytes > totals[j].SizeBytes
+		}
+		if totals[i].Group != totals[j].Group {
+			return totals[i].Group < totals[j].Group
+		}
+		return totals[i].VirtualMachine < totals[j].VirtualMachine
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package governance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newDiskUsageReport(t *testing.T) {
+	disks := []DiskUsage{
+		{Group: "group2", Container: "c1", Name: "data", SizeBytes: 10},
+		{Group: "group1", Container: "c1", Name: "vm1-os", VirtualMachine: "vm1", SizeBytes: 100},
+		{Group: "group1", Container: "c2", Name: "vm1-data", VirtualMachine: "vm1", SizeBytes: 50},
+		{Group: "group1", Container: "c1", Name: "vm2-os", VirtualMachine: "vm2", SizeBytes: 100},
+	}
+
+	report := newDiskUsageReport("location1", disks)
+	assert.Equal(t, "vm1-os", report.Disks[0].Name)
+	assert.Equal(t, "vm2-os", report.Disks[1].Name)
+	assert.Equal(t, "vm1-data", report.Disks[2].Name)
+	assert.Equal(t, "data", report.Disks[3].Name)
+
+	assert.Equal(t, []UsageTotal{
+		{Group: "group1", VirtualMachine: "vm1", DiskCount: 2, SizeBytes: 150},
+		{Group: "group1", VirtualMachine: "vm2", DiskCount: 1, SizeBytes: 100},
+		{Group: "group2", DiskCount: 1, SizeBytes: 10},
+	}, report.VirtualMachines)
+	assert.Equal(t, []UsageTotal{
+		{Group: "group1", DiskCount: 3, SizeBytes: 250},
+		{Group: "group2", DiskCount: 1, SizeBytes: 10},
+	}, report.Groups)
+}
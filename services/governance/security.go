@@ -0,0 +1,121 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+)
+
+// DiskSecurityStatus is the encryption state of a disk of a virtual machine
+type DiskSecurityStatus struct {
+	Name string `json:"name"`
+	// EncryptionType - Security encryption type of the managed disk, empty if the disk has none
+	EncryptionType compute.SecurityEncryptionTypes `json:"encryptionType,omitempty"`
+}
+
+// VirtualMachineSecurityStatus is the security state of a virtual machine and its disks
+type VirtualMachineSecurityStatus struct {
+	Group        string                `json:"group"`
+	Name         string                `json:"name"`
+	SecurityType compute.SecurityTypes `json:"securityType,omitempty"`
+	SecureBoot   bool                  `json:"secureBoot"`
+	VTPM         bool                  `json:"vTPM"`
+	OSDisk       *DiskSecurityStatus   `json:"osDisk,omitempty"`
+	DataDisks    []DiskSecurityStatus  `json:"dataDisks,omitempty"`
+}
+
+// SecurityReport lists the security state of the virtual machines of a location
+type SecurityReport struct {
+	Location        string                         `json:"location"`
+	GeneratedAt     time.Time                      `json:"generatedAt"`
+	VirtualMachines []VirtualMachineSecurityStatus `json:"virtualMachines"`
+}
+
+// JSON returns the report in JSON, for export to auditing tools
+func (r *SecurityReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// GetSecurityReport returns the encryption, secure boot and vTPM state of the virtual machines, and of their
+// disks, in all the groups of the location. Virtual machines are sorted by group and name.
+func (c *Client) GetSecurityReport(ctx context.Context, location string) (*SecurityReport, error) {
+	groups, err := c.groups.List(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SecurityReport{Location: location, GeneratedAt: time.Now().UTC(), VirtualMachines: []VirtualMachineSecurityStatus{}}
+	if groups == nil {
+		return report, nil
+	}
+	for _, group := range *groups {
+		if group.Name == nil {
+			continue
+		}
+		vms, err := c.vms.List(ctx, *group.Name)
+		if err != nil {
+			return nil, err
+		}
+		if vms == nil {
+			continue
+		}
+		for i := range *vms {
+			if (*vms)[i].Name == nil {
+				continue
+			}
+			report.VirtualMachines = append(report.VirtualMachines, getVirtualMachineSecurityStatus(*group.Name, &(*vms)[i]))
+		}
+	}
+
+	sort.SliceStable(report.VirtualMachines, func(i, j int) bool {
+		if report.VirtualMachines[i].Group != report.VirtualMachines[j].Group {
+			return report.VirtualMachines[i].Group < report.VirtualMachines[j].Group
+		}
+		return report.VirtualMachines[i].Name < report.VirtualMachines[j].Name
+	})
+	return report, nil
+}
+
+func getVirtualMachineSecurityStatus(group string, vm *compute.VirtualMachine) VirtualMachineSecurityStatus {
+	status := VirtualMachineSecurityStatus{Group: group, Name: *vm.Name}
+	if vm.VirtualMachineProperties == nil {
+		return status
+	}
+
+	if profile := vm.SecurityProfile; profile != nil {
+		status.SecurityType = profile.SecurityType
+		status.VTPM = profile.EnableTPM != nil && *profile.EnableTPM
+		status.SecureBoot = profile.UefiSettings != nil && profile.UefiSettings.SecureBootEnabled != nil && *profile.UefiSettings.SecureBootEnabled
+	}
+
+	if storageProfile := vm.StorageProfile; storageProfile != nil {
+		if osDisk := storageProfile.OsDisk; osDisk != nil {
+			status.OSDisk = &DiskSecurityStatus{Name: getDiskName(osDisk.Name, osDisk.Vhd)}
+			if osDisk.ManagedDisk != nil && osDisk.ManagedDisk.SecurityProfile != nil {
+				status.OSDisk.EncryptionType = osDisk.ManagedDisk.SecurityProfile.SecurityEncryptionType
+			}
+		}
+		if storageProfile.DataDisks != nil {
+			for _, disk := range *storageProfile.DataDisks {
+				status.DataDisks = append(status.DataDisks, DiskSecurityStatus{Name: getDiskName(disk.Name, disk.Vhd)})
+			}
+		}
+	}
+	return status
+}
+
+func getDiskName(name *string, vhd *compute.VirtualHardDisk) string {
+	if vhd != nil && vhd.URI != nil {
+		return *vhd.URI
+	}
+	if name != nil {
+		return *name
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package governance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getVirtualMachineSecurityStatus(t *testing.T) {
+	name, osDisk, dataDisk := "vm1", "vm1-os", "vm1-data"
+	enabled := true
+	vm := &compute.VirtualMachine{
+		Name: &name,
+		VirtualMachineProperties: &compute.VirtualMachineProperties{
+			SecurityProfile: &compute.SecurityProfile{
+				EnableTPM:    &enabled,
+				UefiSettings: &compute.UefiSettings{SecureBootEnabled: &enabled},
+				SecurityType: compute.ConfidentialVM,
+			},
+			StorageProfile: &compute.StorageProfile{
+				OsDisk: &compute.OSDisk{
+					Vhd: &compute.VirtualHardDisk{URI: &osDisk},
+					ManagedDisk: &compute.VirtualMachineManagedDiskParameters{
+						SecurityProfile: &compute.VMDiskSecurityProfile{SecurityEncryptionType: compute.NonPersistedTPM},
+					},
+				},
+				DataDisks: &[]compute.DataDisk{{Vhd: &compute.VirtualHardDisk{URI: &dataDisk}}},
+			},
+		},
+	}
+
+	status := getVirtualMachineSecurityStatus("group1", vm)
+	assert.True(t, status.SecureBoot)
+	assert.True(t, status.VTPM)
+	assert.Equal(t, compute.ConfidentialVM, status.SecurityType)
+	assert.Equal(t, "vm1-os", status.OSDisk.Name)
+	assert.Equal(t, compute.NonPersistedTPM, status.OSDisk.EncryptionType)
+	assert.Equal(t, []DiskSecurityStatus{{Name: "vm1-data"}}, status.DataDisks)
+
+	report := &SecurityReport{Location: "loc", VirtualMachines: []VirtualMachineSecurityStatus{status}}
+	out, err := report.JSON()
+	assert.NoError(t, err)
+	var decoded SecurityReport
+	assert.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, status, decoded.VirtualMachines[0])
+}
@@ -0,0 +1,321 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package governance
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourcetags"
+	"github.com/microsoft/moc-sdk-for-go/services/cloud"
+	"github.com/microsoft/moc-sdk-for-go/services/cloud/group"
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc-sdk-for-go/services/compute/virtualmachine"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/network/loadbalancer"
+	"github.com/microsoft/moc-sdk-for-go/services/network/networkinterface"
+	"github.com/microsoft/moc-sdk-for-go/services/network/virtualnetwork"
+	"github.com/microsoft/moc-sdk-for-go/services/storage"
+	"github.com/microsoft/moc-sdk-for-go/services/storage/container"
+	"github.com/microsoft/moc-sdk-for-go/services/storage/virtualharddisk"
+	"github.com/microsoft/moc/pkg/auth"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// TagChange describes the tags added to a resource missing required tags
+type TagChange struct {
+	// ResourceType - Type of the resource, as in pkg/conversion
+	ResourceType string
+	// Name - Name of the resource. Virtual hard disks are named {container}/{name}.
+	Name string
+	// Added - Required tags the resource was missing
+	Added map[string]*string
+	// Err - Error updating the resource, nil on success or in a dry run
+	Err error
+}
+
+// TagReport is the outcome of EnforceTags
+type TagReport struct {
+	// DryRun - Whether the changes were only computed and not applied
+	DryRun bool
+	// Changes - Resources missing required tags, sorted by type and name
+	Changes []TagChange
+}
+
+// Failed returns the changes that could not be applied
+func (r *TagReport) Failed() []TagChange {
+	failed := []TagChange{}
+	for _, change := range r.Changes {
+		if change.Err != nil {
+			failed = append(failed, change)
+		}
+	}
+	return failed
+}
+
+// Client applies governance policies to the resources of a group
+type Client struct {
+	groups        *group.GroupClient
+	vms           *virtualmachine.VirtualMachineClient
+	nics          *networkinterface.InterfaceClient
+	vnets         *virtualnetwork.VirtualNetworkClient
+	loadBalancers *loadbalancer.LoadBalancerClient
+	containers    *container.ContainerClient
+	vhds          *virtualharddisk.VirtualHardDiskClient
+}
+
+// NewGovernanceClient returns a client applying governance policies
+func NewGovernanceClient(cloudFQDN string, authorizer auth.Authorizer) (*Client, error) {
+	var (
+		c   = &Client{}
+		err error
+	)
+	if c.groups, err = group.NewGroupClient(cloudFQDN, authorizer); err != nil {
+		return nil, err
+	}
+	if c.vms, err = virtualmachine.NewVirtualMachineClient(cloudFQDN, authorizer); err != nil {
+		return nil, err
+	}
+	if c.nics, err = networkinterface.NewInterfaceClient(cloudFQDN, authorizer); err != nil {
+		return nil, err
+	}
+	if c.vnets, err = virtualnetwork.NewVirtualNetworkClient(cloudFQDN, authorizer); err != nil {
+		return nil, err
+	}
+	if c.loadBalancers, err = loadbalancer.NewLoadBalancerClient(cloudFQDN, authorizer); err != nil {
+		return nil, err
+	}
+	if c.containers, err = container.NewContainerClient(cloudFQDN, authorizer); err != nil {
+		return nil, err
+	}
+	if c.vhds, err = virtualharddisk.NewVirtualHardDiskClient(cloudFQDN, authorizer); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// taggedResource is a resource of any type whose tags can be read and updated
+type taggedResource struct {
+	resourceType string
+	name         string
+	tags         map[string]*string
+	update       func(ctx context.Context, missing map[string]*string) error
+}
+
+// EnforceTags adds the required tags missing from the group and from the virtual machines, network interfaces,
+// virtual networks, load balancers and virtual hard disks of the group. Tags already set on a resource are left
+// unchanged, even if their value differs. In a dry run the changes are reported and not applied. A resource failing
+// to be updated is reported in its change and does not stop the others. Availability sets are not tagged, since the
+// agent cannot update an existing availability set.
+func (c *Client) EnforceTags(ctx context.Context, location, groupName string, requiredTags map[string]*string, dryRun bool) (*TagReport, error) {
+	if len(groupName) == 0 {
+		return nil, errors.Wrapf(errors.InvalidGroup, "Group not specified")
+	}
+	if err := resourcetags.ValidateUserTags(requiredTags); err != nil {
+		return nil, err
+	}
+
+	resources, err := c.listTaggedResources(ctx, location, groupName)
+	if err != nil {
+		return nil, err
+	}
+	return enforceTags(ctx, resources, requiredTags, dryRun), nil
+}
+
+func enforceTags(ctx context.Context, resources []taggedResource, requiredTags map[string]*string, dryRun bool) *TagReport {
+	report := &TagReport{DryRun: dryRun, Changes: []TagChange{}}
+	for _, resource := range resources {
+		missing := getMissingTags(resource.tags, requiredTags)
+		if len(missing) == 0 {
+			continue
+		}
+		change := TagChange{ResourceType: resource.resourceType, Name: resource.name, Added: missing}
+		if !dryRun {
+			change.Err = resource.update(ctx, missing)
+		}
+		report.Changes = append(report.Changes, change)
+	}
+
+	sort.SliceStable(report.Changes, func(i, j int) bool {
+		if report.Changes[i].ResourceType != report.Changes[j].ResourceType {
+			return report.Changes[i].ResourceType < report.Changes[j].ResourceType
+		}
+		return report.Changes[i].Name < report.Changes[j].Name
+	})
+	return report
+}
+
+// getMissingTags returns the required tags that are not set in tags
+func getMissingTags(tags, requiredTags map[string]*string) map[string]*string {
+	missing := map[string]*string{}
+	for k, v := range requiredTags {
+		if _, ok := resourcetags.Get(tags, k); !ok {
+			missing[k] = v
+		}
+	}
+	return missing
+}
+
+func (c *Client) listTaggedResources(ctx context.Context, location, groupName string) ([]taggedResource, error) {
+	resources := []taggedResource{}
+
+	groups, err := c.groups.Get(ctx, location, groupName)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendTagged(resources, conversion.Group, groups,
+		func(g *cloud.Group) (*string, *map[string]*string) { return g.Name, &g.Tags },
+		func(ctx context.Context, g *cloud.Group) (*[]cloud.Group, error) {
+			return c.groups.Get(ctx, location, groupName)
+		},
+		func(ctx context.Context, g *cloud.Group) error {
+			_, err := c.groups.CreateOrUpdate(ctx, location, groupName, g)
+			return err
+		})
+
+	vms, err := c.vms.List(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendTagged(resources, conversion.VirtualMachine, vms,
+		func(vm *compute.VirtualMachine) (*string, *map[string]*string) { return vm.Name, &vm.Tags },
+		func(ctx context.Context, vm *compute.VirtualMachine) (*[]compute.VirtualMachine, error) {
+			return c.vms.Get(ctx, groupName, *vm.Name)
+		},
+		func(ctx context.Context, vm *compute.VirtualMachine) error {
+			_, err := c.vms.CreateOrUpdate(ctx, groupName, *vm.Name, vm)
+			return err
+		})
+
+	nics, err := c.nics.List(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendTagged(resources, conversion.NetworkInterface, nics,
+		func(nic *network.Interface) (*string, *map[string]*string) { return nic.Name, &nic.Tags },
+		func(ctx context.Context, nic *network.Interface) (*[]network.Interface, error) {
+			return c.nics.Get(ctx, groupName, *nic.Name)
+		},
+		func(ctx context.Context, nic *network.Interface) error {
+			_, err := c.nics.CreateOrUpdate(ctx, groupName, *nic.Name, nic)
+			return err
+		})
+
+	vnets, err := c.vnets.List(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendTagged(resources, conversion.VirtualNetwork, vnets,
+		func(vnet *network.VirtualNetwork) (*string, *map[string]*string) { return vnet.Name, &vnet.Tags },
+		func(ctx context.Context, vnet *network.VirtualNetwork) (*[]network.VirtualNetwork, error) {
+			return c.vnets.Get(ctx, groupName, *vnet.Name)
+		},
+		func(ctx context.Context, vnet *network.VirtualNetwork) error {
+			_, err := c.vnets.CreateOrUpdate(ctx, groupName, *vnet.Name, vnet)
+			return err
+		})
+
+	lbs, err := c.loadBalancers.List(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendTagged(resources, conversion.LoadBalancer, lbs,
+		func(lb *network.LoadBalancer) (*string, *map[string]*string) { return lb.Name, &lb.Tags },
+		func(ctx context.Context, lb *network.LoadBalancer) (*[]network.LoadBalancer, error) {
+			return c.loadBalancers.Get(ctx, groupName, *lb.Name)
+		},
+		func(ctx context.Context, lb *network.LoadBalancer) error {
+			_, err := c.loadBalancers.CreateOrUpdate(ctx, groupName, *lb.Name, lb)
+			return err
+		})
+
+	containers, err := c.containers.List(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	if containers != nil {
+		for _, ct := range *containers {
+			if ct.Name == nil {
+				continue
+			}
+			containerName := *ct.Name
+			vhds, err := c.vhds.List(ctx, groupName, containerName)
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			}
+			resources = appendTagged(resources, conversion.VirtualHardDisk, vhds,
+				func(vhd *storage.VirtualHardDisk) (*string, *map[string]*string) {
+					if vhd.Name == nil {
+						return nil, &vhd.Tags
+					}
+					name := containerName + "/" + *vhd.Name
+					return &name, &vhd.Tags
+				},
+				func(ctx context.Context, vhd *storage.VirtualHardDisk) (*[]storage.VirtualHardDisk, error) {
+					return c.vhds.Get(ctx, groupName, containerName, *vhd.Name)
+				},
+				func(ctx context.Context, vhd *storage.VirtualHardDisk) error {
+					_, err := c.vhds.CreateOrUpdate(ctx, groupName, containerName, *vhd.Name, vhd)
+					return err
+				})
+		}
+	}
+
+	return resources, nil
+}
+
+// appendTagged appends the named resources to tagged. A resource is updated by adding the missing tags and passing it
+// to put, reading it again with get and retrying on a version conflict.
+func appendTagged[T any](tagged []taggedResource, resourceType string, resources *[]T, fields func(*T) (*string, *map[string]*string), get func(context.Context, *T) (*[]T, error), put func(context.Context, *T) error) []taggedResource {
+	if resources == nil {
+		return tagged
+	}
+	for i := range *resources {
+		resource := &(*resources)[i]
+		name, tags := fields(resource)
+		if name == nil {
+			continue
+		}
+		resourceName := *name
+		tagged = append(tagged, taggedResource{
+			resourceType: resourceType,
+			name:         resourceName,
+			tags:         *tags,
+			update: func(ctx context.Context, missing map[string]*string) error {
+				return updateTags(ctx, resourceType, resourceName, resource, missing, fields, get, put)
+			},
+		})
+	}
+	return tagged
+}
+
+// updateTags adds the tags still missing from the resource and writes it. On an invalid version the resource is read
+// again, so that changes made since it was listed are kept.
+func updateTags[T any](ctx context.Context, resourceType, name string, resource *T, missing map[string]*string, fields func(*T) (*string, *map[string]*string), get func(context.Context, *T) (*[]T, error), put func(context.Context, *T) error) error {
+	for {
+		_, tags := fields(resource)
+		*tags = resourcetags.Merge(*tags, getMissingTags(*tags, missing))
+
+		err := put(ctx, resource)
+		if err == nil {
+			return nil
+		}
+		// Retry only on invalid version
+		if !errors.IsInvalidVersion(err) {
+			return err
+		}
+		time.Sleep(100 * time.Millisecond)
+
+		resources, err := get(ctx, resource)
+		if err != nil {
+			return err
+		}
+		if resources == nil || len(*resources) == 0 {
+			return errors.Wrapf(errors.NotFound, "%s [%s] not found", resourceType, name)
+		}
+		resource = &(*resources)[0]
+	}
+}
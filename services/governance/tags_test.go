@@ -0,0 +1,127 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package governance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeVirtualMachines stores virtual machines by name and fails the first conflicts puts with an invalid version,
+// as if another writer had updated the virtual machine
+type fakeVirtualMachines struct {
+	vms       map[string]compute.VirtualMachine
+	conflicts int
+	puts      int
+}
+
+func (f *fakeVirtualMachines) get(ctx context.Context, vm *compute.VirtualMachine) (*[]compute.VirtualMachine, error) {
+	stored, ok := f.vms[*vm.Name]
+	if !ok {
+		return &[]compute.VirtualMachine{}, nil
+	}
+	stored.Tags = copyTags(stored.Tags)
+	return &[]compute.VirtualMachine{stored}, nil
+}
+
+func (f *fakeVirtualMachines) put(ctx context.Context, vm *compute.VirtualMachine) error {
+	f.puts++
+	if f.conflicts > 0 {
+		f.conflicts--
+		if stored, ok := f.vms[*vm.Name]; ok {
+			stored.Tags = copyTags(stored.Tags)
+			stored.Tags["owner"] = strPtr("someone")
+			f.vms[*vm.Name] = stored
+		}
+		return errors.Wrapf(errors.InvalidVersion, "Virtual Machine [%s] was updated", *vm.Name)
+	}
+	f.vms[*vm.Name] = *vm
+	return nil
+}
+
+func (f *fakeVirtualMachines) list() *[]compute.VirtualMachine {
+	vms := []compute.VirtualMachine{}
+	for _, vm := range f.vms {
+		vm.Tags = copyTags(vm.Tags)
+		vms = append(vms, vm)
+	}
+	return &vms
+}
+
+func (f *fakeVirtualMachines) tagged() []taggedResource {
+	return appendTagged(nil, conversion.VirtualMachine, f.list(),
+		func(vm *compute.VirtualMachine) (*string, *map[string]*string) { return vm.Name, &vm.Tags },
+		f.get, f.put)
+}
+
+func copyTags(tags map[string]*string) map[string]*string {
+	copied := map[string]*string{}
+	for k, v := range tags {
+		copied[k] = v
+	}
+	return copied
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func Test_enforceTags(t *testing.T) {
+	fake := &fakeVirtualMachines{vms: map[string]compute.VirtualMachine{
+		"vm1": {Name: strPtr("vm1"), Tags: map[string]*string{"Env": strPtr("dev")}},
+		"vm2": {Name: strPtr("vm2"), Tags: map[string]*string{}},
+	}}
+	required := map[string]*string{"env": strPtr("prod"), "owner": strPtr("team")}
+
+	report := enforceTags(context.Background(), fake.tagged(), required, true)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 2, len(report.Changes))
+	assert.Equal(t, "vm1", report.Changes[0].Name)
+	assert.Equal(t, map[string]*string{"owner": required["owner"]}, report.Changes[0].Added)
+	assert.Equal(t, 0, fake.puts)
+
+	report = enforceTags(context.Background(), fake.tagged(), required, false)
+	assert.Equal(t, 0, len(report.Failed()))
+	assert.Equal(t, "dev", *fake.vms["vm1"].Tags["Env"])
+	assert.Equal(t, "team", *fake.vms["vm1"].Tags["owner"])
+	assert.Equal(t, "prod", *fake.vms["vm2"].Tags["env"])
+
+	// Nothing is missing anymore
+	report = enforceTags(context.Background(), fake.tagged(), required, false)
+	assert.Equal(t, 0, len(report.Changes))
+}
+
+func Test_enforceTagsRetriesInvalidVersion(t *testing.T) {
+	fake := &fakeVirtualMachines{vms: map[string]compute.VirtualMachine{
+		"vm1": {Name: strPtr("vm1"), Tags: map[string]*string{}},
+	}}
+	required := map[string]*string{"env": strPtr("prod"), "owner": strPtr("team")}
+	tagged := fake.tagged()
+	fake.conflicts = 1
+
+	report := enforceTags(context.Background(), tagged, required, false)
+	assert.Equal(t, 0, len(report.Failed()))
+	assert.Equal(t, 2, fake.puts)
+	// The tag set by the concurrent writer is kept
+	assert.Equal(t, "someone", *fake.vms["vm1"].Tags["owner"])
+	assert.Equal(t, "prod", *fake.vms["vm1"].Tags["env"])
+}
+
+func Test_enforceTagsReportsFailures(t *testing.T) {
+	fake := &fakeVirtualMachines{vms: map[string]compute.VirtualMachine{
+		"vm1": {Name: strPtr("vm1"), Tags: map[string]*string{}},
+	}}
+	tagged := fake.tagged()
+	delete(fake.vms, "vm1")
+	fake.conflicts = 1
+
+	report := enforceTags(context.Background(), tagged, map[string]*string{"env": strPtr("prod")}, false)
+	assert.Equal(t, 1, len(report.Failed()))
+	assert.True(t, errors.IsNotFound(report.Failed()[0].Err))
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package network
+
+import (
+	"net"
+	"strings"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// CheckAddressPrefixes checks that the additional address prefixes are valid CIDRs that overlap neither each other
+// nor any of the existing address prefixes. Existing prefixes that are not valid CIDRs are ignored.
+func CheckAddressPrefixes(existing, additional []string) error {
+	type prefix struct {
+		cidr  *net.IPNet
+		value string
+	}
+	prefixes := []prefix{}
+	for _, value := range existing {
+		if _, cidr, err := net.ParseCIDR(value); err == nil {
+			prefixes = append(prefixes, prefix{cidr, value})
+		}
+	}
+
+	for _, value := range additional {
+		_, cidr, err := net.ParseCIDR(value)
+		if err != nil {
+			return errors.Wrapf(errors.InvalidInput, "Invalid address prefix [%s]", value)
+		}
+		for _, p := range prefixes {
+			if cidr.Contains(p.cidr.IP) || p.cidr.Contains(cidr.IP) {
+				return errors.Wrapf(errors.InvalidInput, "Address prefix [%s] overlaps address prefix [%s]", value, p.value)
+			}
+		}
+		prefixes = append(prefixes, prefix{cidr, value})
+	}
+	return nil
+}
+
+// AddressPrefixSubnetName returns the name of the subnet that holds an address prefix added to a network
+func AddressPrefixSubnetName(networkName, addressPrefix string) string {
+	return networkName + "-" + strings.NewReplacer(".", "-", ":", "-", "/", "-").Replace(addressPrefix)
+}
@@ -0,0 +1,24 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CheckAddressPrefixes(t *testing.T) {
+	existing := []string{"10.0.0.0/24", "10.0.2.0/24", "invalid"}
+	assert.NoError(t, CheckAddressPrefixes(existing, []string{"10.0.1.0/24", "10.0.3.0/25"}))
+	assert.NoError(t, CheckAddressPrefixes(nil, []string{"fd00::/64"}))
+
+	assert.True(t, errors.IsInvalidInput(CheckAddressPrefixes(existing, []string{"10.0.0.128/25"})))
+	assert.True(t, errors.IsInvalidInput(CheckAddressPrefixes(existing, []string{"10.0.0.0/16"})))
+	assert.True(t, errors.IsInvalidInput(CheckAddressPrefixes(existing, []string{"10.0.4.0/24", "10.0.4.0/23"})))
+	assert.True(t, errors.IsInvalidInput(CheckAddressPrefixes(existing, []string{"10.0.4.0"})))
+
+	assert.Equal(t, "lnet1-10-0-1-0-24", AddressPrefixSubnetName("lnet1", "10.0.1.0/24"))
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package network
+
+import (
+	"net"
+	"strings"
+
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// ValidateDhcpOptions fails if the DNS servers are not distinct ip addresses or if the DNS suffix is not a domain name
+func ValidateDhcpOptions(options *DhcpOptions) error {
+	if options == nil {
+		return nil
+	}
+	if options.DNSServers != nil {
+		seen := map[string]bool{}
+		for _, server := range *options.DNSServers {
+			ip := net.ParseIP(server)
+			if ip == nil {
+				return errors.Wrapf(errors.InvalidInput, "DNS server [%s] is not an ip address", server)
+			}
+			if seen[ip.String()] {
+				return errors.Wrapf(errors.InvalidInput, "DNS server [%s] is specified more than once", server)
+			}
+			seen[ip.String()] = true
+		}
+	}
+	if options.DNSSuffix != nil && len(*options.DNSSuffix) > 0 && !IsDomainName(*options.DNSSuffix) {
+		return errors.Wrapf(errors.InvalidInput, "DNS suffix [%s] is not a valid domain name", *options.DNSSuffix)
+	}
+	return nil
+}
+
+// IsDomainName returns true if name is a valid DNS domain name, with an optional trailing dot
+func IsDomainName(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if len(name) == 0 || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateDhcpOptions(t *testing.T) {
+	servers := []string{"10.0.0.4", "fd00::4"}
+	suffix := "corp.contoso.com."
+	assert.NoError(t, ValidateDhcpOptions(&DhcpOptions{DNSServers: &servers, DNSSuffix: &suffix}))
+	assert.NoError(t, ValidateDhcpOptions(nil))
+
+	duplicates := []string{"10.0.0.4", "10.0.0.4"}
+	assert.True(t, errors.IsInvalidInput(ValidateDhcpOptions(&DhcpOptions{DNSServers: &duplicates})))
+	names := []string{"dns.contoso.com"}
+	assert.True(t, errors.IsInvalidInput(ValidateDhcpOptions(&DhcpOptions{DNSServers: &names})))
+	invalidSuffix := "-corp.contoso.com"
+	assert.True(t, errors.IsInvalidInput(ValidateDhcpOptions(&DhcpOptions{DNSSuffix: &invalidSuffix})))
+}
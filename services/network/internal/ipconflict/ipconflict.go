@@ -0,0 +1,347 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package ipconflict
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc/pkg/auth"
+	"github.com/microsoft/moc/pkg/errors"
+	wssdcloud "github.com/microsoft/moc/rpc/cloudagent/cloud"
+	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
+	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
+)
+
+// Resource types holding ip addresses
+const (
+	NetworkInterface = "networkInterfaces"
+	LoadBalancer     = "loadBalancers"
+)
+
+// Request is a static ip address requested by a resource on a network
+type Request struct {
+	IPAddress    string
+	ResourceType string
+	Name         string
+	// Network - Virtual network or subnet reference of the address, empty if not known
+	Network string
+}
+
+// Checker detects static ip addresses that are already in use by other resources on the same network, in any group
+// of the location of the group, and network interface addresses inside the ranges reserved by the vip pools of the
+// location
+type Checker struct {
+	interfaces    wssdcloudnetwork.NetworkInterfaceAgentClient
+	loadBalancers wssdcloudnetwork.LoadBalancerAgentClient
+	groups        wssdcloud.GroupAgentClient
+	vipPools      wssdcloudnetwork.VipPoolAgentClient
+}
+
+// NewChecker returns a checker using the network interface, load balancer, group and vip pool agents
+func NewChecker(cloudFQDN string, authorizer auth.Authorizer) (*Checker, error) {
+	interfaces, err := wssdcloudclient.GetNetworkInterfaceClient(&cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	loadBalancers, err := wssdcloudclient.GetLoadBalancerClient(&cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := wssdcloudclient.GetGroupClient(&cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	vipPools, err := wssdcloudclient.GetVipPoolClient(&cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &Checker{interfaces: interfaces, loadBalancers: loadBalancers, groups: groups, vipPools: vipPools}, nil
+}
+
+type usage struct {
+	resourceType string
+	group        string
+	name         string
+	id           string
+}
+
+// addresses holds the ip addresses in use, in canonical form, keyed by network and then by address. Addresses whose
+// network is not known are under the empty network.
+type addresses map[string]map[string]usage
+
+func (a addresses) add(network, ip string, u usage) {
+	key := networkKey(network)
+	if a[key] == nil {
+		a[key] = map[string]usage{}
+	}
+	a[key][ip] = u
+}
+
+// lookup returns the usage of the ip address on the network. An address whose network is not known, or a lookup
+// without a network, matches on any network.
+func (a addresses) lookup(network, ip string) (usage, bool) {
+	key := networkKey(network)
+	if len(key) == 0 {
+		for _, ips := range a {
+			if u, ok := ips[ip]; ok {
+				return u, true
+			}
+		}
+		return usage{}, false
+	}
+	if u, ok := a[key][ip]; ok {
+		return u, true
+	}
+	u, ok := a[""][ip]
+	return u, ok
+}
+
+// reservedRange is a range of ip addresses reserved by a vip pool for load balancer frontends
+type reservedRange struct {
+	start net.IP
+	end   net.IP
+	id    string
+}
+
+func (r reservedRange) contains(ip net.IP) bool {
+	ip = ip.To16()
+	return ip != nil && bytes.Compare(ip, r.start) >= 0 && bytes.Compare(ip, r.end) <= 0
+}
+
+// Check returns an AlreadyExists error naming the conflicting resource if any of the requested ip
+// addresses is used by another resource on the same network or requested twice, or if a network
+// interface requests an address reserved by a vip pool
+func (c *Checker) Check(ctx context.Context, group string, requests []Request) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	location, err := c.getLocation(ctx, group)
+	if err != nil {
+		return err
+	}
+	inUse, err := c.getIPAddressesInUse(ctx, group, location)
+	if err != nil {
+		return err
+	}
+	reserved, err := c.getReservedRanges(ctx, location)
+	if err != nil {
+		return err
+	}
+	return checkRequests(inUse, reserved, group, requests)
+}
+
+// InUse returns the ip addresses used on the network by network interfaces and load balancers, in canonical form.
+// Addresses whose network is not known are included.
+func (c *Checker) InUse(ctx context.Context, group, network string) (map[string]bool, error) {
+	location, err := c.getLocation(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	inUse, err := c.getIPAddressesInUse(ctx, group, location)
+	if err != nil {
+		return nil, err
+	}
+	ips := map[string]bool{}
+	for _, key := range []string{networkKey(network), ""} {
+		for ip := range inUse[key] {
+			ips[ip] = true
+		}
+	}
+	return ips, nil
+}
+
+func checkRequests(inUse addresses, reserved []reservedRange, group string, requests []Request) error {
+	requested := addresses{}
+	for _, r := range requests {
+		ip := normalize(r.IPAddress)
+		if len(ip) == 0 {
+			continue
+		}
+		if r.ResourceType == NetworkInterface {
+			for _, rr := range reserved {
+				if rr.contains(net.ParseIP(ip)) {
+					return errors.Wrapf(errors.AlreadyExists, "IP address [%s] requested by %s [%s] is in the range reserved by [%s]", r.IPAddress, r.ResourceType, r.Name, rr.id)
+				}
+			}
+		}
+		if u, ok := inUse.lookup(r.Network, ip); ok && !(u.resourceType == r.ResourceType && u.group == group && u.name == r.Name) {
+			return errors.Wrapf(errors.AlreadyExists, "IP address [%s] requested by %s [%s] is in use by [%s]", r.IPAddress, r.ResourceType, r.Name, u.resourceID())
+		}
+		if other, ok := requested.lookup(r.Network, ip); ok && !(other.resourceType == r.ResourceType && other.name == r.Name) {
+			return errors.Wrapf(errors.AlreadyExists, "IP address [%s] is requested by both %s [%s] and %s [%s]", r.IPAddress, other.resourceType, other.name, r.ResourceType, r.Name)
+		}
+		requested.add(r.Network, ip, usage{resourceType: r.ResourceType, group: group, name: r.Name})
+	}
+	return nil
+}
+
+// getIPAddressesInUse returns the addresses used in every group of the location, since a virtual network may be
+// shared by resources of several groups. Only the group is searched if the location is not known.
+func (c *Checker) getIPAddressesInUse(ctx context.Context, group, location string) (addresses, error) {
+	groups, err := c.getGroups(ctx, group, location)
+	if err != nil {
+		return nil, err
+	}
+
+	inUse := addresses{}
+	for _, g := range groups {
+		nicResponse, err := c.interfaces.Invoke(ctx, &wssdcloudnetwork.NetworkInterfaceRequest{
+			OperationType:     wssdcloudcommon.Operation_GET,
+			NetworkInterfaces: []*wssdcloudnetwork.NetworkInterface{{GroupName: g}},
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		for _, nic := range nicResponse.GetNetworkInterfaces() {
+			for _, ipConfig := range nic.GetIpConfigurations() {
+				if ip := normalize(ipConfig.GetIpaddress()); len(ip) > 0 {
+					inUse.add(ipConfig.GetSubnetid(), ip, usage{resourceType: NetworkInterface, group: g, name: nic.GetName(), id: nic.GetId()})
+				}
+			}
+		}
+
+		lbResponse, err := c.loadBalancers.Invoke(ctx, &wssdcloudnetwork.LoadBalancerRequest{
+			OperationType: wssdcloudcommon.Operation_GET,
+			LoadBalancers: []*wssdcloudnetwork.LoadBalancer{{GroupName: g}},
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		for _, lb := range lbResponse.GetLoadBalancers() {
+			if ip := normalize(lb.GetFrontendIP()); len(ip) > 0 {
+				inUse.add(lb.GetNetworkid(), ip, usage{resourceType: LoadBalancer, group: g, name: lb.GetName(), id: lb.GetId()})
+			}
+		}
+	}
+
+	return inUse, nil
+}
+
+// getLocation returns the location of the context, or else the location of the group as reported by the agent.
+// Returns an empty location if neither is known.
+func (c *Checker) getLocation(ctx context.Context, group string) (string, error) {
+	if location := moc.Location(ctx, ""); len(location) > 0 {
+		return location, nil
+	}
+	if c.groups == nil {
+		return "", nil
+	}
+	response, err := c.groups.Invoke(ctx, &wssdcloud.GroupRequest{
+		OperationType: wssdcloudcommon.Operation_GET,
+		Groups:        []*wssdcloud.Group{{Name: group}},
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return "", err
+	}
+	for _, g := range response.GetGroups() {
+		if strings.EqualFold(g.GetName(), group) {
+			return g.GetLocationName(), nil
+		}
+	}
+	return "", nil
+}
+
+// getGroups returns the names of the groups of the location, including group
+func (c *Checker) getGroups(ctx context.Context, group, location string) ([]string, error) {
+	if c.groups == nil || len(location) == 0 {
+		return []string{group}, nil
+	}
+	response, err := c.groups.Invoke(ctx, &wssdcloud.GroupRequest{
+		OperationType: wssdcloudcommon.Operation_GET,
+		Groups:        []*wssdcloud.Group{{LocationName: location}},
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	groups := []string{group}
+	for _, g := range response.GetGroups() {
+		if name := g.GetName(); len(name) > 0 && !strings.EqualFold(name, group) {
+			groups = append(groups, name)
+		}
+	}
+	return groups, nil
+}
+
+// getReservedRanges returns the ranges of the vip pools of the location. Vip pools given by prefix reserve the
+// whole prefix. No ranges are returned if the location is not known.
+func (c *Checker) getReservedRanges(ctx context.Context, location string) ([]reservedRange, error) {
+	if c.vipPools == nil || len(location) == 0 {
+		return nil, nil
+	}
+	response, err := c.vipPools.Invoke(ctx, &wssdcloudnetwork.VipPoolRequest{
+		OperationType: wssdcloudcommon.Operation_GET,
+		VipPools:      []*wssdcloudnetwork.VipPool{{LocationName: location}},
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	reserved := []reservedRange{}
+	for _, vp := range response.GetVipPools() {
+		id := vp.GetId()
+		if len(id) == 0 {
+			id = fmt.Sprintf("vippools/%s", vp.GetName())
+		}
+		if start, end, ok := getRange(vp); ok {
+			reserved = append(reserved, reservedRange{start: start, end: end, id: id})
+		}
+	}
+	return reserved, nil
+}
+
+// getRange returns the first and last addresses of the vip pool, from its prefix if set or else from its start and
+// end addresses
+func getRange(vp *wssdcloudnetwork.VipPool) (net.IP, net.IP, bool) {
+	if len(vp.GetCidr()) > 0 {
+		_, prefix, err := net.ParseCIDR(vp.GetCidr())
+		if err != nil {
+			return nil, nil, false
+		}
+		end := make(net.IP, len(prefix.IP))
+		for i := range prefix.IP {
+			end[i] = prefix.IP[i] | ^prefix.Mask[i]
+		}
+		return prefix.IP.To16(), end.To16(), true
+	}
+	start, end := net.ParseIP(vp.GetStartip()).To16(), net.ParseIP(vp.GetEndip()).To16()
+	if start == nil || end == nil {
+		return nil, nil, false
+	}
+	return start, end, true
+}
+
+func (u usage) resourceID() string {
+	if len(u.id) > 0 {
+		return u.id
+	}
+	return fmt.Sprintf("%s/%s", u.resourceType, u.name)
+}
+
+// networkKey returns the virtual network of a network reference, which is either the name of the virtual network or
+// an id containing virtualNetworks/<name>, so that references to the same network compare equal
+func networkKey(network string) string {
+	network = strings.ToLower(strings.Trim(network, "/"))
+	parts := strings.Split(network, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "virtualnetworks" {
+			return parts[i+1]
+		}
+	}
+	return network
+}
+
+// normalize returns the canonical form of the ip address so that equal addresses compare equal
+func normalize(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ip
+	}
+	return parsed.String()
+}
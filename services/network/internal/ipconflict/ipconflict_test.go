@@ -0,0 +1,171 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package ipconflict
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc/pkg/errors"
+	wssdcloud "github.com/microsoft/moc/rpc/cloudagent/cloud"
+	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+type fakeInterfaces struct {
+	wssdcloudnetwork.NetworkInterfaceAgentClient
+	nics map[string][]*wssdcloudnetwork.NetworkInterface
+}
+
+func (f *fakeInterfaces) Invoke(ctx context.Context, req *wssdcloudnetwork.NetworkInterfaceRequest, opts ...grpc.CallOption) (*wssdcloudnetwork.NetworkInterfaceResponse, error) {
+	return &wssdcloudnetwork.NetworkInterfaceResponse{NetworkInterfaces: f.nics[req.NetworkInterfaces[0].GroupName]}, nil
+}
+
+type fakeLoadBalancers struct {
+	wssdcloudnetwork.LoadBalancerAgentClient
+	lbs map[string][]*wssdcloudnetwork.LoadBalancer
+}
+
+func (f *fakeLoadBalancers) Invoke(ctx context.Context, req *wssdcloudnetwork.LoadBalancerRequest, opts ...grpc.CallOption) (*wssdcloudnetwork.LoadBalancerResponse, error) {
+	return &wssdcloudnetwork.LoadBalancerResponse{LoadBalancers: f.lbs[req.LoadBalancers[0].GroupName]}, nil
+}
+
+// fakeGroups holds the location of each group
+type fakeGroups struct {
+	wssdcloud.GroupAgentClient
+	groups map[string]string
+}
+
+func (f *fakeGroups) Invoke(ctx context.Context, req *wssdcloud.GroupRequest, opts ...grpc.CallOption) (*wssdcloud.GroupResponse, error) {
+	response := &wssdcloud.GroupResponse{}
+	for name, location := range f.groups {
+		if (len(req.Groups[0].Name) == 0 || req.Groups[0].Name == name) && (len(req.Groups[0].LocationName) == 0 || req.Groups[0].LocationName == location) {
+			response.Groups = append(response.Groups, &wssdcloud.Group{Name: name, LocationName: location})
+		}
+	}
+	return response, nil
+}
+
+type fakeVipPools struct {
+	wssdcloudnetwork.VipPoolAgentClient
+	vipPools map[string][]*wssdcloudnetwork.VipPool
+}
+
+func (f *fakeVipPools) Invoke(ctx context.Context, req *wssdcloudnetwork.VipPoolRequest, opts ...grpc.CallOption) (*wssdcloudnetwork.VipPoolResponse, error) {
+	return &wssdcloudnetwork.VipPoolResponse{VipPools: f.vipPools[req.VipPools[0].LocationName]}, nil
+}
+
+func Test_checkRequests(t *testing.T) {
+	inUse := addresses{}
+	inUse.add("vnet1", "10.0.0.4", usage{resourceType: NetworkInterface, group: "group1", name: "nic1", id: "/networkInterfaces/nic1"})
+	inUse.add("/virtualNetworks/vnet1/subnets/subnet1", "10.0.0.5", usage{resourceType: LoadBalancer, group: "group1", name: "lb1"})
+	inUse.add("", "10.0.0.6", usage{resourceType: LoadBalancer, group: "group1", name: "lb2"})
+
+	// The resource already holding the address may keep it
+	assert.NoError(t, checkRequests(inUse, nil, "group1", []Request{{IPAddress: "10.0.0.4", ResourceType: NetworkInterface, Name: "nic1", Network: "vnet1"}}))
+	assert.NoError(t, checkRequests(inUse, nil, "group1", []Request{{IPAddress: "", ResourceType: NetworkInterface, Name: "nic2", Network: "vnet1"}}))
+
+	// A resource of the same name in another group may not
+	err := checkRequests(inUse, nil, "group2", []Request{{IPAddress: "10.0.0.4", ResourceType: NetworkInterface, Name: "nic1", Network: "vnet1"}})
+	assert.True(t, errors.IsAlreadyExists(err))
+
+	err = checkRequests(inUse, nil, "group1", []Request{{IPAddress: "10.0.0.4", ResourceType: LoadBalancer, Name: "lb2", Network: "/virtualNetworks/vnet1/subnets/subnet1"}})
+	assert.True(t, errors.IsAlreadyExists(err))
+	assert.Contains(t, err.Error(), "/networkInterfaces/nic1")
+
+	err = checkRequests(inUse, nil, "group1", []Request{{IPAddress: "10.0.0.5", ResourceType: NetworkInterface, Name: "nic2", Network: "VNET1"}})
+	assert.Contains(t, err.Error(), "loadBalancers/lb1")
+
+	// The same address may be used on another network
+	assert.NoError(t, checkRequests(inUse, nil, "group1", []Request{{IPAddress: "10.0.0.4", ResourceType: NetworkInterface, Name: "nic2", Network: "vnet2"}}))
+
+	// Addresses without a known network conflict on any network
+	err = checkRequests(inUse, nil, "group1", []Request{{IPAddress: "10.0.0.6", ResourceType: NetworkInterface, Name: "nic2", Network: "vnet2"}})
+	assert.True(t, errors.IsAlreadyExists(err))
+	err = checkRequests(inUse, nil, "group1", []Request{{IPAddress: "10.0.0.4", ResourceType: LoadBalancer, Name: "lb3"}})
+	assert.True(t, errors.IsAlreadyExists(err))
+
+	err = checkRequests(inUse, nil, "group1", []Request{
+		{IPAddress: "10.0.0.9", ResourceType: NetworkInterface, Name: "nic2", Network: "vnet1"},
+		{IPAddress: "10.0.0.9", ResourceType: NetworkInterface, Name: "nic3", Network: "vnet1"},
+	})
+	assert.True(t, errors.IsAlreadyExists(err))
+	assert.NoError(t, checkRequests(inUse, nil, "group1", []Request{
+		{IPAddress: "10.0.0.9", ResourceType: NetworkInterface, Name: "nic2", Network: "vnet1"},
+		{IPAddress: "10.0.0.9", ResourceType: NetworkInterface, Name: "nic3", Network: "vnet2"},
+	}))
+}
+
+func Test_checkRequestsReservedRanges(t *testing.T) {
+	reserved := []reservedRange{}
+	for _, vp := range []*wssdcloudnetwork.VipPool{
+		{Name: "vippool1", Startip: "10.0.1.10", Endip: "10.0.1.20"},
+		{Name: "vippool2", Cidr: "10.0.2.0/28"},
+		{Name: "vippool3", Cidr: "invalid"},
+	} {
+		if start, end, ok := getRange(vp); ok {
+			reserved = append(reserved, reservedRange{start: start, end: end, id: vp.Name})
+		}
+	}
+	assert.Equal(t, 2, len(reserved))
+
+	for _, ip := range []string{"10.0.1.10", "10.0.1.15", "10.0.1.20", "10.0.2.0", "10.0.2.15"} {
+		err := checkRequests(addresses{}, reserved, "group1", []Request{{IPAddress: ip, ResourceType: NetworkInterface, Name: "nic1", Network: "vnet1"}})
+		assert.True(t, errors.IsAlreadyExists(err), ip)
+	}
+	err := checkRequests(addresses{}, reserved, "group1", []Request{{IPAddress: "10.0.1.12", ResourceType: NetworkInterface, Name: "nic1"}})
+	assert.Contains(t, err.Error(), "vippool1")
+
+	for _, ip := range []string{"10.0.1.9", "10.0.1.21", "10.0.2.16", "fd00::1"} {
+		assert.NoError(t, checkRequests(addresses{}, reserved, "group1", []Request{{IPAddress: ip, ResourceType: NetworkInterface, Name: "nic1", Network: "vnet1"}}), ip)
+	}
+
+	// Load balancer frontends are allocated from the vip pools
+	assert.NoError(t, checkRequests(addresses{}, reserved, "group1", []Request{{IPAddress: "10.0.1.15", ResourceType: LoadBalancer, Name: "lb1", Network: "vnet1"}}))
+}
+
+func Test_Check(t *testing.T) {
+	checker := &Checker{
+		interfaces: &fakeInterfaces{nics: map[string][]*wssdcloudnetwork.NetworkInterface{
+			"group2": {{
+				Name:             "nic1",
+				IpConfigurations: []*wssdcloudnetwork.IpConfiguration{{Ipaddress: "10.0.0.4", Subnetid: "vnet1"}},
+			}},
+		}},
+		loadBalancers: &fakeLoadBalancers{lbs: map[string][]*wssdcloudnetwork.LoadBalancer{
+			"group1": {{Name: "lb1", FrontendIP: "10.0.0.5", Networkid: "/virtualNetworks/vnet1/subnets/subnet1"}},
+		}},
+		groups: &fakeGroups{groups: map[string]string{"group1": "location1", "group2": "location1", "group3": "location2"}},
+		vipPools: &fakeVipPools{vipPools: map[string][]*wssdcloudnetwork.VipPool{
+			"location1": {{Name: "vippool1", Id: "/vippools/vippool1", Startip: "10.0.1.10", Endip: "10.0.1.20"}},
+		}},
+	}
+	request := []Request{{IPAddress: "10.0.0.4", ResourceType: NetworkInterface, Name: "nic2", Network: "vnet1"}}
+
+	// The location of the group is used when the context has none, so the network interface of the other group of
+	// the location sharing the virtual network conflicts
+	err := checker.Check(context.Background(), "group1", request)
+	assert.True(t, errors.IsAlreadyExists(err))
+	assert.Contains(t, err.Error(), "nic1")
+
+	// The location of the context takes precedence
+	assert.NoError(t, checker.Check(moc.WithLocation(context.Background(), "location2"), "group1", request))
+
+	// A group without a known location is searched alone
+	assert.NoError(t, checker.Check(context.Background(), "group4", request))
+
+	err = checker.Check(context.Background(), "group1", []Request{{IPAddress: "10.0.1.15", ResourceType: NetworkInterface, Name: "nic2", Network: "vnet1"}})
+	assert.True(t, errors.IsAlreadyExists(err))
+	assert.Contains(t, err.Error(), "/vippools/vippool1")
+
+	inUse, err := checker.InUse(context.Background(), "group1", "vnet1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"10.0.0.4": true, "10.0.0.5": true}, inUse)
+
+	inUse, err = checker.InUse(context.Background(), "group1", "vnet2")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(inUse))
+}
@@ -0,0 +1,153 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package loadbalancer
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+var (
+	membersMux   sync.Mutex
+	membersLocks = map[string]*sync.Mutex{}
+)
+
+// AddBackendPoolMember adds the primary ip configuration of the network interface to the backend address pool of the
+// load balancer. Only the network interface is updated, so concurrent changes to the load balancer are not overwritten.
+// Adding a network interface that is already a member of the pool does not update it.
+func (c *LoadBalancerClient) AddBackendPoolMember(ctx context.Context, group, lbName, poolName, nicName string) (*network.Interface, error) {
+	if err := c.checkBackendAddressPool(ctx, group, lbName, poolName); err != nil {
+		return nil, err
+	}
+
+	return c.updateBackendPoolMember(ctx, group, nicName, func(ipConfigs []network.InterfaceIPConfiguration) bool {
+		for _, ipConfig := range ipConfigs {
+			if isBackendPoolMember(&ipConfig, poolName) {
+				return false
+			}
+		}
+		primary := &ipConfigs[0]
+		for i := range ipConfigs {
+			if ipConfigs[i].Primary != nil && *ipConfigs[i].Primary {
+				primary = &ipConfigs[i]
+				break
+			}
+		}
+		pools := []network.BackendAddressPool{}
+		if primary.LoadBalancerBackendAddressPools != nil {
+			pools = *primary.LoadBalancerBackendAddressPools
+		}
+		name := poolName
+		pools = append(pools, network.BackendAddressPool{Name: &name})
+		primary.LoadBalancerBackendAddressPools = &pools
+		return true
+	})
+}
+
+// RemoveBackendPoolMember removes the ip configurations of the network interface from the backend address pool of
+// the load balancer. Removing a network interface that is not a member of the pool does not update it.
+func (c *LoadBalancerClient) RemoveBackendPoolMember(ctx context.Context, group, lbName, poolName, nicName string) (*network.Interface, error) {
+	if err := c.checkBackendAddressPool(ctx, group, lbName, poolName); err != nil {
+		return nil, err
+	}
+
+	return c.updateBackendPoolMember(ctx, group, nicName, func(ipConfigs []network.InterfaceIPConfiguration) bool {
+		changed := false
+		for i := range ipConfigs {
+			if !isBackendPoolMember(&ipConfigs[i], poolName) {
+				continue
+			}
+			pools := []network.BackendAddressPool{}
+			for _, pool := range *ipConfigs[i].LoadBalancerBackendAddressPools {
+				if pool.Name == nil || !strings.EqualFold(*pool.Name, poolName) {
+					pools = append(pools, pool)
+				}
+			}
+			ipConfigs[i].LoadBalancerBackendAddressPools = &pools
+			changed = true
+		}
+		return changed
+	})
+}
+
+// checkBackendAddressPool returns a NotFound error if the load balancer has no backend address pool named poolName
+func (c *LoadBalancerClient) checkBackendAddressPool(ctx context.Context, group, lbName, poolName string) error {
+	lb, err := c.getLoadBalancer(ctx, group, lbName)
+	if err != nil {
+		return err
+	}
+	if lb.LoadBalancerPropertiesFormat == nil || !hasBackendAddressPool(lb.BackendAddressPools, poolName) {
+		return errors.Wrapf(errors.NotFound, "Backend address pool [%s] not found in load balancer [%s]", poolName, lbName)
+	}
+	return nil
+}
+
+// updateBackendPoolMember applies update to the ip configurations of the network interface and saves it if update
+// returns true. Updates of the same network interface are serialized within the process.
+func (c *LoadBalancerClient) updateBackendPoolMember(ctx context.Context, group, nicName string, update func([]network.InterfaceIPConfiguration) bool) (*network.Interface, error) {
+	lock := getMemberLock(group, nicName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	nics, err := c.nics.Get(ctx, group, nicName)
+	if err != nil {
+		return nil, err
+	}
+	if nics == nil || len(*nics) == 0 {
+		return nil, errors.Wrapf(errors.NotFound, "Network Interface [%s] not found", nicName)
+	}
+	nic := (*nics)[0]
+	if nic.InterfacePropertiesFormat == nil || nic.IPConfigurations == nil || len(*nic.IPConfigurations) == 0 {
+		return nil, errors.Wrapf(errors.InvalidConfiguration, "Network Interface [%s] has no ip configurations", nicName)
+	}
+
+	if !update(*nic.IPConfigurations) {
+		return &nic, nil
+	}
+	return c.nics.CreateOrUpdate(ctx, group, nicName, &nic)
+}
+
+func isBackendPoolMember(ipConfig *network.InterfaceIPConfiguration, poolName string) bool {
+	if ipConfig.InterfaceIPConfigurationPropertiesFormat == nil || ipConfig.LoadBalancerBackendAddressPools == nil {
+		return false
+	}
+	for _, pool := range *ipConfig.LoadBalancerBackendAddressPools {
+		if pool.Name != nil && strings.EqualFold(*pool.Name, poolName) {
+			return true
+		}
+	}
+	return false
+}
+
+func getMemberLock(group, nicName string) *sync.Mutex {
+	membersMux.Lock()
+	defer membersMux.Unlock()
+	key := group + "/" + nicName
+	lock, ok := membersLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		membersLocks[key] = lock
+	}
+	return lock
+}
+
+// hasBackendAddressPool returns true if ref identifies one of the pools, either by id, by name or by an id ending in its name
+func hasBackendAddressPool(pools *[]network.BackendAddressPool, ref string) bool {
+	if pools == nil {
+		return false
+	}
+	for _, pool := range *pools {
+		if pool.ID != nil && strings.EqualFold(*pool.ID, ref) {
+			return true
+		}
+		if pool.Name != nil && (strings.EqualFold(*pool.Name, ref) || strings.HasSuffix(strings.ToLower(ref), "/backendaddresspools/"+strings.ToLower(*pool.Name))) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package loadbalancer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeLoadBalancerService struct {
+	Service
+	lbs []network.LoadBalancer
+}
+
+func (f *fakeLoadBalancerService) Get(ctx context.Context, group, name string) (*[]network.LoadBalancer, error) {
+	lbs := []network.LoadBalancer{}
+	for _, lb := range f.lbs {
+		if len(name) == 0 || strings.EqualFold(*lb.Name, name) {
+			lbs = append(lbs, lb)
+		}
+	}
+	return &lbs, nil
+}
+
+func Test_RemoveBackendPoolMemberChecksLoadBalancer(t *testing.T) {
+	getLB := func(name, pool string) network.LoadBalancer {
+		return network.LoadBalancer{
+			Name: strPtr(name),
+			LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+				BackendAddressPools: &[]network.BackendAddressPool{{Name: strPtr(pool)}},
+			},
+		}
+	}
+	c := &LoadBalancerClient{internal: &fakeLoadBalancerService{lbs: []network.LoadBalancer{getLB("lb1", "pool1"), getLB("lb2", "pool2")}}}
+
+	// The pool is looked up in the named load balancer only, not in the first one of the group that has it
+	_, err := c.RemoveBackendPoolMember(context.Background(), "group", "lb1", "pool2", "nic1")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = c.AddBackendPoolMember(context.Background(), "group", "lb1", "pool2", "nic1")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = c.RemoveBackendPoolMember(context.Background(), "group", "lb3", "pool1", "nic1")
+	assert.True(t, errors.IsNotFound(err))
+}
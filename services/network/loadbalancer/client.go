@@ -6,8 +6,17 @@ package loadbalancer
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/network/internal/ipconflict"
+	"github.com/microsoft/moc-sdk-for-go/services/network/networkinterface"
 	"github.com/microsoft/moc/pkg/auth"
+	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
 )
 
 // Service interface
@@ -21,7 +30,9 @@ type Service interface {
 // LoadBalancerClient structure
 type LoadBalancerClient struct {
 	network.BaseClient
-	internal Service
+	internal  Service
+	ipchecker *ipconflict.Checker
+	nics      *networkinterface.InterfaceClient
 }
 
 // NewLoadBalancerClient method returns new client
@@ -31,26 +42,132 @@ func NewLoadBalancerClient(cloudFQDN string, authorizer auth.Authorizer) (*LoadB
 		return nil, err
 	}
 
-	return &LoadBalancerClient{internal: c}, nil
+	ipchecker, err := ipconflict.NewChecker(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	nics, err := networkinterface.NewInterfaceClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoadBalancerClient{internal: c, ipchecker: ipchecker, nics: nics}, nil
 }
 
 // Get methods invokes the client Get method
 func (c *LoadBalancerClient) Get(ctx context.Context, group, name string) (*[]network.LoadBalancer, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *LoadBalancerClient) List(ctx context.Context, group string, opts ...list.Options) (*[]network.LoadBalancer, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *LoadBalancerClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]network.LoadBalancer] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]network.LoadBalancer, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *LoadBalancerClient) NewLister(group string) *list.Lister[network.LoadBalancer] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]network.LoadBalancer, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *LoadBalancerClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]network.LoadBalancer, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/LoadBalancer/{name}, see resourceid.ID
+func (c *LoadBalancerClient) GetByID(ctx context.Context, id string) (*network.LoadBalancer, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.LoadBalancer, c.Get)
+}
+
 // Ensure methods invokes create or update on the client
 func (c *LoadBalancerClient) CreateOrUpdate(ctx context.Context, group, name string, lb *network.LoadBalancer) (*network.LoadBalancer, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, lb)
 }
 
 // Delete methods invokes delete of the network resource
 func (c *LoadBalancerClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
 
 // Prechecks whether the system is able to create specified loadBalancers.
 // Returns true if it is possible; or false with reason in error message if not.
+// Rules sharing a frontend port and protocol are reported in a PortConflictError before the agent is called.
 func (c *LoadBalancerClient) Precheck(ctx context.Context, group string, loadBalancers []*network.LoadBalancer) (bool, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckDuplicateNames(loadBalancers); err != nil {
+		return false, err
+	}
+	conflicts := []PortConflict{}
+	for _, lb := range loadBalancers {
+		conflicts = append(conflicts, GetPortConflicts(lb)...)
+	}
+	if len(conflicts) > 0 {
+		return false, &PortConflictError{Conflicts: conflicts}
+	}
+	if err := c.precheckIPConflicts(ctx, group, loadBalancers); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, group, loadBalancers)
 }
+
+// precheckIPConflicts fails if a static ip requested by the loadBalancers is already used by another resource on the same network
+func (c *LoadBalancerClient) precheckIPConflicts(ctx context.Context, group string, loadBalancers []*network.LoadBalancer) error {
+	if c.ipchecker == nil {
+		return nil
+	}
+	requests := []ipconflict.Request{}
+	for _, lb := range loadBalancers {
+		if lb == nil {
+			continue
+		}
+		wssdLB, err := getWssdLoadBalancer(lb, group)
+		if err != nil {
+			return err
+		}
+		requests = append(requests, ipconflict.Request{IPAddress: wssdLB.FrontendIP, ResourceType: ipconflict.LoadBalancer, Name: wssdLB.Name, Network: wssdLB.Networkid})
+	}
+
+	return c.ipchecker.Check(ctx, group, requests)
+}
+
+// Raw returns the underlying agent client, giving access to agent fields not yet
+// exposed by the sdk. Returns nil if the client is not backed by the agent.
+func (c *LoadBalancerClient) Raw() wssdcloudnetwork.LoadBalancerAgentClient {
+	if rc, ok := c.internal.(*client); ok {
+		return rc.LoadBalancerAgentClient
+	}
+	return nil
+}
+
+// ToProto converts a load balancer to the message sent to the agent
+func ToProto(lb *network.LoadBalancer, group string) (*wssdcloudnetwork.LoadBalancer, error) {
+	return getWssdLoadBalancer(lb, group)
+}
+
+// FromProto converts a load balancer message returned by the agent
+func FromProto(lb *wssdcloudnetwork.LoadBalancer) (*network.LoadBalancer, error) {
+	return getLoadBalancer(lb)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package loadbalancer
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+var (
+	natRulesMux   sync.Mutex
+	natRulesLocks = map[string]*sync.Mutex{}
+)
+
+// ListInboundNatRules returns the inbound NAT rules of the load balancer
+func (c *LoadBalancerClient) ListInboundNatRules(ctx context.Context, group, lbName string) (*[]network.InboundNatRule, error) {
+	lb, err := c.getLoadBalancer(ctx, group, lbName)
+	if err != nil {
+		return nil, err
+	}
+	rules := []network.InboundNatRule{}
+	if lb.LoadBalancerPropertiesFormat != nil && lb.InboundNatRules != nil {
+		rules = *lb.InboundNatRules
+	}
+	return &rules, nil
+}
+
+// GetInboundNatRule returns the inbound NAT rule of the load balancer
+func (c *LoadBalancerClient) GetInboundNatRule(ctx context.Context, group, lbName, name string) (*network.InboundNatRule, error) {
+	rules, err := c.ListInboundNatRules(ctx, group, lbName)
+	if err != nil {
+		return nil, err
+	}
+	if i := findInboundNatRule(*rules, name); i >= 0 {
+		return &(*rules)[i], nil
+	}
+	return nil, errors.Wrapf(errors.NotFound, "Inbound NAT rule [%s] not found in load balancer [%s]", name, lbName)
+}
+
+// CreateOrUpdateInboundNatRule adds the inbound NAT rule to the load balancer, or replaces the rule of the same name,
+// forwarding the frontend port of the rule to the backend port
+func (c *LoadBalancerClient) CreateOrUpdateInboundNatRule(ctx context.Context, group, lbName, name string, rule *network.InboundNatRule) (*network.InboundNatRule, error) {
+	if rule == nil || rule.InboundNatRulePropertiesFormat == nil {
+		return nil, errors.Wrapf(errors.InvalidConfiguration, "Missing Inbound NAT Rule Properties")
+	}
+	updated := *rule
+	updated.Name = &name
+
+	lb, err := c.updateInboundNatRules(ctx, group, lbName, func(rules []network.InboundNatRule) ([]network.InboundNatRule, error) {
+		if i := findInboundNatRule(rules, name); i >= 0 {
+			rules[i] = updated
+			return rules, nil
+		}
+		return append(rules, updated), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lb.LoadBalancerPropertiesFormat != nil && lb.InboundNatRules != nil {
+		if i := findInboundNatRule(*lb.InboundNatRules, name); i >= 0 {
+			return &(*lb.InboundNatRules)[i], nil
+		}
+	}
+	return &updated, nil
+}
+
+// DeleteInboundNatRule removes the inbound NAT rule from the load balancer
+func (c *LoadBalancerClient) DeleteInboundNatRule(ctx context.Context, group, lbName, name string) error {
+	_, err := c.updateInboundNatRules(ctx, group, lbName, func(rules []network.InboundNatRule) ([]network.InboundNatRule, error) {
+		i := findInboundNatRule(rules, name)
+		if i < 0 {
+			return nil, errors.Wrapf(errors.NotFound, "Inbound NAT rule [%s] not found in load balancer [%s]", name, lbName)
+		}
+		return append(rules[:i], rules[i+1:]...), nil
+	})
+	return err
+}
+
+// updateInboundNatRules applies update to the inbound NAT rules of the load balancer. Updates of the same load
+// balancer are serialized within the process.
+func (c *LoadBalancerClient) updateInboundNatRules(ctx context.Context, group, lbName string, update func([]network.InboundNatRule) ([]network.InboundNatRule, error)) (*network.LoadBalancer, error) {
+	lock := getInboundNatRulesLock(group, lbName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	lb, err := c.getLoadBalancer(ctx, group, lbName)
+	if err != nil {
+		return nil, err
+	}
+	if lb.LoadBalancerPropertiesFormat == nil {
+		lb.LoadBalancerPropertiesFormat = &network.LoadBalancerPropertiesFormat{}
+	}
+
+	current := []network.InboundNatRule{}
+	if lb.InboundNatRules != nil {
+		current = append(current, *lb.InboundNatRules...)
+	}
+	rules, err := update(current)
+	if err != nil {
+		return nil, err
+	}
+	lb.InboundNatRules = &rules
+
+	return c.CreateOrUpdate(ctx, group, lbName, lb)
+}
+
+func (c *LoadBalancerClient) getLoadBalancer(ctx context.Context, group, name string) (*network.LoadBalancer, error) {
+	lbs, err := c.Get(ctx, group, name)
+	if err != nil {
+		return nil, err
+	}
+	if lbs == nil || len(*lbs) == 0 {
+		return nil, errors.Wrapf(errors.NotFound, "Load Balancer [%s] not found", name)
+	}
+	return &(*lbs)[0], nil
+}
+
+func getInboundNatRulesLock(group, name string) *sync.Mutex {
+	natRulesMux.Lock()
+	defer natRulesMux.Unlock()
+	key := group + "/" + name
+	lock, ok := natRulesLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		natRulesLocks[key] = lock
+	}
+	return lock
+}
+
+func findInboundNatRule(rules []network.InboundNatRule, name string) int {
+	for i, rule := range rules {
+		if rule.Name != nil && strings.EqualFold(*rule.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,20 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package loadbalancer
+
+import (
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// validateOutboundRules checks the outbound rules of the load balancer. The agent load balancer has no outbound rule
+// settings and always translates outbound flows to its frontend ip, so outbound rules are rejected instead of being
+// silently dropped.
+func validateOutboundRules(lb *network.LoadBalancer) error {
+	lbp := lb.LoadBalancerPropertiesFormat
+	if lbp.OutboundRules != nil && len(*lbp.OutboundRules) > 0 {
+		return errors.Wrapf(errors.NotSupported, "Outbound rules are not supported on load balancer [%s]", *lb.Name)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package loadbalancer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// PortConflict is a frontend port of a load balancer used by two of its rules with overlapping protocols
+type PortConflict struct {
+	LoadBalancer string
+	// Rule - The rule using the port, as "load balancing rule [name]" or "inbound NAT rule [name]". Rules
+	// without a name are named by their index.
+	Rule string
+	// ConflictingRule - The earlier rule already using the port
+	ConflictingRule string
+	FrontendPort    int32
+	Protocol        network.TransportProtocol
+}
+
+func (c PortConflict) String() string {
+	return fmt.Sprintf("%s frontend port %d/%s of load balancer [%s] is already used by %s", c.Rule, c.FrontendPort, c.Protocol, c.LoadBalancer, c.ConflictingRule)
+}
+
+// PortConflictError is returned when rules of a load balancer share a frontend port and protocol
+type PortConflictError struct {
+	Conflicts []PortConflict
+}
+
+func (e *PortConflictError) Error() string {
+	messages := []string{}
+	for _, c := range e.Conflicts {
+		messages = append(messages, c.String())
+	}
+	return fmt.Sprintf("%s: %s", errors.InvalidInput.Error(), strings.Join(messages, "; "))
+}
+
+// Cause allows errors.IsInvalidInput to match the error
+func (e *PortConflictError) Cause() error {
+	return errors.InvalidInput
+}
+
+// Unwrap allows errors.Is(err, errors.InvalidInput) to match the error
+func (e *PortConflictError) Unwrap() error {
+	return errors.InvalidInput
+}
+
+// IsPortConflict returns true if the error is a PortConflictError
+func IsPortConflict(err error) bool {
+	_, ok := err.(*PortConflictError)
+	return ok
+}
+
+type frontendPortUser struct {
+	rule     string
+	protocol network.TransportProtocol
+}
+
+// GetPortConflicts returns the frontend ports used by more than one load balancing or inbound NAT rule of the load
+// balancer with overlapping protocols. Protocol All overlaps TCP and UDP. HA ports rules and rules without a
+// frontend port are ignored.
+func GetPortConflicts(lb *network.LoadBalancer) []PortConflict {
+	conflicts := []PortConflict{}
+	if lb == nil || lb.LoadBalancerPropertiesFormat == nil {
+		return conflicts
+	}
+	lbName := ""
+	if lb.Name != nil {
+		lbName = *lb.Name
+	}
+
+	users := map[int32][]frontendPortUser{}
+	use := func(rule string, port *int32, protocol network.TransportProtocol) {
+		if port == nil {
+			return
+		}
+		for _, user := range users[*port] {
+			if protocolsOverlap(user.protocol, protocol) {
+				conflicts = append(conflicts, PortConflict{
+					LoadBalancer:    lbName,
+					Rule:            rule,
+					ConflictingRule: user.rule,
+					FrontendPort:    *port,
+					Protocol:        protocol,
+				})
+				return
+			}
+		}
+		users[*port] = append(users[*port], frontendPortUser{rule: rule, protocol: protocol})
+	}
+
+	if lb.LoadBalancingRules != nil {
+		for i := range *lb.LoadBalancingRules {
+			rule := &(*lb.LoadBalancingRules)[i]
+			if rule.LoadBalancingRulePropertiesFormat == nil || IsHAPortsRule(rule) {
+				continue
+			}
+			use(fmt.Sprintf("load balancing rule [%s]", getRuleName(rule.Name, i)), rule.FrontendPort, rule.Protocol)
+		}
+	}
+	if lb.InboundNatRules != nil {
+		for i := range *lb.InboundNatRules {
+			rule := &(*lb.InboundNatRules)[i]
+			if rule.InboundNatRulePropertiesFormat == nil {
+				continue
+			}
+			use(fmt.Sprintf("inbound NAT rule [%s]", getRuleName(rule.Name, i)), rule.FrontendPort, rule.Protocol)
+		}
+	}
+	return conflicts
+}
+
+func getRuleName(name *string, index int) string {
+	if name == nil || len(*name) == 0 {
+		return fmt.Sprintf("#%d", index)
+	}
+	return *name
+}
+
+// protocolsOverlap returns true if traffic of one protocol can match the other. An empty protocol is All.
+func protocolsOverlap(a, b network.TransportProtocol) bool {
+	isAll := func(p network.TransportProtocol) bool {
+		return len(p) == 0 || strings.EqualFold(string(p), string(network.TransportProtocolAll))
+	}
+	return isAll(a) || isAll(b) || strings.EqualFold(string(a), string(b))
+}
@@ -0,0 +1,28 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package loadbalancer
+
+import (
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// validateProbes checks the health probes of the load balancer and the probe references of its load balancing rules.
+// The agent load balancer has no probe settings, so probes are rejected instead of being silently dropped.
+func validateProbes(lb *network.LoadBalancer) error {
+	lbp := lb.LoadBalancerPropertiesFormat
+	if lbp.Probes != nil && len(*lbp.Probes) > 0 {
+		return errors.Wrapf(errors.NotSupported, "Health probes are not supported on load balancer [%s]", *lb.Name)
+	}
+
+	if lbp.LoadBalancingRules == nil {
+		return nil
+	}
+	for _, rule := range *lbp.LoadBalancingRules {
+		if rule.LoadBalancingRulePropertiesFormat != nil && rule.Probe != nil && rule.Probe.ID != nil {
+			return errors.Wrapf(errors.NotSupported, "Load balancing rule references probe [%s], health probes are not supported", *rule.Probe.ID)
+		}
+	}
+	return nil
+}
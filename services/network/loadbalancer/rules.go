@@ -0,0 +1,114 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package loadbalancer
+
+import (
+	"strings"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
+)
+
+// getWssdProtocol converts the protocol of a load balancing or inbound NAT rule. The protocol defaults to All.
+func getWssdProtocol(protocol network.TransportProtocol) (wssdcloudcommon.Protocol, error) {
+	switch {
+	case len(protocol) == 0, strings.EqualFold(string(protocol), string(network.TransportProtocolAll)):
+		return wssdcloudcommon.Protocol_All, nil
+	case strings.EqualFold(string(protocol), string(network.TransportProtocolTCP)):
+		return wssdcloudcommon.Protocol_Tcp, nil
+	case strings.EqualFold(string(protocol), string(network.TransportProtocolUDP)):
+		return wssdcloudcommon.Protocol_Udp, nil
+	default:
+		return wssdcloudcommon.Protocol_All, errors.Wrapf(errors.InvalidInput, "Unknown protocol %s specified, must be one of %s, %s or %s",
+			protocol, network.TransportProtocolTCP, network.TransportProtocolUDP, network.TransportProtocolAll)
+	}
+}
+
+// getProtocol converts the protocol of a load balancing or inbound NAT rule returned by the agent
+func getProtocol(protocol wssdcloudcommon.Protocol) (network.TransportProtocol, error) {
+	switch protocol {
+	case wssdcloudcommon.Protocol_All:
+		return network.TransportProtocolAll, nil
+	case wssdcloudcommon.Protocol_Tcp:
+		return network.TransportProtocolTCP, nil
+	case wssdcloudcommon.Protocol_Udp:
+		return network.TransportProtocolUDP, nil
+	default:
+		return network.TransportProtocolAll, errors.Wrapf(errors.InvalidInput, "Unknown protocol %s specified", protocol)
+	}
+}
+
+// validateLoadDistribution checks the load distribution of the load balancing rule. The agent load balancing rule has
+// no load distribution setting and always distributes by the 5-tuple, so session persistence is rejected instead of
+// being silently dropped.
+func validateLoadDistribution(rule *network.LoadBalancingRule) error {
+	distribution := rule.LoadDistribution
+	switch {
+	case len(distribution) == 0, strings.EqualFold(string(distribution), string(network.LoadDistributionDefault)):
+		return nil
+	case strings.EqualFold(string(distribution), string(network.LoadDistributionSourceIP)),
+		strings.EqualFold(string(distribution), string(network.LoadDistributionSourceIPProtocol)):
+		return errors.Wrapf(errors.NotSupported, "Load distribution %s is not supported, only %s is supported", distribution, network.LoadDistributionDefault)
+	default:
+		return errors.Wrapf(errors.InvalidInput, "Unknown load distribution %s specified", distribution)
+	}
+}
+
+// IsHAPortsRule returns true if the load balancing rule balances all ports of all protocols, with frontend and
+// backend ports 0 and protocol All
+func IsHAPortsRule(rule *network.LoadBalancingRule) bool {
+	return rule != nil && rule.LoadBalancingRulePropertiesFormat != nil &&
+		rule.FrontendPort != nil && *rule.FrontendPort == 0 &&
+		rule.BackendPort != nil && *rule.BackendPort == 0 &&
+		strings.EqualFold(string(rule.Protocol), string(network.TransportProtocolAll))
+}
+
+// validateRulePorts checks the ports of the load balancing rules. Port 0 is only allowed in an HA ports rule, which
+// must be the only HA ports rule of an internal load balancer.
+func validateRulePorts(lb *network.LoadBalancer, rules []network.LoadBalancingRule) error {
+	haPorts := 0
+	for i := range rules {
+		rule := &rules[i]
+		if IsHAPortsRule(rule) {
+			haPorts++
+			continue
+		}
+		if *rule.FrontendPort < 1 || *rule.FrontendPort > 65534 {
+			return errors.Wrapf(errors.InvalidInput, "Frontend port %d must be between 1 and 65534, or 0 with backend port 0 and protocol %s for HA ports", *rule.FrontendPort, network.TransportProtocolAll)
+		}
+		if *rule.BackendPort < 1 || *rule.BackendPort > 65535 {
+			return errors.Wrapf(errors.InvalidInput, "Backend port %d must be between 1 and 65535, or 0 with frontend port 0 and protocol %s for HA ports", *rule.BackendPort, network.TransportProtocolAll)
+		}
+	}
+	if haPorts == 0 {
+		return nil
+	}
+	if haPorts > 1 {
+		return errors.Wrapf(errors.InvalidInput, "Load balancer [%s] has %d HA ports rules, only one is allowed", *lb.Name, haPorts)
+	}
+	if !IsInternalLoadBalancer(lb) {
+		return errors.Wrapf(errors.InvalidInput, "HA ports rule requires load balancer [%s] to have an internal frontend", *lb.Name)
+	}
+	return nil
+}
+
+// validateFloatingIP checks the floating IP setting of the load balancing rule. The agent load balancer always
+// translates the frontend IP to the backend IP, so floating IP (direct server return) is rejected instead of being
+// silently dropped.
+func validateFloatingIP(enableFloatingIP *bool) error {
+	if enableFloatingIP != nil && *enableFloatingIP {
+		return errors.Wrapf(errors.NotSupported, "Floating IP is not supported on load balancer rules")
+	}
+	return nil
+}
+
+// validateTCPReset checks the TCP reset setting of a load balancing rule. The agent load balancer has no
+// idle timeout reset setting and silently drops idle flows, so TCP reset is rejected instead of being silently dropped.
+func validateTCPReset(enableTCPReset *bool) error {
+	if enableTCPReset != nil && *enableTCPReset {
+		return errors.Wrapf(errors.NotSupported, "TCP reset is not supported on load balancer rules")
+	}
+	return nil
+}
@@ -6,15 +6,18 @@ package loadbalancer
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	"github.com/microsoft/moc/pkg/tags"
 	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
 	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
 )
@@ -181,6 +184,7 @@ func (c *client) getLoadBalancersFromResponse(response *wssdcloudnetwork.LoadBal
 		networkLBs = append(networkLBs, *networkLB)
 	}
 
+	list.SortResponse(&networkLBs)
 	return &networkLBs, nil
 }
 
@@ -211,33 +215,30 @@ func getWssdLoadBalancer(networkLB *network.LoadBalancer, group string) (wssdClo
 		wssdCloudLB.LocationName = *networkLB.Location
 	}
 
-	if networkLB.Tags != nil {
-		wssdCloudLB.Tags = tags.MapToProto(networkLB.Tags)
-	}
+	wssdCloudLB.Tags = conversion.TagsToProto(conversion.LoadBalancer, networkLB.Tags)
 
 	if networkLB.LoadBalancerPropertiesFormat != nil {
 		lbp := networkLB.LoadBalancerPropertiesFormat
+		if err := validateProbes(networkLB); err != nil {
+			return nil, err
+		}
 		if lbp.BackendAddressPools != nil && len(*lbp.BackendAddressPools) > 0 {
 			bap := *lbp.BackendAddressPools
 			if bap[0].Name != nil {
 				wssdCloudLB.Backendpoolnames = append(wssdCloudLB.Backendpoolnames, *bap[0].Name)
 			}
 		}
+		// The agent load balancer has a single frontend, so only the first frontend ip configuration is sent and rules
+		// referencing another frontend are rejected
 		if lbp.FrontendIPConfigurations != nil && len(*lbp.FrontendIPConfigurations) > 0 {
 			fipc := *lbp.FrontendIPConfigurations
-			if fipc[0].FrontendIPConfigurationPropertiesFormat != nil {
-				fipcf := fipc[0].FrontendIPConfigurationPropertiesFormat
-				if fipcf.Subnet != nil {
-					subnet := fipcf.Subnet
-					if subnet.ID != nil {
-						wssdCloudLB.Networkid = *subnet.ID
-					}
-				}
-				if fipcf.IPAddress != nil {
-					wssdCloudLB.FrontendIP = *fipcf.IPAddress
-				}
+			if err := setWssdFrontendIPConfiguration(wssdCloudLB, fipc[0].FrontendIPConfigurationPropertiesFormat); err != nil {
+				return nil, err
 			}
 		}
+		if err := validateOutboundRules(networkLB); err != nil {
+			return nil, err
+		}
 		if lbp.LoadBalancingRules != nil && len(*lbp.LoadBalancingRules) > 0 {
 			rules := *lbp.LoadBalancingRules
 			for _, rule := range rules {
@@ -247,17 +248,22 @@ func getWssdLoadBalancer(networkLB *network.LoadBalancer, group string) (wssdClo
 				if rule.BackendPort == nil {
 					return nil, errors.Wrapf(errors.InvalidInput, "Backend port not specified")
 				}
+				if err := validateLoadDistribution(&rule); err != nil {
+					return nil, err
+				}
+				if err := validateFloatingIP(rule.EnableFloatingIP); err != nil {
+					return nil, err
+				}
+				if err := validateTCPReset(rule.EnableTCPReset); err != nil {
+					return nil, err
+				}
+				if err := checkFrontendIPConfigurationReference(lbp.FrontendIPConfigurations, rule.FrontendIPConfiguration, "Load balancing rule"); err != nil {
+					return nil, err
+				}
 
-				protocol := wssdcloudcommon.Protocol_All
-
-				if strings.EqualFold(string(rule.Protocol), string(network.TransportProtocolAll)) {
-					protocol = wssdcloudcommon.Protocol_All
-				} else if strings.EqualFold(string(rule.Protocol), string(network.TransportProtocolTCP)) {
-					protocol = wssdcloudcommon.Protocol_Tcp
-				} else if strings.EqualFold(string(rule.Protocol), string(network.TransportProtocolUDP)) {
-					protocol = wssdcloudcommon.Protocol_Udp
-				} else {
-					return nil, errors.Wrapf(errors.InvalidInput, "Unknown protocol %s specified", rule.Protocol)
+				protocol, err := getWssdProtocol(rule.Protocol)
+				if err != nil {
+					return nil, err
 				}
 
 				wssdCloudLBRule := &wssdcloudnetwork.LoadBalancingRule{
@@ -267,12 +273,163 @@ func getWssdLoadBalancer(networkLB *network.LoadBalancer, group string) (wssdClo
 				}
 				wssdCloudLB.Loadbalancingrules = append(wssdCloudLB.Loadbalancingrules, wssdCloudLBRule)
 			}
+			if err := validateRulePorts(networkLB, rules); err != nil {
+				return nil, err
+			}
+		}
+		if lbp.InboundNatRules != nil && len(*lbp.InboundNatRules) > 0 {
+			for _, rule := range *lbp.InboundNatRules {
+				if rule.Name == nil || len(*rule.Name) == 0 {
+					return nil, errors.Wrapf(errors.InvalidInput, "Missing Name for inbound NAT rule")
+				}
+				if rule.InboundNatRulePropertiesFormat == nil {
+					return nil, errors.Wrapf(errors.InvalidInput, "Missing properties for inbound NAT rule [%s]", *rule.Name)
+				}
+				if rule.FrontendPort == nil || *rule.FrontendPort < 1 || *rule.FrontendPort > 65534 {
+					return nil, errors.Wrapf(errors.InvalidInput, "Inbound NAT rule [%s] frontend port must be between 1 and 65534", *rule.Name)
+				}
+				if rule.BackendPort == nil || *rule.BackendPort < 1 || *rule.BackendPort > 65535 {
+					return nil, errors.Wrapf(errors.InvalidInput, "Inbound NAT rule [%s] backend port must be between 1 and 65535", *rule.Name)
+				}
+				if err := validateFloatingIP(rule.EnableFloatingIP); err != nil {
+					return nil, err
+				}
+				if err := checkFrontendIPConfigurationReference(lbp.FrontendIPConfigurations, rule.FrontendIPConfiguration, "Inbound NAT rule ["+*rule.Name+"]"); err != nil {
+					return nil, err
+				}
+				protocol, err := getWssdProtocol(rule.Protocol)
+				if err != nil {
+					return nil, err
+				}
+
+				wssdCloudLB.InboundNatRules = append(wssdCloudLB.InboundNatRules, &wssdcloudnetwork.InboundNatRule{
+					Name:         *rule.Name,
+					FrontendPort: uint32(*rule.FrontendPort),
+					BackendPort:  uint32(*rule.BackendPort),
+					Protocol:     protocol,
+				})
+			}
+		}
+		if conflicts := GetPortConflicts(networkLB); len(conflicts) > 0 {
+			return nil, &PortConflictError{Conflicts: conflicts}
 		}
 	}
 
 	return wssdCloudLB, nil
 }
 
+// IsInternalLoadBalancer returns true if the frontend of the load balancer is a private ip on a virtual network subnet
+func IsInternalLoadBalancer(lb *network.LoadBalancer) bool {
+	if lb == nil || lb.LoadBalancerPropertiesFormat == nil || lb.FrontendIPConfigurations == nil {
+		return false
+	}
+	for _, fipc := range *lb.FrontendIPConfigurations {
+		if isInternalFrontend(fipc.FrontendIPConfigurationPropertiesFormat) {
+			return true
+		}
+	}
+	return false
+}
+
+func isInternalFrontend(fipcf *network.FrontendIPConfigurationPropertiesFormat) bool {
+	return fipcf != nil && fipcf.Subnet != nil && fipcf.Subnet.ID != nil && len(*fipcf.Subnet.ID) > 0
+}
+
+// setWssdFrontendIPConfiguration sets the frontend of the cloud load balancer. Internal load balancers take their
+// frontend ip from the virtual network subnet, either the requested static private ip or one allocated by the agent.
+// Only the frontend ip and network are sent, so only conflicts between the private ip fields are rejected.
+func setWssdFrontendIPConfiguration(wssdCloudLB *wssdcloudnetwork.LoadBalancer, fipcf *network.FrontendIPConfigurationPropertiesFormat) error {
+	if fipcf == nil {
+		return nil
+	}
+
+	if !isInternalFrontend(fipcf) {
+		if fipcf.IPAddress != nil {
+			wssdCloudLB.FrontendIP = *fipcf.IPAddress
+		}
+		return nil
+	}
+
+	// Public ip references are not sent to the agent, so they are ignored rather than rejected
+	wssdCloudLB.Networkid = *fipcf.Subnet.ID
+
+	frontendIP := ""
+	if fipcf.PrivateIPAddress != nil {
+		frontendIP = *fipcf.PrivateIPAddress
+	}
+	if fipcf.IPAddress != nil && len(*fipcf.IPAddress) > 0 {
+		if len(frontendIP) > 0 && frontendIP != *fipcf.IPAddress {
+			return errors.Wrapf(errors.InvalidInput, "Conflicting frontend IP [%s] and private IP [%s] for load balancer [%s]", *fipcf.IPAddress, frontendIP, wssdCloudLB.Name)
+		}
+		frontendIP = *fipcf.IPAddress
+	}
+
+	switch fipcf.PrivateIPAllocationMethod {
+	case network.Static:
+		if len(frontendIP) == 0 {
+			return errors.Wrapf(errors.InvalidInput, "Static private IP allocation for load balancer [%s] requires a private IP address", wssdCloudLB.Name)
+		}
+	case network.Dynamic:
+		if len(frontendIP) > 0 {
+			return errors.Wrapf(errors.InvalidInput, "Dynamic private IP allocation for load balancer [%s] cannot specify a private IP address", wssdCloudLB.Name)
+		}
+	case "":
+	default:
+		return errors.Wrapf(errors.InvalidInput, "Unknown private IP allocation method %s specified", fipcf.PrivateIPAllocationMethod)
+	}
+	if len(frontendIP) > 0 && net.ParseIP(frontendIP) == nil {
+		return errors.Wrapf(errors.InvalidInput, "Invalid private IP address [%s] for load balancer [%s]", frontendIP, wssdCloudLB.Name)
+	}
+
+	wssdCloudLB.FrontendIP = frontendIP
+	return nil
+}
+
+// getFrontendIPConfigurationIndex returns the index of the frontend that ref identifies, either by id, by name or by
+// an id ending in its name, or -1 if it identifies none. Any reference is accepted when no frontend is configured,
+// and a reference matching no frontend by name or id goes to the first frontend without a name or id, since it
+// cannot be told apart.
+func getFrontendIPConfigurationIndex(frontends *[]network.FrontendIPConfiguration, ref string) int {
+	if frontends == nil || len(*frontends) == 0 {
+		return 0
+	}
+	unnamed := -1
+	for i, frontend := range *frontends {
+		if frontend.ID == nil && (frontend.Name == nil || len(*frontend.Name) == 0) {
+			if unnamed < 0 {
+				unnamed = i
+			}
+			continue
+		}
+		if frontend.ID != nil && strings.EqualFold(*frontend.ID, ref) {
+			return i
+		}
+		if frontend.Name != nil && len(*frontend.Name) > 0 {
+			name := *frontend.Name
+			if strings.EqualFold(name, ref) || strings.HasSuffix(strings.ToLower(ref), "/"+strings.ToLower(name)) {
+				return i
+			}
+		}
+	}
+	return unnamed
+}
+
+// checkFrontendIPConfigurationReference checks the frontend reference of a load balancing or inbound NAT rule. The
+// agent load balancer has a single frontend, and only the first frontend is sent, so a rule referencing another
+// frontend is rejected instead of being bound to the first one.
+func checkFrontendIPConfigurationReference(frontends *[]network.FrontendIPConfiguration, ref *network.SubResource, rule string) error {
+	if ref == nil || ref.ID == nil {
+		return nil
+	}
+	switch i := getFrontendIPConfigurationIndex(frontends, *ref.ID); {
+	case i < 0:
+		return errors.Wrapf(errors.InvalidInput, "%s references unknown frontend IP configuration [%s]", rule, *ref.ID)
+	case i > 0:
+		return errors.Wrapf(errors.NotSupported, "%s references frontend IP configuration [%s], only the first frontend IP configuration is supported", rule, *ref.ID)
+	}
+	return nil
+}
+
 // getLoadBalancer converts the cloud load balancer protobuf returned from wssdcloudagent (wssdcloudnetwork.LoadBalancer) to our internal representation of a loadbalancer (network.LoadBalancer)
 func getLoadBalancer(wssdLB *wssdcloudnetwork.LoadBalancer) (networkLB *network.LoadBalancer, err error) {
 	networkLB = &network.LoadBalancer{
@@ -281,7 +438,7 @@ func getLoadBalancer(wssdLB *wssdcloudnetwork.LoadBalancer) (networkLB *network.
 		ID:       &wssdLB.Id,
 		Version:  &wssdLB.Status.Version.Number,
 		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
-			Statuses:         status.GetStatuses(wssdLB.GetStatus()),
+			Statuses:         provisioning.GetStatuses(wssdLB.GetStatus()),
 			ReplicationCount: wssdLB.GetReplicationCount(),
 		},
 	}
@@ -309,6 +466,9 @@ func getLoadBalancer(wssdLB *wssdcloudnetwork.LoadBalancer) (networkLB *network.
 		}
 		if len(wssdLB.Networkid) != 0 {
 			frontendipconfigurations[0].FrontendIPConfigurationPropertiesFormat.Subnet = &network.Subnet{ID: &wssdLB.Networkid}
+			if len(wssdLB.FrontendIP) != 0 {
+				frontendipconfigurations[0].FrontendIPConfigurationPropertiesFormat.PrivateIPAddress = &wssdLB.FrontendIP
+			}
 		}
 		networkLB.LoadBalancerPropertiesFormat.FrontendIPConfigurations = &frontendipconfigurations
 	}
@@ -319,16 +479,9 @@ func getLoadBalancer(wssdLB *wssdcloudnetwork.LoadBalancer) (networkLB *network.
 		for _, loadbalancingrule := range wssdLB.Loadbalancingrules {
 			frontendport := int32(loadbalancingrule.FrontendPort)
 			backendport := int32(loadbalancingrule.BackendPort)
-			protocol := network.TransportProtocolAll
-
-			if loadbalancingrule.Protocol == wssdcloudcommon.Protocol_All {
-				protocol = network.TransportProtocolAll
-			} else if loadbalancingrule.Protocol == wssdcloudcommon.Protocol_Tcp {
-				protocol = network.TransportProtocolTCP
-			} else if loadbalancingrule.Protocol == wssdcloudcommon.Protocol_Udp {
-				protocol = network.TransportProtocolUDP
-			} else {
-				return nil, errors.Wrapf(errors.InvalidInput, "Unknown protocol %s specified", loadbalancingrule.Protocol)
+			protocol, err := getProtocol(loadbalancingrule.Protocol)
+			if err != nil {
+				return nil, err
 			}
 			networkLBRules = append(networkLBRules, network.LoadBalancingRule{
 				LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
@@ -347,15 +500,9 @@ func getLoadBalancer(wssdLB *wssdcloudnetwork.LoadBalancer) (networkLB *network.
 		for _, wssdInboundNatRule := range wssdLB.InboundNatRules {
 			fePort := int32(wssdInboundNatRule.FrontendPort)
 			bePort := int32(wssdInboundNatRule.BackendPort)
-			protocol := network.TransportProtocolAll
-			if wssdInboundNatRule.Protocol == wssdcloudcommon.Protocol_All {
-				protocol = network.TransportProtocolAll
-			} else if wssdInboundNatRule.Protocol == wssdcloudcommon.Protocol_Tcp {
-				protocol = network.TransportProtocolTCP
-			} else if wssdInboundNatRule.Protocol == wssdcloudcommon.Protocol_Udp {
-				protocol = network.TransportProtocolUDP
-			} else {
-				return nil, errors.Wrapf(errors.InvalidInput, "Unknown protocol %s specified", wssdInboundNatRule.Protocol)
+			protocol, err := getProtocol(wssdInboundNatRule.Protocol)
+			if err != nil {
+				return nil, err
 			}
 
 			newNetworkInboundNatRule := network.InboundNatRule{
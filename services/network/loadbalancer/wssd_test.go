@@ -0,0 +1,342 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package loadbalancer
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func getTestLoadBalancer(frontends []network.FrontendIPConfiguration, rules []network.LoadBalancingRule) *network.LoadBalancer {
+	return &network.LoadBalancer{
+		Name: strPtr("lb1"),
+		LoadBalancerPropertiesFormat: &network.LoadBalancerPropertiesFormat{
+			FrontendIPConfigurations: &frontends,
+			LoadBalancingRules:       &rules,
+		},
+	}
+}
+
+func Test_getWssdLoadBalancerFrontends(t *testing.T) {
+	frontend := network.FrontendIPConfiguration{
+		Name: strPtr("fe1"),
+		FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+			IPAddress: strPtr("10.0.0.4"),
+		},
+	}
+	rule := network.LoadBalancingRule{
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			FrontendIPConfiguration: &network.SubResource{ID: strPtr("/loadBalancers/lb1/frontendIPConfigurations/fe1")},
+			FrontendPort:            int32Ptr(80),
+			BackendPort:             int32Ptr(8080),
+			Protocol:                network.TransportProtocolTCP,
+		},
+	}
+
+	wssdLB, err := getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{frontend}, []network.LoadBalancingRule{rule}), "group1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.4", wssdLB.FrontendIP)
+	assert.Equal(t, 1, len(wssdLB.Loadbalancingrules))
+
+	rule.FrontendIPConfiguration = &network.SubResource{ID: strPtr("fe2")}
+	_, err = getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{frontend}, []network.LoadBalancingRule{rule}), "group1")
+	assert.True(t, errors.IsInvalidInput(err))
+
+	// Only the first frontend is sent to the agent, so rules may not reference the others
+	second := network.FrontendIPConfiguration{
+		Name: strPtr("fe2"),
+		FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+			IPAddress: strPtr("10.0.0.5"),
+		},
+	}
+	_, err = getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{frontend, second}, []network.LoadBalancingRule{rule}), "group1")
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+
+	rule.FrontendIPConfiguration = &network.SubResource{ID: strPtr("/loadBalancers/lb1/frontendIPConfigurations/fe1")}
+	wssdLB, err = getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{frontend, second}, []network.LoadBalancingRule{rule}), "group1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.4", wssdLB.FrontendIP)
+
+	// References are not checked when no frontend is configured
+	wssdLB, err = getWssdLoadBalancer(getTestLoadBalancer(nil, []network.LoadBalancingRule{rule}), "group1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(wssdLB.Loadbalancingrules))
+}
+
+func Test_getWssdLoadBalancerInternal(t *testing.T) {
+	subnetID := "/virtualNetworks/vnet1/subnets/subnet1"
+	frontend := network.FrontendIPConfiguration{
+		FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+			Subnet:                    &network.Subnet{ID: &subnetID},
+			PrivateIPAddress:          strPtr("10.0.0.10"),
+			PrivateIPAllocationMethod: network.Static,
+		},
+	}
+	lb := getTestLoadBalancer([]network.FrontendIPConfiguration{frontend}, nil)
+	assert.True(t, IsInternalLoadBalancer(lb))
+
+	wssdLB, err := getWssdLoadBalancer(lb, "group1")
+	assert.NoError(t, err)
+	assert.Equal(t, subnetID, wssdLB.Networkid)
+	assert.Equal(t, "10.0.0.10", wssdLB.FrontendIP)
+
+	frontend.PrivateIPAllocationMethod = network.Dynamic
+	_, err = getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{frontend}, nil), "group1")
+	assert.True(t, errors.IsInvalidInput(err))
+
+	frontend.PrivateIPAddress = nil
+	wssdLB, err = getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{frontend}, nil), "group1")
+	assert.NoError(t, err)
+	assert.Equal(t, "", wssdLB.FrontendIP)
+
+	frontend.PublicIPPrefix = &network.SubResource{ID: strPtr("prefix1")}
+	frontend.PublicIPAddress = &network.PublicIPAddress{ID: strPtr("publicip1")}
+	wssdLB, err = getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{frontend}, nil), "group1")
+	assert.NoError(t, err)
+	assert.Equal(t, subnetID, wssdLB.Networkid)
+
+	external := network.FrontendIPConfiguration{
+		FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+			IPAddress:        strPtr("10.0.0.20"),
+			PrivateIPAddress: strPtr("10.0.0.21"),
+		},
+	}
+	wssdLB, err = getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{external}, nil), "group1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.20", wssdLB.FrontendIP)
+}
+
+func Test_getWssdLoadBalancerProbes(t *testing.T) {
+	probe := network.Probe{
+		Name: strPtr("probe1"),
+		ProbePropertiesFormat: &network.ProbePropertiesFormat{
+			Protocol:          network.ProbeProtocolHTTP,
+			Port:              int32Ptr(8080),
+			IntervalInSeconds: int32Ptr(10),
+			NumberOfProbes:    int32Ptr(3),
+			RequestPath:       strPtr("/healthz"),
+		},
+	}
+	rule := network.LoadBalancingRule{
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			FrontendPort: int32Ptr(80),
+			BackendPort:  int32Ptr(8080),
+			Protocol:     network.TransportProtocolTCP,
+		},
+	}
+	getLB := func(probes ...network.Probe) *network.LoadBalancer {
+		lb := getTestLoadBalancer(nil, []network.LoadBalancingRule{rule})
+		lb.Probes = &probes
+		return lb
+	}
+
+	_, err := getWssdLoadBalancer(getLB(), "group1")
+	assert.NoError(t, err)
+
+	// The agent cannot apply probes, so they are rejected rather than dropped
+	_, err = getWssdLoadBalancer(getLB(probe), "group1")
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+
+	rule.Probe = &network.SubResource{ID: strPtr("/loadBalancers/lb1/probes/probe1")}
+	_, err = getWssdLoadBalancer(getLB(), "group1")
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+}
+
+func Test_getWssdLoadBalancerOutboundRules(t *testing.T) {
+	frontend := network.FrontendIPConfiguration{
+		Name: strPtr("fe1"),
+		FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+			IPAddress: strPtr("10.0.0.4"),
+		},
+	}
+	rule := network.OutboundRule{
+		Name: strPtr("snat"),
+		OutboundRulePropertiesFormat: &network.OutboundRulePropertiesFormat{
+			AllocatedOutboundPorts:   int32Ptr(1024),
+			IdleTimeoutInMinutes:     int32Ptr(15),
+			FrontendIPConfigurations: &[]network.SubResource{{ID: strPtr("/loadBalancers/lb1/frontendIPConfigurations/fe1")}},
+			BackendAddressPool:       &network.SubResource{ID: strPtr("/loadBalancers/lb1/backendAddressPools/pool1")},
+			Protocol:                 network.LoadBalancerOutboundRuleProtocolAll,
+		},
+	}
+	getLB := func(rules ...network.OutboundRule) *network.LoadBalancer {
+		lb := getTestLoadBalancer([]network.FrontendIPConfiguration{frontend}, nil)
+		lb.BackendAddressPools = &[]network.BackendAddressPool{{Name: strPtr("pool1")}}
+		lb.OutboundRules = &rules
+		return lb
+	}
+
+	_, err := getWssdLoadBalancer(getLB(), "group1")
+	assert.NoError(t, err)
+
+	// The agent cannot apply outbound rules, so they are rejected rather than dropped
+	_, err = getWssdLoadBalancer(getLB(rule), "group1")
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+}
+
+func Test_getWssdLoadBalancerInboundNatRules(t *testing.T) {
+	rule := network.LoadBalancingRule{
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			FrontendPort: int32Ptr(80),
+			BackendPort:  int32Ptr(8080),
+			Protocol:     network.TransportProtocolTCP,
+		},
+	}
+	natRule := network.InboundNatRule{
+		Name: strPtr("ssh-vm1"),
+		InboundNatRulePropertiesFormat: &network.InboundNatRulePropertiesFormat{
+			FrontendPort: int32Ptr(2201),
+			BackendPort:  int32Ptr(22),
+			Protocol:     network.TransportProtocolTCP,
+		},
+	}
+	getLB := func(natRules ...network.InboundNatRule) *network.LoadBalancer {
+		lb := getTestLoadBalancer(nil, []network.LoadBalancingRule{rule})
+		lb.InboundNatRules = &natRules
+		return lb
+	}
+
+	wssdLB, err := getWssdLoadBalancer(getLB(natRule), "group1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(wssdLB.InboundNatRules))
+	assert.Equal(t, "ssh-vm1", wssdLB.InboundNatRules[0].Name)
+	assert.Equal(t, uint32(2201), wssdLB.InboundNatRules[0].FrontendPort)
+
+	_, err = getWssdLoadBalancer(getLB(natRule, natRule), "group1")
+	assert.True(t, errors.IsInvalidInput(err))
+
+	natRule.FrontendPort = int32Ptr(80)
+	_, err = getWssdLoadBalancer(getLB(natRule), "group1")
+	assert.True(t, errors.IsInvalidInput(err))
+}
+
+func Test_getWssdLoadBalancerRuleSettings(t *testing.T) {
+	rule := network.LoadBalancingRule{
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			FrontendPort:     int32Ptr(80),
+			BackendPort:      int32Ptr(8080),
+			Protocol:         network.TransportProtocolTCP,
+			LoadDistribution: network.LoadDistributionDefault,
+		},
+	}
+	_, err := getWssdLoadBalancer(getTestLoadBalancer(nil, []network.LoadBalancingRule{rule}), "group1")
+	assert.NoError(t, err)
+
+	rule.LoadDistribution = network.LoadDistributionSourceIP
+	_, err = getWssdLoadBalancer(getTestLoadBalancer(nil, []network.LoadBalancingRule{rule}), "group1")
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+
+	rule.LoadDistribution = network.LoadDistributionDefault
+	enabled := true
+	rule.EnableFloatingIP = &enabled
+	_, err = getWssdLoadBalancer(getTestLoadBalancer(nil, []network.LoadBalancingRule{rule}), "group1")
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+
+	rule.EnableFloatingIP = nil
+	rule.EnableTCPReset = &enabled
+	_, err = getWssdLoadBalancer(getTestLoadBalancer(nil, []network.LoadBalancingRule{rule}), "group1")
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+
+	rule.EnableTCPReset = nil
+	rule.LoadDistribution = "RoundRobin"
+	_, err = getWssdLoadBalancer(getTestLoadBalancer(nil, []network.LoadBalancingRule{rule}), "group1")
+	assert.True(t, errors.IsInvalidInput(err))
+}
+
+func Test_getWssdLoadBalancerHAPorts(t *testing.T) {
+	subnetID := "/virtualNetworks/vnet1/subnets/subnet1"
+	frontend := network.FrontendIPConfiguration{
+		FrontendIPConfigurationPropertiesFormat: &network.FrontendIPConfigurationPropertiesFormat{
+			Subnet: &network.Subnet{ID: &subnetID},
+		},
+	}
+	rule := network.LoadBalancingRule{
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			FrontendPort: int32Ptr(0),
+			BackendPort:  int32Ptr(0),
+			Protocol:     network.TransportProtocolAll,
+		},
+	}
+	assert.True(t, IsHAPortsRule(&rule))
+
+	wssdLB, err := getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{frontend}, []network.LoadBalancingRule{rule}), "group1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), wssdLB.Loadbalancingrules[0].FrontendPort)
+
+	_, err = getWssdLoadBalancer(getTestLoadBalancer(nil, []network.LoadBalancingRule{rule}), "group1")
+	assert.True(t, errors.IsInvalidInput(err))
+
+	_, err = getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{frontend}, []network.LoadBalancingRule{rule, rule}), "group1")
+	assert.True(t, errors.IsInvalidInput(err))
+
+	rule.Protocol = network.TransportProtocolTCP
+	assert.False(t, IsHAPortsRule(&rule))
+	_, err = getWssdLoadBalancer(getTestLoadBalancer([]network.FrontendIPConfiguration{frontend}, []network.LoadBalancingRule{rule}), "group1")
+	assert.True(t, errors.IsInvalidInput(err))
+}
+
+func Test_getWssdProtocol(t *testing.T) {
+	for _, protocol := range []network.TransportProtocol{network.TransportProtocolTCP, network.TransportProtocolUDP, network.TransportProtocolAll} {
+		wssdProtocol, err := getWssdProtocol(protocol)
+		assert.NoError(t, err)
+		roundTrip, err := getProtocol(wssdProtocol)
+		assert.NoError(t, err)
+		assert.Equal(t, protocol, roundTrip)
+	}
+
+	wssdProtocol, err := getWssdProtocol("udp")
+	assert.NoError(t, err)
+	assert.Equal(t, wssdcloudcommon.Protocol_Udp, wssdProtocol)
+	wssdProtocol, err = getWssdProtocol("")
+	assert.NoError(t, err)
+	assert.Equal(t, wssdcloudcommon.Protocol_All, wssdProtocol)
+
+	rule := network.LoadBalancingRule{
+		LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{
+			FrontendPort: int32Ptr(80),
+			BackendPort:  int32Ptr(8080),
+			Protocol:     "Icmp",
+		},
+	}
+	_, err = getWssdLoadBalancer(getTestLoadBalancer(nil, []network.LoadBalancingRule{rule}), "group1")
+	assert.True(t, errors.IsInvalidInput(err))
+}
+
+func Test_GetPortConflicts(t *testing.T) {
+	rules := []network.LoadBalancingRule{
+		{Name: strPtr("http"), LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{FrontendPort: int32Ptr(80), BackendPort: int32Ptr(8080), Protocol: network.TransportProtocolTCP}},
+		{Name: strPtr("quic"), LoadBalancingRulePropertiesFormat: &network.LoadBalancingRulePropertiesFormat{FrontendPort: int32Ptr(80), BackendPort: int32Ptr(8080), Protocol: network.TransportProtocolUDP}},
+	}
+	lb := getTestLoadBalancer(nil, rules)
+	assert.Empty(t, GetPortConflicts(lb))
+	_, err := getWssdLoadBalancer(lb, "group1")
+	assert.NoError(t, err)
+
+	lb.InboundNatRules = &[]network.InboundNatRule{
+		{Name: strPtr("nat1"), InboundNatRulePropertiesFormat: &network.InboundNatRulePropertiesFormat{FrontendPort: int32Ptr(80), BackendPort: int32Ptr(22), Protocol: network.TransportProtocolAll}},
+	}
+	conflicts := GetPortConflicts(lb)
+	assert.Equal(t, 1, len(conflicts))
+	assert.Equal(t, "inbound NAT rule [nat1]", conflicts[0].Rule)
+	assert.Equal(t, "load balancing rule [http]", conflicts[0].ConflictingRule)
+	assert.Equal(t, int32(80), conflicts[0].FrontendPort)
+
+	_, err = getWssdLoadBalancer(lb, "group1")
+	assert.True(t, IsPortConflict(err))
+	assert.True(t, errors.IsInvalidInput(err))
+	assert.True(t, goerrors.Is(err, errors.InvalidInput))
+}
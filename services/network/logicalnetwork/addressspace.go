@@ -0,0 +1,90 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package logicalnetwork
+
+import (
+	"context"
+	"strings"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// ExpandAddressSpace adds address prefixes to an existing logical network, without recreating it. The agent logical
+// network has no address space of its own, so each prefix is added as a subnet named by network.AddressPrefixSubnetName,
+// with the vlan and allocation method of the first subnet of the network. Prefixes already in the network are skipped,
+// and the others must not overlap the subnets of any logical network in the location.
+func (c *LogicalNetworkClient) ExpandAddressSpace(ctx context.Context, location, networkName string, additionalPrefixes []string) (*network.LogicalNetwork, error) {
+	location = moc.Location(ctx, location)
+	lnets, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var lnet *network.LogicalNetwork
+	existing := []string{}
+	for i := range *lnets {
+		if (*lnets)[i].Name != nil && strings.EqualFold(*(*lnets)[i].Name, networkName) {
+			lnet = &(*lnets)[i]
+		}
+		existing = append(existing, getAddressPrefixes(&(*lnets)[i])...)
+	}
+	if lnet == nil {
+		return nil, errors.Wrapf(errors.NotFound, "Logical network [%s] not found", networkName)
+	}
+
+	own := map[string]bool{}
+	for _, prefix := range getAddressPrefixes(lnet) {
+		own[prefix] = true
+	}
+	added := []string{}
+	for _, prefix := range additionalPrefixes {
+		if !own[prefix] {
+			added = append(added, prefix)
+		}
+	}
+	if len(added) == 0 {
+		return lnet, nil
+	}
+	if err := network.CheckAddressPrefixes(existing, added); err != nil {
+		return nil, err
+	}
+
+	if lnet.LogicalNetworkPropertiesFormat == nil {
+		lnet.LogicalNetworkPropertiesFormat = &network.LogicalNetworkPropertiesFormat{}
+	}
+	subnets := []network.LogicalSubnet{}
+	if lnet.Subnets != nil {
+		subnets = *lnet.Subnets
+	}
+	template := network.LogicalSubnetPropertiesFormat{}
+	if len(subnets) > 0 && subnets[0].LogicalSubnetPropertiesFormat != nil {
+		template.Vlan = subnets[0].Vlan
+		template.IPAllocationMethod = subnets[0].IPAllocationMethod
+	}
+	for _, prefix := range added {
+		name := network.AddressPrefixSubnetName(networkName, prefix)
+		addressPrefix := prefix
+		props := template
+		props.AddressPrefix = &addressPrefix
+		subnets = append(subnets, network.LogicalSubnet{Name: &name, LogicalSubnetPropertiesFormat: &props})
+	}
+	lnet.Subnets = &subnets
+
+	return c.internal.CreateOrUpdate(ctx, location, networkName, lnet)
+}
+
+func getAddressPrefixes(lnet *network.LogicalNetwork) []string {
+	prefixes := []string{}
+	if lnet.LogicalNetworkPropertiesFormat == nil || lnet.Subnets == nil {
+		return prefixes
+	}
+	for _, subnet := range *lnet.Subnets {
+		if subnet.LogicalSubnetPropertiesFormat != nil && subnet.AddressPrefix != nil {
+			prefixes = append(prefixes, *subnet.AddressPrefix)
+		}
+	}
+	return prefixes
+}
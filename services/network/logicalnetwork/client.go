@@ -6,8 +6,15 @@ package logicalnetwork
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/auth"
+	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
 )
 
 // Service interface
@@ -36,21 +43,86 @@ func NewLogicalNetworkClient(cloudFQDN string, authorizer auth.Authorizer) (*Log
 
 // Get methods invokes the client Get method
 func (c *LogicalNetworkClient) Get(ctx context.Context, location, name string) (*[]network.LogicalNetwork, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *LogicalNetworkClient) List(ctx context.Context, location string, opts ...list.Options) (*[]network.LogicalNetwork, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *LogicalNetworkClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]network.LogicalNetwork] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]network.LogicalNetwork, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *LogicalNetworkClient) NewLister(location string) *list.Lister[network.LogicalNetwork] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]network.LogicalNetwork, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *LogicalNetworkClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]network.LogicalNetwork, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/LogicalNetwork/{name}, see resourceid.ID
+func (c *LogicalNetworkClient) GetByID(ctx context.Context, id string) (*network.LogicalNetwork, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.LogicalNetwork, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *LogicalNetworkClient) CreateOrUpdate(ctx context.Context, location, name string, network *network.LogicalNetwork) (*network.LogicalNetwork, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.CreateOrUpdate(ctx, location, name, network)
 }
 
 // Delete methods invokes delete of the logical network resource
 func (c *LogicalNetworkClient) Delete(ctx context.Context, location, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Delete(ctx, location, name)
 }
 
 // Prechecks whether the system is able to create specified logicalNetworks.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *LogicalNetworkClient) Precheck(ctx context.Context, location string, logicalNetworks []*network.LogicalNetwork) (bool, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckDuplicateNames(logicalNetworks); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, location, logicalNetworks)
 }
+
+// Raw returns the underlying agent client, giving access to agent fields not yet
+// exposed by the sdk. Returns nil if the client is not backed by the agent.
+func (c *LogicalNetworkClient) Raw() wssdcloudnetwork.LogicalNetworkAgentClient {
+	if rc, ok := c.internal.(*client); ok {
+		return rc.LogicalNetworkAgentClient
+	}
+	return nil
+}
+
+// ToProto converts a logical network to the message sent to the agent
+func ToProto(lnet *network.LogicalNetwork) (*wssdcloudnetwork.LogicalNetwork, error) {
+	return getWssdLogicalNetwork(lnet)
+}
+
+// FromProto converts a logical network message returned by the agent
+func FromProto(lnet *wssdcloudnetwork.LogicalNetwork) *network.LogicalNetwork {
+	return getLogicalNetwork(lnet)
+}
@@ -5,10 +5,12 @@ package logicalnetwork
 import (
 	"strings"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	"github.com/microsoft/moc/pkg/tags"
 	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
 	wssdcommonproto "github.com/microsoft/moc/rpc/common"
 )
@@ -24,7 +26,7 @@ func getWssdLogicalNetwork(c *network.LogicalNetwork) (*wssdcloudnetwork.Logical
 	wssdnetwork := &wssdcloudnetwork.LogicalNetwork{
 		Name:         *c.Name,
 		LocationName: *c.Location,
-		Tags:         tags.MapToProto(c.Tags),
+		Tags:         conversion.TagsToProto(conversion.LogicalNetwork, c.Tags),
 	}
 
 	if c.Version != nil {
@@ -119,10 +121,9 @@ func getWssdNetworkSubnets(subnets *[]network.LogicalSubnet, location string) (w
 			return
 		}
 
-		if subnet.DhcpOptions != nil && subnet.DhcpOptions.DNSServers != nil {
-			wssdsubnet.Dns = &wssdcommonproto.Dns{
-				Servers: *subnet.DhcpOptions.DNSServers,
-			}
+		wssdsubnet.Dns, err = getWssdDns(subnet.DhcpOptions)
+		if err != nil {
+			return
 		}
 
 		for _, ippool := range subnet.IPPools {
@@ -190,10 +191,10 @@ func getLogicalNetwork(c *wssdcloudnetwork.LogicalNetwork) *network.LogicalNetwo
 		Version:  &c.Status.Version.Number,
 		LogicalNetworkPropertiesFormat: &network.LogicalNetworkPropertiesFormat{
 			Subnets:     getNetworkSubnets(c.Subnets),
-			Statuses:    status.GetStatuses(c.GetStatus()),
+			Statuses:    provisioning.GetStatuses(c.GetStatus()),
 			MacPoolName: &c.MacPoolName,
 		},
-		Tags: tags.ProtoToMap(c.Tags),
+		Tags: conversion.TagsFromProto(conversion.LogicalNetwork, c.Tags),
 	}
 }
 
@@ -201,10 +202,6 @@ func getNetworkSubnets(wssdsubnets []*wssdcloudnetwork.LogicalSubnet) *[]network
 	subnets := []network.LogicalSubnet{}
 
 	for _, subnet := range wssdsubnets {
-		dnsservers := []string{}
-		if subnet.Dns != nil {
-			dnsservers = subnet.Dns.Servers
-		}
 		subnets = append(subnets, network.LogicalSubnet{
 			Name: &subnet.Name,
 			ID:   &subnet.Id,
@@ -212,18 +209,17 @@ func getNetworkSubnets(wssdsubnets []*wssdcloudnetwork.LogicalSubnet) *[]network
 				AddressPrefix: &subnet.AddressPrefix,
 				RouteTable:    getNetworkRoutetable(subnet.Routes),
 				// TODO: implement something for IPConfigurationReferences
-				IPAllocationMethod: ipAllocationMethodProtobufToSdk(subnet.Allocation),
-				Vlan:               getVlan(subnet.Vlan),
-				IPPools:            getIPPools(subnet.IpPools),
-				DhcpOptions: &network.DhcpOptions{
-					DNSServers: &dnsservers,
-				},
+				IPAllocationMethod:   ipAllocationMethodProtobufToSdk(subnet.Allocation),
+				Vlan:                 getVlan(subnet.Vlan),
+				IPPools:              getIPPools(subnet.IpPools),
+				DhcpOptions:          getDhcpOptions(subnet.Dns),
 				NetworkSecurityGroup: getNetworkSecurityGroup(subnet.NetworkSecurityGroupRef),
 				Public:               &subnet.IsPublic,
 			},
 		})
 	}
 
+	list.SortResponse(&subnets)
 	return &subnets
 }
 
@@ -288,3 +284,36 @@ func getNetworkSecurityGroup(wssdNsg *wssdcommonproto.NetworkSecurityGroupRefere
 		ID: &wssdNsg.ResourceRef.Name,
 	}
 }
+
+// getWssdDns converts the DHCP options of the subnet, returning nil if they set neither servers nor a suffix
+func getWssdDns(options *network.DhcpOptions) (*wssdcommonproto.Dns, error) {
+	if options == nil {
+		return nil, nil
+	}
+	if err := network.ValidateDhcpOptions(options); err != nil {
+		return nil, err
+	}
+	if options.DNSServers == nil && (options.DNSSuffix == nil || len(*options.DNSSuffix) == 0) {
+		return nil, nil
+	}
+	dns := &wssdcommonproto.Dns{}
+	if options.DNSServers != nil {
+		dns.Servers = *options.DNSServers
+	}
+	if options.DNSSuffix != nil {
+		dns.Domain = *options.DNSSuffix
+	}
+	return dns, nil
+}
+
+func getDhcpOptions(dns *wssdcommonproto.Dns) *network.DhcpOptions {
+	dnsservers := []string{}
+	if dns != nil {
+		dnsservers = dns.Servers
+	}
+	options := &network.DhcpOptions{DNSServers: &dnsservers}
+	if dns != nil && len(dns.Domain) > 0 {
+		options.DNSSuffix = &dns.Domain
+	}
+	return options
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package logicalnetwork
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// AssociateNSG applies the network security group to the subnet of the logical network, leaving the rest
+// of the logical network unchanged
+func (c *LogicalNetworkClient) AssociateNSG(ctx context.Context, location, name, subnetName, nsgName string) (*network.LogicalNetwork, error) {
+	if len(nsgName) == 0 {
+		return nil, errors.Wrapf(errors.InvalidInput, "Network Security Group name not specified")
+	}
+	return c.setSubnetNSG(ctx, location, name, subnetName, &network.SubResource{ID: &nsgName})
+}
+
+// DisassociateNSG removes the network security group applied to the subnet of the logical network
+func (c *LogicalNetworkClient) DisassociateNSG(ctx context.Context, location, name, subnetName string) (*network.LogicalNetwork, error) {
+	return c.setSubnetNSG(ctx, location, name, subnetName, nil)
+}
+
+// setSubnetNSG sets the network security group of the subnet and writes the logical network back with the version
+// that was read, retrying from the read if the logical network was changed in between
+func (c *LogicalNetworkClient) setSubnetNSG(ctx context.Context, location, name, subnetName string, nsg *network.SubResource) (*network.LogicalNetwork, error) {
+	for {
+		lnets, err := c.Get(ctx, location, name)
+		if err != nil {
+			return nil, err
+		}
+		if lnets == nil || len(*lnets) == 0 {
+			return nil, errors.Wrapf(errors.NotFound, "Logical Network [%s] not found", name)
+		}
+		lnet := (*lnets)[0]
+		i := findSubnet(lnet, subnetName)
+		if i < 0 {
+			return nil, errors.Wrapf(errors.NotFound, "Subnet [%s] of Logical Network [%s] not found", subnetName, name)
+		}
+		if (*lnet.Subnets)[i].LogicalSubnetPropertiesFormat == nil {
+			(*lnet.Subnets)[i].LogicalSubnetPropertiesFormat = &network.LogicalSubnetPropertiesFormat{}
+		}
+		(*lnet.Subnets)[i].NetworkSecurityGroup = nsg
+
+		result, err := c.CreateOrUpdate(ctx, location, name, &lnet)
+		if err != nil {
+			if errors.IsInvalidVersion(err) && ctx.Err() == nil {
+				// Retry only on invalid version
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return nil, err
+		}
+		return result, nil
+	}
+}
+
+func findSubnet(lnet network.LogicalNetwork, name string) int {
+	if lnet.LogicalNetworkPropertiesFormat == nil || lnet.Subnets == nil {
+		return -1
+	}
+	for i, subnet := range *lnet.Subnets {
+		if subnet.Name != nil && strings.EqualFold(*subnet.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
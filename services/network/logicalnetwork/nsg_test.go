@@ -0,0 +1,86 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package logicalnetwork
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLogicalNetworkService stores a single logical network and rejects writes of a stale version. The first
+// conflicts writes add a subnet before being rejected, as if another writer had updated the logical network.
+type fakeLogicalNetworkService struct {
+	Service
+	lnet      network.LogicalNetwork
+	conflicts int
+	puts      int
+}
+
+func (f *fakeLogicalNetworkService) Get(ctx context.Context, location, name string) (*[]network.LogicalNetwork, error) {
+	if *f.lnet.Name != name {
+		return nil, errors.Wrapf(errors.NotFound, "Logical Network [%s] not found", name)
+	}
+	data, err := json.Marshal(f.lnet)
+	if err != nil {
+		return nil, err
+	}
+	lnet := network.LogicalNetwork{}
+	if err := json.Unmarshal(data, &lnet); err != nil {
+		return nil, err
+	}
+	return &[]network.LogicalNetwork{lnet}, nil
+}
+
+func (f *fakeLogicalNetworkService) CreateOrUpdate(ctx context.Context, location, name string, lnet *network.LogicalNetwork) (*network.LogicalNetwork, error) {
+	f.puts++
+	if f.conflicts > 0 {
+		f.conflicts--
+		subnetName := "concurrent" + strconv.Itoa(f.puts)
+		*f.lnet.Subnets = append(*f.lnet.Subnets, network.LogicalSubnet{Name: &subnetName, LogicalSubnetPropertiesFormat: &network.LogicalSubnetPropertiesFormat{}})
+		f.bumpVersion()
+	}
+	if *lnet.Version != *f.lnet.Version {
+		return nil, errors.Wrapf(errors.InvalidVersion, "Logical Network [%s] was updated", name)
+	}
+	f.lnet = *lnet
+	f.bumpVersion()
+	return f.Get(ctx, location, name)
+}
+
+func (f *fakeLogicalNetworkService) bumpVersion() {
+	version, _ := strconv.Atoi(*f.lnet.Version)
+	next := strconv.Itoa(version + 1)
+	f.lnet.Version = &next
+}
+
+func Test_AssociateNSG(t *testing.T) {
+	name, version, subnetName := "lnet1", "1", "subnet1"
+	subnets := []network.LogicalSubnet{{Name: &subnetName, LogicalSubnetPropertiesFormat: &network.LogicalSubnetPropertiesFormat{}}}
+	fake := &fakeLogicalNetworkService{conflicts: 1, lnet: network.LogicalNetwork{
+		Name:                           &name,
+		Version:                        &version,
+		LogicalNetworkPropertiesFormat: &network.LogicalNetworkPropertiesFormat{Subnets: &subnets},
+	}}
+	c := &LogicalNetworkClient{internal: fake}
+
+	lnet, err := c.AssociateNSG(context.Background(), "location", "lnet1", "subnet1", "nsg1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fake.puts)
+	// The subnet added by the concurrent writer is kept
+	assert.Equal(t, 2, len(*lnet.Subnets))
+	assert.Equal(t, "nsg1", *(*lnet.Subnets)[0].NetworkSecurityGroup.ID)
+
+	lnet, err = c.DisassociateNSG(context.Background(), "location", "lnet1", "subnet1")
+	assert.NoError(t, err)
+	assert.Nil(t, (*lnet.Subnets)[0].NetworkSecurityGroup)
+
+	_, err = c.AssociateNSG(context.Background(), "location", "lnet1", "subnet2", "nsg1")
+	assert.True(t, errors.IsNotFound(err))
+}
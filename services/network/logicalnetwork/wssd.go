@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -159,5 +160,6 @@ func getLogicalNetworksFromResponse(response *wssdcloudnetwork.LogicalNetworkRes
 		logicalNetworks = append(logicalNetworks, *(getLogicalNetwork(lnet)))
 	}
 
+	list.SortResponse(&logicalNetworks)
 	return &logicalNetworks
 }
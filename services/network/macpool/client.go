@@ -6,6 +6,12 @@ package macpool
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -36,21 +42,67 @@ func NewMacPoolClient(cloudFQDN string, authorizer auth.Authorizer) (*MacPoolCli
 
 // Get methods invokes the client Get method
 func (c *MacPoolClient) Get(ctx context.Context, location, name string) (*[]network.MACPool, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *MacPoolClient) List(ctx context.Context, location string, opts ...list.Options) (*[]network.MACPool, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *MacPoolClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]network.MACPool] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]network.MACPool, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *MacPoolClient) NewLister(location string) *list.Lister[network.MACPool] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]network.MACPool, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *MacPoolClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]network.MACPool, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/MacPool/{name}, see resourceid.ID
+func (c *MacPoolClient) GetByID(ctx context.Context, id string) (*network.MACPool, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.MacPool, c.Get)
+}
+
 // Ensure methods invokes create or update on the client
 func (c *MacPoolClient) CreateOrUpdate(ctx context.Context, location, name string, macpool *network.MACPool) (*network.MACPool, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.CreateOrUpdate(ctx, location, name, macpool)
 }
 
 // Delete methods invokes delete of the network resource
 func (c *MacPoolClient) Delete(ctx context.Context, location, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Delete(ctx, location, name)
 }
 
 // Prechecks whether the system is able to create specified resources.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *MacPoolClient) Precheck(ctx context.Context, location string, macPools []*network.MACPool) (bool, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckDuplicateNames(macPools); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, location, macPools)
 }
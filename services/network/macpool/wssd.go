@@ -7,9 +7,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -186,6 +188,7 @@ func (c *client) getMacPoolsFromResponse(response *wssdcloudnetwork.MacPoolRespo
 		networkMacPools = append(networkMacPools, *networkMacPool)
 	}
 
+	list.SortResponse(&networkMacPools)
 	return &networkMacPools, nil
 }
 
@@ -235,7 +238,7 @@ func getMacPool(wssdMacPool *wssdcloudnetwork.MacPool) (networkMacPool *network.
 				StartMACAddress: &wssdMacPool.Range.StartMacAddress,
 				EndMACAddress:   &wssdMacPool.Range.EndMacAddress,
 			},
-			Statuses: status.GetStatuses(wssdMacPool.GetStatus()),
+			Statuses: provisioning.GetStatuses(wssdMacPool.GetStatus()),
 		},
 	}
 
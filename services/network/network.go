@@ -216,6 +216,8 @@ type InterfaceDNSSettings struct {
 	InternalFqdn *string `json:"internalFqdn,omitempty"`
 	// InternalDomainNameSuffix - Even if internalDnsNameLabel is not specified, a DNS entry is created for the primary NIC of the VM. This DNS name can be constructed by concatenating the VM name with the value of internalDomainNameSuffix.
 	InternalDomainNameSuffix *string `json:"internalDomainNameSuffix,omitempty"`
+	// RegistrationEnabled - Whether the guest registers the address of this NIC with its DNS servers. Only enabled registration is supported.
+	RegistrationEnabled *bool `json:"registrationEnabled,omitempty"`
 }
 
 // AddressSpace addressSpace contains an array of IP address ranges that can be used by subnets of the
@@ -536,11 +538,21 @@ type LoadBalancer struct {
 	Tags map[string]*string `json:"tags"`
 }
 
+// IPAddressAvailabilityResult response for CheckIPAddressAvailability API service call.
+type IPAddressAvailabilityResult struct {
+	// Available - Private IP address availability.
+	Available *bool `json:"available,omitempty"`
+	// AvailableIPAddresses - Contains other available private IP addresses if the asked for address is taken.
+	AvailableIPAddresses *[]string `json:"availableIPAddresses,omitempty"`
+}
+
 // DhcpOptions dhcpOptions contains an array of DNS servers available to VMs deployed in the virtual
 // network. Standard DHCP option for a subnet overrides VNET DHCP options.
 type DhcpOptions struct {
 	// DNSServers - The list of DNS servers IP addresses.
 	DNSServers *[]string `json:"dnsServers,omitempty"`
+	// DNSSuffix - The DNS suffix of the VMs deployed in the network.
+	DNSSuffix *string `json:"dnsSuffix,omitempty"`
 }
 
 // VirtualNetworkPropertiesFormat properties of the virtual network.
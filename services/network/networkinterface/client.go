@@ -6,8 +6,17 @@ package networkinterface
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
+	cloudgroup "github.com/microsoft/moc-sdk-for-go/services/cloud/group"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/network/internal/ipconflict"
 	"github.com/microsoft/moc/pkg/auth"
+	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
 )
 
 // Service interface
@@ -21,7 +30,9 @@ type Service interface {
 // InterfaceClient structure
 type InterfaceClient struct {
 	network.BaseClient
-	internal Service
+	internal  Service
+	ipchecker *ipconflict.Checker
+	groups    *cloudgroup.GroupClient
 }
 
 // NewInterfaceClient method returns new client
@@ -31,26 +42,133 @@ func NewInterfaceClient(cloudFQDN string, authorizer auth.Authorizer) (*Interfac
 		return nil, err
 	}
 
-	return &InterfaceClient{internal: c}, nil
+	ipchecker, err := ipconflict.NewChecker(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := cloudgroup.NewGroupClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InterfaceClient{internal: c, ipchecker: ipchecker, groups: groups}, nil
 }
 
 // Get methods invokes the client Get method
 func (c *InterfaceClient) Get(ctx context.Context, group, name string) (*[]network.Interface, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *InterfaceClient) List(ctx context.Context, group string, opts ...list.Options) (*[]network.Interface, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *InterfaceClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]network.Interface] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]network.Interface, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *InterfaceClient) NewLister(group string) *list.Lister[network.Interface] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]network.Interface, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *InterfaceClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]network.Interface, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/NetworkInterface/{name}, see resourceid.ID
+func (c *InterfaceClient) GetByID(ctx context.Context, id string) (*network.Interface, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.NetworkInterface, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *InterfaceClient) CreateOrUpdate(ctx context.Context, group, name string, networkInterface *network.Interface) (*network.Interface, error) {
+	group = moc.Group(ctx, group)
+	networkInterface, err := c.withGroupDefaults(ctx, group, name, networkInterface)
+	if err != nil {
+		return nil, err
+	}
 	return c.internal.CreateOrUpdate(ctx, group, name, networkInterface)
 }
 
 // Delete methods invokes delete of the network interface resource
 func (c *InterfaceClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
 
 // Prechecks whether the system is able to create specified resources.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *InterfaceClient) Precheck(ctx context.Context, group string, networkInterfaces []*network.Interface) (bool, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckDuplicateNames(networkInterfaces); err != nil {
+		return false, err
+	}
+	if err := c.precheckIPConflicts(ctx, group, networkInterfaces); err != nil {
+		return false, err
+	}
+	if err := precheckDNSServers(ctx, networkInterfaces); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, group, networkInterfaces)
 }
+
+// precheckIPConflicts fails if a static ip requested by the networkInterfaces is already used by another resource on the same network
+func (c *InterfaceClient) precheckIPConflicts(ctx context.Context, group string, networkInterfaces []*network.Interface) error {
+	if c.ipchecker == nil {
+		return nil
+	}
+	requests := []ipconflict.Request{}
+	for _, nic := range networkInterfaces {
+		if nic == nil {
+			continue
+		}
+		wssdNic, err := getWssdNetworkInterface(nic, group)
+		if err != nil {
+			return err
+		}
+		for _, ipConfig := range wssdNic.IpConfigurations {
+			requests = append(requests, ipconflict.Request{IPAddress: ipConfig.Ipaddress, ResourceType: ipconflict.NetworkInterface, Name: wssdNic.Name, Network: ipConfig.Subnetid})
+		}
+	}
+
+	return c.ipchecker.Check(ctx, group, requests)
+}
+
+// Raw returns the underlying agent client, giving access to agent fields not yet
+// exposed by the sdk. Returns nil if the client is not backed by the agent.
+func (c *InterfaceClient) Raw() wssdcloudnetwork.NetworkInterfaceAgentClient {
+	if rc, ok := c.internal.(*client); ok {
+		return rc.NetworkInterfaceAgentClient
+	}
+	return nil
+}
+
+// ToProto converts a network interface to the message sent to the agent
+func ToProto(nic *network.Interface, group string) (*wssdcloudnetwork.NetworkInterface, error) {
+	return getWssdNetworkInterface(nic, group)
+}
+
+// FromProto converts a network interface message returned by the agent
+func FromProto(nic *wssdcloudnetwork.NetworkInterface, group string) (*network.Interface, error) {
+	return getNetworkInterface("", group, nic)
+}
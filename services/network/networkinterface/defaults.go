@@ -0,0 +1,60 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package networkinterface
+
+import (
+	"context"
+
+	cloudgroup "github.com/microsoft/moc-sdk-for-go/services/cloud/group"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// withGroupDefaults returns the network interface with the default network security group of the group associated
+// with the ip configurations that do not specify one, if the network interface is new. Existing network interfaces
+// are left as they are. The network interface of the caller is not changed.
+func (c *InterfaceClient) withGroupDefaults(ctx context.Context, group, name string, nic *network.Interface) (*network.Interface, error) {
+	if nic == nil || nic.InterfacePropertiesFormat == nil || nic.IPConfigurations == nil || len(*nic.IPConfigurations) == 0 {
+		return nic, nil
+	}
+
+	location := ""
+	if nic.Location != nil {
+		location = *nic.Location
+	}
+	defaults, err := c.groups.LookupNetworkDefaults(ctx, location, group)
+	if err != nil {
+		return nil, err
+	}
+	if defaults.IsEmpty() {
+		return nic, nil
+	}
+
+	existing, err := c.internal.Get(ctx, group, name)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && existing != nil && len(*existing) > 0 {
+		return nic, nil
+	}
+
+	return applyGroupDefaults(nic, defaults), nil
+}
+
+// applyGroupDefaults returns a copy of the network interface with the defaults applied to its ip configurations
+func applyGroupDefaults(nic *network.Interface, defaults *cloudgroup.NetworkDefaults) *network.Interface {
+	copied := *nic
+	props := *nic.InterfacePropertiesFormat
+	ipconfigs := append([]network.InterfaceIPConfiguration{}, *nic.IPConfigurations...)
+	for i := range ipconfigs {
+		if ipconfigs[i].InterfaceIPConfigurationPropertiesFormat != nil {
+			ipconfigProps := *ipconfigs[i].InterfaceIPConfigurationPropertiesFormat
+			ipconfigs[i].InterfaceIPConfigurationPropertiesFormat = &ipconfigProps
+		}
+	}
+	props.IPConfigurations = &ipconfigs
+	copied.InterfacePropertiesFormat = &props
+	defaults.ApplyToInterface(&copied)
+	return &copied
+}
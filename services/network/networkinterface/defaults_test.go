@@ -0,0 +1,26 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package networkinterface
+
+import (
+	"testing"
+
+	cloudgroup "github.com/microsoft/moc-sdk-for-go/services/cloud/group"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyGroupDefaults(t *testing.T) {
+	ipconfigs := []network.InterfaceIPConfiguration{
+		{InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{}},
+	}
+	nic := &network.Interface{InterfacePropertiesFormat: &network.InterfacePropertiesFormat{IPConfigurations: &ipconfigs}}
+
+	applied := applyGroupDefaults(nic, &cloudgroup.NetworkDefaults{NetworkSecurityGroup: "baseline"})
+	assert.Equal(t, "baseline", *(*applied.IPConfigurations)[0].NetworkSecurityGroup.ID)
+
+	// The network interface of the caller is not changed
+	assert.Nil(t, ipconfigs[0].NetworkSecurityGroup)
+	assert.Equal(t, &ipconfigs, nic.IPConfigurations)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package networkinterface
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+const dnsPort = "53"
+
+var (
+	dnsMux          sync.RWMutex
+	dnsProbeTimeout time.Duration
+)
+
+// SetDNSServerProbeTimeout makes Precheck verify that the DNS servers of the network interfaces
+// accept connections within timeout. A zero timeout, the default, disables the probe, as the
+// servers are commonly reachable only from the guest network.
+func SetDNSServerProbeTimeout(timeout time.Duration) {
+	dnsMux.Lock()
+	defer dnsMux.Unlock()
+	dnsProbeTimeout = timeout
+}
+
+func getDNSServerProbeTimeout() time.Duration {
+	dnsMux.RLock()
+	defer dnsMux.RUnlock()
+	return dnsProbeTimeout
+}
+
+// validateDNSSettings fails if the servers are not distinct ip addresses, if the suffix is not a
+// domain name, or if registration is disabled, which the agent does not support
+func validateDNSSettings(dnssetting *network.InterfaceDNSSettings) error {
+	if dnssetting.RegistrationEnabled != nil && !*dnssetting.RegistrationEnabled {
+		return errors.Wrapf(errors.NotSupported, "Disabling DNS registration of a network interface is not supported")
+	}
+	return network.ValidateDhcpOptions(&network.DhcpOptions{
+		DNSServers: dnssetting.DNSServers,
+		DNSSuffix:  dnssetting.InternalDomainNameSuffix,
+	})
+}
+
+// precheckDNSServers fails if a DNS server of the networkInterfaces does not accept connections
+// within the probe timeout. It does nothing unless SetDNSServerProbeTimeout was called.
+func precheckDNSServers(ctx context.Context, networkInterfaces []*network.Interface) error {
+	timeout := getDNSServerProbeTimeout()
+	if timeout <= 0 {
+		return nil
+	}
+	probed := map[string]bool{}
+	dialer := net.Dialer{Timeout: timeout}
+	for _, nic := range networkInterfaces {
+		if nic == nil || nic.InterfacePropertiesFormat == nil || nic.DNSSettings == nil || nic.DNSSettings.DNSServers == nil {
+			continue
+		}
+		for _, server := range *nic.DNSSettings.DNSServers {
+			if probed[server] {
+				continue
+			}
+			probed[server] = true
+			conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(server, dnsPort))
+			if err != nil {
+				return errors.Wrapf(errors.InvalidInput, "DNS server [%s] of network interface [%s] is not reachable: %v", server, getName(nic), err)
+			}
+			conn.Close()
+		}
+	}
+	return nil
+}
+
+func getName(nic *network.Interface) string {
+	if nic.Name == nil {
+		return ""
+	}
+	return *nic.Name
+}
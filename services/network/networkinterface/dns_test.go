@@ -0,0 +1,43 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+package networkinterface
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getDns(t *testing.T) {
+	servers := []string{"10.0.0.4", "10.0.0.5"}
+	suffix := "corp.contoso.com"
+	enabled := true
+	dns, err := getDns(&network.InterfaceDNSSettings{DNSServers: &servers, InternalDomainNameSuffix: &suffix, RegistrationEnabled: &enabled})
+	assert.NoError(t, err)
+	assert.Equal(t, servers, dns.Servers)
+	assert.Equal(t, suffix, dns.Domain)
+
+	settings := getWssdDNSSettings(dns)
+	assert.Equal(t, servers, *settings.DNSServers)
+	assert.Equal(t, suffix, *settings.InternalDomainNameSuffix)
+	assert.True(t, *settings.RegistrationEnabled)
+}
+
+func Test_validateDNSSettings(t *testing.T) {
+	invalidServers := []string{"10.0.0.4", "dns.contoso.com"}
+	duplicateServers := []string{"10.0.0.4", "10.0.0.4"}
+	invalidSuffix := "corp..contoso.com"
+	disabled := false
+
+	err := validateDNSSettings(&network.InterfaceDNSSettings{DNSServers: &invalidServers})
+	assert.True(t, errors.IsInvalidInput(err))
+	err = validateDNSSettings(&network.InterfaceDNSSettings{DNSServers: &duplicateServers})
+	assert.True(t, errors.IsInvalidInput(err))
+	err = validateDNSSettings(&network.InterfaceDNSSettings{InternalDomainNameSuffix: &invalidSuffix})
+	assert.True(t, errors.IsInvalidInput(err))
+	err = validateDNSSettings(&network.InterfaceDNSSettings{RegistrationEnabled: &disabled})
+	assert.True(t, goerrors.Is(err, errors.NotSupported))
+}
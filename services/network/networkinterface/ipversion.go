@@ -0,0 +1,108 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+package networkinterface
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// getIPVersion returns the address family of the ip configuration. The family is taken from PrivateIPAddressVersion,
+// or from the private ip address when no version is set, and defaults to IPv4.
+func getIPVersion(ipConfig *network.InterfaceIPConfiguration) (network.IPVersion, error) {
+	switch {
+	case strings.EqualFold(string(ipConfig.PrivateIPAddressVersion), string(network.IPv4)):
+		return network.IPv4, nil
+	case strings.EqualFold(string(ipConfig.PrivateIPAddressVersion), string(network.IPv6)):
+		return network.IPv6, nil
+	case len(ipConfig.PrivateIPAddressVersion) > 0:
+		return "", errors.Wrapf(errors.InvalidInput, "Unknown private IP address version %s specified", ipConfig.PrivateIPAddressVersion)
+	}
+	if ipConfig.PrivateIPAddress != nil {
+		if ip := net.ParseIP(*ipConfig.PrivateIPAddress); ip != nil && ip.To4() == nil {
+			return network.IPv6, nil
+		}
+	}
+	return network.IPv4, nil
+}
+
+// validateIPConfigAddressFamily checks that the address, gateway and prefix length of the ip configuration belong
+// to its address family
+func validateIPConfigAddressFamily(ipConfig *network.InterfaceIPConfiguration) error {
+	version, err := getIPVersion(ipConfig)
+	if err != nil {
+		return err
+	}
+	maxPrefixLength := 32
+	if version == network.IPv6 {
+		maxPrefixLength = 128
+	}
+
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{{"private IP address", ipConfig.PrivateIPAddress}, {"gateway", ipConfig.Gateway}} {
+		if field.value == nil || len(*field.value) == 0 {
+			continue
+		}
+		ip := net.ParseIP(*field.value)
+		if ip == nil {
+			return errors.Wrapf(errors.InvalidInput, "Invalid %s [%s]", field.name, *field.value)
+		}
+		if (ip.To4() == nil) != (version == network.IPv6) {
+			return errors.Wrapf(errors.InvalidInput, "%s [%s] is not an %s address", field.name, *field.value, version)
+		}
+	}
+
+	if ipConfig.PrefixLength != nil && len(*ipConfig.PrefixLength) > 0 {
+		length, err := strconv.Atoi(*ipConfig.PrefixLength)
+		if err != nil || length < 0 || length > maxPrefixLength {
+			return errors.Wrapf(errors.InvalidInput, "Prefix length [%s] must be between 0 and %d for %s", *ipConfig.PrefixLength, maxPrefixLength, version)
+		}
+	}
+	return nil
+}
+
+// validateDualStack checks the address families of the ip configurations of a network interface. IPv6 ip
+// configurations are secondary to an IPv4 ip configuration, as in a dual-stack network interface.
+func validateDualStack(ipConfigs []network.InterfaceIPConfiguration) error {
+	hasIPv4, hasIPv6 := false, false
+	for i := range ipConfigs {
+		if ipConfigs[i].InterfaceIPConfigurationPropertiesFormat == nil {
+			continue
+		}
+		version, err := getIPVersion(&ipConfigs[i])
+		if err != nil {
+			return err
+		}
+		if version == network.IPv4 {
+			hasIPv4 = true
+			continue
+		}
+		hasIPv6 = true
+		if ipConfigs[i].Primary != nil && *ipConfigs[i].Primary {
+			return errors.Wrapf(errors.InvalidInput, "IPv6 ip configuration cannot be the primary ip configuration")
+		}
+	}
+	if hasIPv6 && !hasIPv4 {
+		return errors.Wrapf(errors.InvalidInput, "IPv6 ip configuration requires an IPv4 ip configuration on the network interface")
+	}
+	return nil
+}
+
+// getIPVersionFromAddress returns the address family of an address returned by the agent, empty if it is not an address
+func getIPVersionFromAddress(address string) network.IPVersion {
+	ip := net.ParseIP(address)
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() == nil:
+		return network.IPv6
+	default:
+		return network.IPv4
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+package networkinterface
+
+import (
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIPConfig(address, prefixLength, gateway string, version network.IPVersion, primary bool) network.InterfaceIPConfiguration {
+	subnet := "subnet"
+	return network.InterfaceIPConfiguration{
+		InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+			Subnet:                  &network.APIEntityReference{ID: &subnet},
+			PrivateIPAddress:        &address,
+			PrefixLength:            &prefixLength,
+			Gateway:                 &gateway,
+			PrivateIPAddressVersion: version,
+			Primary:                 &primary,
+		},
+	}
+}
+
+func Test_validateIPConfigAddressFamily(t *testing.T) {
+	ipv4 := newIPConfig("10.0.0.4", "24", "10.0.0.1", "", true)
+	assert.NoError(t, validateIPConfigAddressFamily(&ipv4))
+	ipv6 := newIPConfig("fd00::4", "64", "fd00::1", network.IPv6, false)
+	assert.NoError(t, validateIPConfigAddressFamily(&ipv6))
+	inferred := newIPConfig("fd00::4", "64", "", "", false)
+	assert.NoError(t, validateIPConfigAddressFamily(&inferred))
+
+	mismatch := newIPConfig("10.0.0.4", "24", "", network.IPv6, false)
+	assert.True(t, errors.IsInvalidInput(validateIPConfigAddressFamily(&mismatch)))
+	gateway := newIPConfig("fd00::4", "64", "10.0.0.1", network.IPv6, false)
+	assert.True(t, errors.IsInvalidInput(validateIPConfigAddressFamily(&gateway)))
+	prefix := newIPConfig("10.0.0.4", "64", "", network.IPv4, false)
+	assert.True(t, errors.IsInvalidInput(validateIPConfigAddressFamily(&prefix)))
+	unknown := newIPConfig("10.0.0.4", "24", "", "IPv5", false)
+	assert.True(t, errors.IsInvalidInput(validateIPConfigAddressFamily(&unknown)))
+}
+
+func Test_validateDualStack(t *testing.T) {
+	ipv4 := newIPConfig("10.0.0.4", "24", "", network.IPv4, true)
+	ipv6 := newIPConfig("fd00::4", "64", "", network.IPv6, false)
+	assert.NoError(t, validateDualStack([]network.InterfaceIPConfiguration{ipv4, ipv6}))
+
+	err := validateDualStack([]network.InterfaceIPConfiguration{ipv6})
+	assert.True(t, errors.IsInvalidInput(err))
+
+	primary := newIPConfig("fd00::4", "64", "", network.IPv6, true)
+	ipv4.Primary = new(bool)
+	err = validateDualStack([]network.InterfaceIPConfiguration{ipv4, primary})
+	assert.True(t, errors.IsInvalidInput(err))
+
+	assert.Equal(t, network.IPv6, getIPVersionFromAddress("fd00::4"))
+	assert.Equal(t, network.IPv4, getIPVersionFromAddress("10.0.0.4"))
+	assert.Equal(t, network.IPVersion(""), getIPVersionFromAddress(""))
+}
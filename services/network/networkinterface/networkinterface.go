@@ -3,10 +3,11 @@
 package networkinterface
 
 import (
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	"github.com/microsoft/moc/pkg/tags"
 	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
 	wssdcommonproto "github.com/microsoft/moc/rpc/common"
 )
@@ -24,6 +25,10 @@ func getWssdNetworkInterface(c *network.Interface, group string) (*wssdcloudnetw
 		return nil, errors.Wrapf(errors.InvalidConfiguration, "Missing IPConfigurations")
 	}
 
+	if err := validateDualStack(*c.IPConfigurations); err != nil {
+		return nil, err
+	}
+
 	wssdipconfigs := []*wssdcloudnetwork.IpConfiguration{}
 	for _, ipconfig := range *c.IPConfigurations {
 		wssdipconfig, err := getWssdNetworkInterfaceIPConfig(&ipconfig, c.Location)
@@ -37,12 +42,17 @@ func getWssdNetworkInterface(c *network.Interface, group string) (*wssdcloudnetw
 		return nil, errors.Wrapf(errors.InvalidConfiguration, "Missing Name for Network Interface")
 	}
 
+	dns, err := getDns(c.DNSSettings)
+	if err != nil {
+		return nil, err
+	}
+
 	vnic := &wssdcloudnetwork.NetworkInterface{
 		Name:             *c.Name,
 		IpConfigurations: wssdipconfigs,
 		GroupName:        group,
-		Dns:              getDns(c.DNSSettings),
-		Tags:             tags.MapToProto(c.Tags),
+		Dns:              dns,
+		Tags:             conversion.TagsToProto(conversion.NetworkInterface, c.Tags),
 	}
 
 	if c.Version != nil {
@@ -71,9 +81,11 @@ func getWssdDNSSettings(dnssetting *wssdcommonproto.Dns) *network.InterfaceDNSSe
 	if dnssetting == nil {
 		return nil
 	}
+	registrationEnabled := true
 	return &network.InterfaceDNSSettings{
 		DNSServers:               &dnssetting.Servers,
 		InternalDomainNameSuffix: &dnssetting.Domain,
+		RegistrationEnabled:      &registrationEnabled,
 	}
 }
 
@@ -113,6 +125,9 @@ func getWssdNetworkInterfaceIPConfig(ipConfig *network.InterfaceIPConfiguration,
 		len(*ipConfig.Subnet.ID) == 0 {
 		return nil, errors.Wrapf(errors.InvalidConfiguration, "Missing Subnet Reference")
 	}
+	if err := validateIPConfigAddressFamily(ipConfig); err != nil {
+		return nil, err
+	}
 
 	wssdipconfig := &wssdcloudnetwork.IpConfiguration{
 		Subnetid: *ipConfig.Subnet.ID,
@@ -161,19 +176,22 @@ func getNetworkInterface(server, group string, c *wssdcloudnetwork.NetworkInterf
 			MacAddress: &c.Macaddress,
 			// TODO: Type
 			IPConfigurations:            &ipConfigs,
-			Statuses:                    status.GetStatuses(c.GetStatus()),
+			Statuses:                    provisioning.GetStatuses(c.GetStatus()),
 			EnableAcceleratedNetworking: getIovSetting(c),
 			DNSSettings:                 getWssdDNSSettings(c.Dns),
 		},
-		Tags: tags.ProtoToMap(c.Tags),
+		Tags: conversion.TagsFromProto(conversion.NetworkInterface, c.Tags),
 	}
 
 	return vnetIntf, nil
 }
 
-func getDns(dnssetting *network.InterfaceDNSSettings) *wssdcommonproto.Dns {
+func getDns(dnssetting *network.InterfaceDNSSettings) (*wssdcommonproto.Dns, error) {
 	if dnssetting == nil {
-		return nil
+		return nil, nil
+	}
+	if err := validateDNSSettings(dnssetting); err != nil {
+		return nil, err
 	}
 	var dns wssdcommonproto.Dns
 	if dnssetting.DNSServers != nil {
@@ -182,7 +200,7 @@ func getDns(dnssetting *network.InterfaceDNSSettings) *wssdcommonproto.Dns {
 	if dnssetting.InternalDomainNameSuffix != nil {
 		dns.Domain = *dnssetting.InternalDomainNameSuffix
 	}
-	return &dns
+	return &dns, nil
 }
 
 func getNetworkIpConfig(wssdcloudipconfig *wssdcloudnetwork.IpConfiguration) *network.InterfaceIPConfiguration {
@@ -202,6 +220,7 @@ func getNetworkIpConfig(wssdcloudipconfig *wssdcloudnetwork.IpConfiguration) *ne
 		}
 	}
 
+	ipconfig.PrivateIPAddressVersion = getIPVersionFromAddress(wssdcloudipconfig.Ipaddress)
 	ipAllocationMethodProtobufToSdk(wssdcloudipconfig, ipconfig)
 
 	var addresspools []network.BackendAddressPool
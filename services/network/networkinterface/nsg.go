@@ -0,0 +1,62 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package networkinterface
+
+import (
+	"context"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// AssociateNSG applies the network security group to all the ip configurations of the network interface,
+// leaving the rest of the network interface unchanged
+func (c *InterfaceClient) AssociateNSG(ctx context.Context, group, name, nsgName string) (*network.Interface, error) {
+	if len(nsgName) == 0 {
+		return nil, errors.Wrapf(errors.InvalidInput, "Network Security Group name not specified")
+	}
+	return c.setNSG(ctx, group, name, &network.SubResource{ID: &nsgName})
+}
+
+// DisassociateNSG removes the network security group applied to the ip configurations of the network interface
+func (c *InterfaceClient) DisassociateNSG(ctx context.Context, group, name string) (*network.Interface, error) {
+	return c.setNSG(ctx, group, name, nil)
+}
+
+// setNSG sets the network security group of the ip configurations and writes the network interface back with the
+// version that was read, retrying from the read if the network interface was changed in between
+func (c *InterfaceClient) setNSG(ctx context.Context, group, name string, nsg *network.SubResource) (*network.Interface, error) {
+	for {
+		nics, err := c.Get(ctx, group, name)
+		if err != nil {
+			return nil, err
+		}
+		if nics == nil || len(*nics) == 0 {
+			return nil, errors.Wrapf(errors.NotFound, "Network Interface [%s] not found", name)
+		}
+		nic := (*nics)[0]
+		if nic.InterfacePropertiesFormat == nil || nic.IPConfigurations == nil || len(*nic.IPConfigurations) == 0 {
+			return nil, errors.Wrapf(errors.InvalidConfiguration, "Network Interface [%s] has no ip configurations", name)
+		}
+
+		for i := range *nic.IPConfigurations {
+			if (*nic.IPConfigurations)[i].InterfaceIPConfigurationPropertiesFormat == nil {
+				continue
+			}
+			(*nic.IPConfigurations)[i].NetworkSecurityGroup = nsg
+		}
+
+		result, err := c.CreateOrUpdate(ctx, group, name, &nic)
+		if err != nil {
+			if errors.IsInvalidVersion(err) && ctx.Err() == nil {
+				// Retry only on invalid version
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return nil, err
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package networkinterface
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeInterfaceService stores a single network interface and rejects writes of a stale version. The first
+// conflicts writes set a tag before being rejected, as if another writer had updated the network interface.
+type fakeInterfaceService struct {
+	Service
+	nic       network.Interface
+	conflicts int
+	puts      int
+}
+
+func (f *fakeInterfaceService) Get(ctx context.Context, group, name string) (*[]network.Interface, error) {
+	if *f.nic.Name != name {
+		return nil, errors.Wrapf(errors.NotFound, "Network Interface [%s] not found", name)
+	}
+	data, err := json.Marshal(f.nic)
+	if err != nil {
+		return nil, err
+	}
+	nic := network.Interface{}
+	if err := json.Unmarshal(data, &nic); err != nil {
+		return nil, err
+	}
+	return &[]network.Interface{nic}, nil
+}
+
+func (f *fakeInterfaceService) CreateOrUpdate(ctx context.Context, group, name string, nic *network.Interface) (*network.Interface, error) {
+	f.puts++
+	if f.conflicts > 0 {
+		f.conflicts--
+		owner := "someone"
+		f.nic.Tags = map[string]*string{"owner": &owner}
+		f.bumpVersion()
+	}
+	if *nic.Version != *f.nic.Version {
+		return nil, errors.Wrapf(errors.InvalidVersion, "Network Interface [%s] was updated", name)
+	}
+	f.nic = *nic
+	f.bumpVersion()
+	return f.Get(ctx, group, name)
+}
+
+func (f *fakeInterfaceService) bumpVersion() {
+	version, _ := strconv.Atoi(*f.nic.Version)
+	next := strconv.Itoa(version + 1)
+	f.nic.Version = &next
+}
+
+func Test_AssociateNSG(t *testing.T) {
+	name, version := "nic1", "1"
+	ipconfigs := []network.InterfaceIPConfiguration{
+		{InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{}},
+		{InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{}},
+	}
+	fake := &fakeInterfaceService{conflicts: 1, nic: network.Interface{
+		Name:                      &name,
+		Version:                   &version,
+		InterfacePropertiesFormat: &network.InterfacePropertiesFormat{IPConfigurations: &ipconfigs},
+	}}
+	c := &InterfaceClient{internal: fake}
+
+	nic, err := c.AssociateNSG(context.Background(), "group", "nic1", "nsg1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fake.puts)
+	// The change of the concurrent writer is kept
+	assert.Equal(t, "someone", *nic.Tags["owner"])
+	for _, ipconfig := range *nic.IPConfigurations {
+		assert.Equal(t, "nsg1", *ipconfig.NetworkSecurityGroup.ID)
+	}
+
+	nic, err = c.DisassociateNSG(context.Background(), "group", "nic1")
+	assert.NoError(t, err)
+	for _, ipconfig := range *nic.IPConfigurations {
+		assert.Nil(t, ipconfig.NetworkSecurityGroup)
+	}
+
+	_, err = c.AssociateNSG(context.Background(), "group", "nic2", "nsg1")
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -141,6 +142,7 @@ func (c *client) getInterfacesFromResponse(group string, response *wssdcloudnetw
 		virtualNetworkInterfaces = append(virtualNetworkInterfaces, *vnetIntf)
 	}
 
+	list.SortResponse(&virtualNetworkInterfaces)
 	return &virtualNetworkInterfaces, nil
 }
 
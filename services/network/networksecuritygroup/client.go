@@ -6,8 +6,15 @@ package networksecuritygroup
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/auth"
+	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
 )
 
 // Service interface
@@ -36,21 +43,86 @@ func NewSecurityGroupClient(cloudFQDN string, authorizer auth.Authorizer) (*Netw
 
 // Get methods invokes the client Get method
 func (c *NetworkSecurityGroupAgentClient) Get(ctx context.Context, location, name string) (*[]network.SecurityGroup, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *NetworkSecurityGroupAgentClient) List(ctx context.Context, location string, opts ...list.Options) (*[]network.SecurityGroup, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *NetworkSecurityGroupAgentClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]network.SecurityGroup] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]network.SecurityGroup, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *NetworkSecurityGroupAgentClient) NewLister(location string) *list.Lister[network.SecurityGroup] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]network.SecurityGroup, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *NetworkSecurityGroupAgentClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]network.SecurityGroup, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/NetworkSecurityGroup/{name}, see resourceid.ID
+func (c *NetworkSecurityGroupAgentClient) GetByID(ctx context.Context, id string) (*network.SecurityGroup, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.NetworkSecurityGroup, c.Get)
+}
+
 // Ensure methods invokes create or update on the client
 func (c *NetworkSecurityGroupAgentClient) CreateOrUpdate(ctx context.Context, location, name string, nsg *network.SecurityGroup) (*network.SecurityGroup, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.CreateOrUpdate(ctx, location, name, nsg)
 }
 
 // Delete methods invokes delete of the network resource
 func (c *NetworkSecurityGroupAgentClient) Delete(ctx context.Context, location, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Delete(ctx, location, name)
 }
 
 // Prechecks whether the system is able to create specified resources.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *NetworkSecurityGroupAgentClient) Precheck(ctx context.Context, location string, networkSecurityGroups []*network.SecurityGroup) (bool, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckDuplicateNames(networkSecurityGroups); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, location, networkSecurityGroups)
 }
+
+// Raw returns the underlying agent client, giving access to agent fields not yet
+// exposed by the sdk. Returns nil if the client is not backed by the agent.
+func (c *NetworkSecurityGroupAgentClient) Raw() wssdcloudnetwork.NetworkSecurityGroupAgentClient {
+	if rc, ok := c.internal.(*client); ok {
+		return rc.NetworkSecurityGroupAgentClient
+	}
+	return nil
+}
+
+// ToProto converts a network security group to the message sent to the agent
+func ToProto(nsg *network.SecurityGroup, location string) (*wssdcloudnetwork.NetworkSecurityGroup, error) {
+	return getWssdNetworkSecurityGroup(nsg, location)
+}
+
+// FromProto converts a network security group message returned by the agent
+func FromProto(nsg *wssdcloudnetwork.NetworkSecurityGroup) (*network.SecurityGroup, error) {
+	return getNetworkSecurityGroup(nsg)
+}
@@ -0,0 +1,217 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package networksecuritygroup
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+const defaultRulePriority uint32 = 4096
+
+// Flow describes the traffic evaluated against a network security group
+type Flow struct {
+	// Direction - Direction of the traffic relative to the resources the group is applied to
+	Direction network.SecurityRuleDirection
+	// Source - Source ip address
+	Source string
+	// Destination - Destination ip address
+	Destination string
+	// Protocol - Protocol of the traffic. Asterisk is not allowed.
+	Protocol network.SecurityRuleProtocol
+	// Port - Destination port, ignored for Icmp
+	Port uint16
+}
+
+// Verdict is the result of evaluating a flow against a network security group
+type Verdict struct {
+	// Access - Whether the flow is allowed or denied
+	Access network.SecurityRuleAccess
+	// MatchedRule - Name of the rule deciding the verdict, empty if no rule matched and the flow is denied
+	MatchedRule string
+	// EvaluatedRules - Names of the rules evaluated in order, up to and including the matched rule
+	EvaluatedRules []string
+	// SkippedRules - Names of the rules that could not be evaluated, such as rules using service tags
+	SkippedRules []string
+}
+
+// EvaluateTraffic evaluates the flow against the rules of the network security group, returning whether it is
+// allowed and the chain of rules leading to the verdict. Use it to validate a change to a group before applying it.
+func (c *NetworkSecurityGroupAgentClient) EvaluateTraffic(ctx context.Context, location, name string, flow Flow) (*Verdict, error) {
+	nsgs, err := c.Get(ctx, location, name)
+	if err != nil {
+		return nil, err
+	}
+	if nsgs == nil || len(*nsgs) == 0 {
+		return nil, errors.Wrapf(errors.NotFound, "Network Security Group [%s] not found", name)
+	}
+	return Evaluate(&(*nsgs)[0], flow)
+}
+
+// Evaluate evaluates the flow against the rules of nsg without contacting the agent. The security rules are
+// evaluated by ascending priority, followed by the default security rules, and the first matching rule decides.
+// A flow matching no rule is denied. Only the rules of the group are evaluated: admin rules and routes are not
+// exposed by the agent.
+func Evaluate(nsg *network.SecurityGroup, flow Flow) (*Verdict, error) {
+	if nsg == nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "Network Security Group not specified")
+	}
+	source, destination := net.ParseIP(flow.Source), net.ParseIP(flow.Destination)
+	if source == nil || destination == nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "Flow source [%s] and destination [%s] must be ip addresses", flow.Source, flow.Destination)
+	}
+	if flow.Protocol == network.SecurityRuleProtocolAsterisk || len(flow.Protocol) == 0 {
+		return nil, errors.Wrapf(errors.InvalidInput, "Flow protocol not specified")
+	}
+
+	verdict := &Verdict{Access: network.SecurityRuleAccessDeny}
+	if nsg.SecurityGroupPropertiesFormat == nil {
+		return verdict, nil
+	}
+	for _, rules := range []*[]network.SecurityRule{nsg.SecurityRules, nsg.DefaultSecurityRules} {
+		for _, rule := range sortRulesByPriority(rules) {
+			name := ""
+			if rule.Name != nil {
+				name = *rule.Name
+			}
+			matched, ok := matchRule(rule.SecurityRulePropertiesFormat, flow, source, destination)
+			if !ok {
+				verdict.SkippedRules = append(verdict.SkippedRules, name)
+				continue
+			}
+			verdict.EvaluatedRules = append(verdict.EvaluatedRules, name)
+			if matched {
+				verdict.Access = network.SecurityRuleAccessAllow
+				if strings.EqualFold(string(rule.Access), string(network.SecurityRuleAccessDeny)) {
+					verdict.Access = network.SecurityRuleAccessDeny
+				}
+				verdict.MatchedRule = name
+				return verdict, nil
+			}
+		}
+	}
+	return verdict, nil
+}
+
+func sortRulesByPriority(rules *[]network.SecurityRule) []network.SecurityRule {
+	if rules == nil {
+		return nil
+	}
+	sorted := []network.SecurityRule{}
+	for _, rule := range *rules {
+		if rule.SecurityRulePropertiesFormat != nil {
+			sorted = append(sorted, rule)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return getPriority(sorted[i].Priority) < getPriority(sorted[j].Priority)
+	})
+	return sorted
+}
+
+func getPriority(priority *uint32) uint32 {
+	if priority == nil || !isValidPriority(*priority) {
+		return defaultRulePriority
+	}
+	return *priority
+}
+
+// matchRule returns whether the rule matches the flow, and false for ok if the rule cannot be evaluated
+func matchRule(rule *network.SecurityRulePropertiesFormat, flow Flow, source, destination net.IP) (matched bool, ok bool) {
+	if !strings.EqualFold(string(rule.Direction), string(flow.Direction)) {
+		return false, true
+	}
+	if rule.Protocol != network.SecurityRuleProtocolAsterisk && !strings.EqualFold(string(rule.Protocol), string(flow.Protocol)) {
+		return false, true
+	}
+
+	for _, match := range []struct {
+		ip       net.IP
+		prefix   *string
+		prefixes *[]string
+	}{
+		{source, rule.SourceAddressPrefix, rule.SourceAddressPrefixes},
+		{destination, rule.DestinationAddressPrefix, rule.DestinationAddressPrefixes},
+	} {
+		matched, ok := matchAddress(match.ip, getValues(match.prefix, match.prefixes))
+		if !ok || !matched {
+			return false, ok
+		}
+	}
+
+	if strings.EqualFold(string(flow.Protocol), string(network.SecurityRuleProtocolIcmp)) {
+		return true, true
+	}
+	return matchPort(flow.Port, getValues(rule.DestinationPortRange, rule.DestinationPortRanges))
+}
+
+func getValues(value *string, values *[]string) []string {
+	if value != nil && len(*value) > 0 {
+		return []string{*value}
+	}
+	if values != nil {
+		return *values
+	}
+	return nil
+}
+
+func matchAddress(ip net.IP, prefixes []string) (bool, bool) {
+	if len(prefixes) == 0 {
+		return true, true
+	}
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSpace(prefix)
+		if prefix == "*" {
+			return true, true
+		}
+		if _, cidr, err := net.ParseCIDR(prefix); err == nil {
+			if cidr.Contains(ip) {
+				return true, true
+			}
+			continue
+		}
+		if address := net.ParseIP(prefix); address != nil {
+			if address.Equal(ip) {
+				return true, true
+			}
+			continue
+		}
+		// Service tags such as VirtualNetwork are resolved by the agent
+		return false, false
+	}
+	return false, true
+}
+
+func matchPort(port uint16, ranges []string) (bool, bool) {
+	if len(ranges) == 0 {
+		return true, true
+	}
+	for _, portRange := range ranges {
+		portRange = strings.TrimSpace(portRange)
+		if portRange == "*" {
+			return true, true
+		}
+		bounds := strings.SplitN(portRange, "-", 2)
+		low, err := strconv.ParseUint(bounds[0], 10, 16)
+		if err != nil {
+			return false, false
+		}
+		high := low
+		if len(bounds) == 2 {
+			if high, err = strconv.ParseUint(bounds[1], 10, 16); err != nil {
+				return false, false
+			}
+		}
+		if uint64(port) >= low && uint64(port) <= high {
+			return true, true
+		}
+	}
+	return false, true
+}
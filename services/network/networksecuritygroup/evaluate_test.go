@@ -0,0 +1,58 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package networksecuritygroup
+
+import (
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestFlowRule(name string, priority uint32, access network.SecurityRuleAccess, source, port string) network.SecurityRule {
+	rule := getTestRule(name, priority)
+	rule.Access = access
+	rule.Direction = network.SecurityRuleDirectionInbound
+	rule.Protocol = network.SecurityRuleProtocolTCP
+	rule.SourceAddressPrefix = &source
+	rule.DestinationPortRange = &port
+	return rule
+}
+
+func Test_Evaluate(t *testing.T) {
+	nsg := &network.SecurityGroup{
+		SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+			SecurityRules: &[]network.SecurityRule{
+				getTestFlowRule("allow-web", 200, network.SecurityRuleAccessAllow, "*", "80-443"),
+				getTestFlowRule("deny-lab", 100, network.SecurityRuleAccessDeny, "10.1.0.0/16", "*"),
+				getTestFlowRule("allow-vnet", 150, network.SecurityRuleAccessAllow, "VirtualNetwork", "22"),
+			},
+		},
+	}
+	flow := Flow{
+		Direction:   network.SecurityRuleDirectionInbound,
+		Source:      "10.1.2.3",
+		Destination: "10.0.0.4",
+		Protocol:    network.SecurityRuleProtocolTCP,
+		Port:        443,
+	}
+
+	verdict, err := Evaluate(nsg, flow)
+	assert.NoError(t, err)
+	assert.Equal(t, network.SecurityRuleAccessDeny, verdict.Access)
+	assert.Equal(t, "deny-lab", verdict.MatchedRule)
+
+	flow.Source = "10.2.0.1"
+	verdict, err = Evaluate(nsg, flow)
+	assert.NoError(t, err)
+	assert.Equal(t, network.SecurityRuleAccessAllow, verdict.Access)
+	assert.Equal(t, []string{"deny-lab", "allow-web"}, verdict.EvaluatedRules)
+	assert.Equal(t, []string{"allow-vnet"}, verdict.SkippedRules)
+
+	flow.Port = 8080
+	verdict, err = Evaluate(nsg, flow)
+	assert.NoError(t, err)
+	assert.Equal(t, network.SecurityRuleAccessDeny, verdict.Access)
+	assert.Empty(t, verdict.MatchedRule)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package networksecuritygroup
+
+import (
+	"context"
+	"sync"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+var (
+	rulesMux   sync.Mutex
+	rulesLocks = map[string]*sync.Mutex{}
+)
+
+// ReplaceRules replaces the security rules of the network security group with rules, leaving its
+// default rules and tags unchanged
+func (c *NetworkSecurityGroupAgentClient) ReplaceRules(ctx context.Context, location, name string, rules []network.SecurityRule) (*network.SecurityGroup, error) {
+	if err := checkRuleNames(rules); err != nil {
+		return nil, err
+	}
+	return c.updateRules(ctx, location, name, func([]network.SecurityRule) ([]network.SecurityRule, error) {
+		return rules, nil
+	})
+}
+
+// PatchRules adds or replaces the rules in add and removes the rules named in remove from the
+// security rules of the network security group. Names in remove that are not rules of the group
+// are ignored, so a patch can be reapplied.
+func (c *NetworkSecurityGroupAgentClient) PatchRules(ctx context.Context, location, name string, add []network.SecurityRule, remove []string) (*network.SecurityGroup, error) {
+	if err := checkRuleNames(add); err != nil {
+		return nil, err
+	}
+	removed := map[string]bool{}
+	for _, ruleName := range remove {
+		removed[ruleName] = true
+	}
+	for _, rule := range add {
+		if removed[*rule.Name] {
+			return nil, errors.Wrapf(errors.InvalidInput, "Network Security Rule [%s] is both added and removed", *rule.Name)
+		}
+	}
+
+	return c.updateRules(ctx, location, name, func(current []network.SecurityRule) ([]network.SecurityRule, error) {
+		added := map[string]network.SecurityRule{}
+		for _, rule := range add {
+			added[*rule.Name] = rule
+		}
+		rules := []network.SecurityRule{}
+		for _, rule := range current {
+			if rule.Name == nil || removed[*rule.Name] {
+				continue
+			}
+			if replacement, ok := added[*rule.Name]; ok {
+				rule = replacement
+				delete(added, *rule.Name)
+			}
+			rules = append(rules, rule)
+		}
+		for _, rule := range add {
+			if _, ok := added[*rule.Name]; ok {
+				rules = append(rules, rule)
+			}
+		}
+		return rules, nil
+	})
+}
+
+// updateRules applies update to the security rules of the network security group. Updates of the same group
+// are serialized within the process, and the version read is sent back so that the agent rejects the update
+// if the group was changed by another client in between.
+func (c *NetworkSecurityGroupAgentClient) updateRules(ctx context.Context, location, name string, update func([]network.SecurityRule) ([]network.SecurityRule, error)) (*network.SecurityGroup, error) {
+	lock := getRulesLock(location, name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	nsgs, err := c.Get(ctx, location, name)
+	if err != nil {
+		return nil, err
+	}
+	if nsgs == nil || len(*nsgs) == 0 {
+		return nil, errors.Wrapf(errors.NotFound, "Network Security Group [%s] not found", name)
+	}
+	nsg := (*nsgs)[0]
+	if nsg.SecurityGroupPropertiesFormat == nil {
+		nsg.SecurityGroupPropertiesFormat = &network.SecurityGroupPropertiesFormat{}
+	}
+
+	current := []network.SecurityRule{}
+	if nsg.SecurityRules != nil {
+		current = *nsg.SecurityRules
+	}
+	rules, err := update(current)
+	if err != nil {
+		return nil, err
+	}
+	nsg.SecurityRules = &rules
+
+	return c.CreateOrUpdate(ctx, location, name, &nsg)
+}
+
+func getRulesLock(location, name string) *sync.Mutex {
+	rulesMux.Lock()
+	defer rulesMux.Unlock()
+	key := location + "/" + name
+	lock, ok := rulesLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		rulesLocks[key] = lock
+	}
+	return lock
+}
+
+func checkRuleNames(rules []network.SecurityRule) error {
+	names := map[string]bool{}
+	for _, rule := range rules {
+		if rule.Name == nil || len(*rule.Name) == 0 {
+			return errors.Wrapf(errors.InvalidInput, "Network Security Rule name not specified")
+		}
+		if names[*rule.Name] {
+			return errors.Wrapf(errors.InvalidInput, "Network Security Rule [%s] is specified more than once", *rule.Name)
+		}
+		names[*rule.Name] = true
+	}
+	return nil
+}
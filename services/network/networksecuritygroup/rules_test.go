@@ -0,0 +1,78 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package networksecuritygroup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeService struct {
+	nsg network.SecurityGroup
+}
+
+func (f *fakeService) Get(ctx context.Context, location, name string) (*[]network.SecurityGroup, error) {
+	return &[]network.SecurityGroup{f.nsg}, nil
+}
+
+func (f *fakeService) CreateOrUpdate(ctx context.Context, location, name string, nsg *network.SecurityGroup) (*network.SecurityGroup, error) {
+	f.nsg = *nsg
+	return nsg, nil
+}
+
+func (f *fakeService) Delete(ctx context.Context, location, name string) error {
+	return nil
+}
+
+func (f *fakeService) Precheck(ctx context.Context, location string, nsgs []*network.SecurityGroup) (bool, error) {
+	return true, nil
+}
+
+func getTestRule(name string, priority uint32) network.SecurityRule {
+	return network.SecurityRule{
+		Name: &name,
+		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+			Priority: &priority,
+		},
+	}
+}
+
+func getRuleNames(nsg *network.SecurityGroup) []string {
+	names := []string{}
+	for _, rule := range *nsg.SecurityRules {
+		names = append(names, *rule.Name)
+	}
+	return names
+}
+
+func Test_PatchRules(t *testing.T) {
+	name, version := "nsg", "3"
+	fake := &fakeService{nsg: network.SecurityGroup{
+		Name:    &name,
+		Version: &version,
+		SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+			SecurityRules: &[]network.SecurityRule{getTestRule("a", 100), getTestRule("b", 200), getTestRule("c", 300)},
+		},
+	}}
+	c := &NetworkSecurityGroupAgentClient{internal: fake}
+
+	nsg, err := c.PatchRules(context.Background(), "location", name, []network.SecurityRule{getTestRule("b", 250), getTestRule("d", 400)}, []string{"a", "x"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c", "d"}, getRuleNames(nsg))
+	assert.Equal(t, uint32(250), *(*nsg.SecurityRules)[0].Priority)
+	assert.Equal(t, "3", *nsg.Version)
+
+	_, err = c.PatchRules(context.Background(), "location", name, []network.SecurityRule{getTestRule("b", 250)}, []string{"b"})
+	assert.Error(t, err)
+
+	nsg, err = c.ReplaceRules(context.Background(), "location", name, []network.SecurityRule{getTestRule("e", 100)})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"e"}, getRuleNames(nsg))
+
+	_, err = c.ReplaceRules(context.Background(), "location", name, []network.SecurityRule{getTestRule("e", 100), getTestRule("e", 200)})
+	assert.Error(t, err)
+}
@@ -9,11 +9,13 @@ import (
 	"strings"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	"github.com/microsoft/moc/pkg/tags"
 	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
 	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
 )
@@ -198,6 +200,7 @@ func (c *client) getNetworkSecurityGroupsFromResponse(response *wssdcloudnetwork
 		networkdNSGs = append(networkdNSGs, *networkNSG)
 	}
 
+	list.SortResponse(&networkdNSGs)
 	return &networkdNSGs, nil
 }
 
@@ -217,8 +220,13 @@ func getWssdNetworkSecurityGroup(networkNSG *network.SecurityGroup, location str
 		LocationName: location,
 	}
 
-	if networkNSG.Tags != nil {
-		wssdCloudNSG.Tags = tags.MapToProto(networkNSG.Tags)
+	wssdCloudNSG.Tags = conversion.TagsToProto(conversion.NetworkSecurityGroup, networkNSG.Tags)
+
+	if networkNSG.Version != nil {
+		if wssdCloudNSG.Status == nil {
+			wssdCloudNSG.Status = status.InitStatus()
+		}
+		wssdCloudNSG.Status.Version.Number = *networkNSG.Version
 	}
 
 	if networkNSG.SecurityGroupPropertiesFormat != nil {
@@ -349,12 +357,16 @@ func getNetworkSecurityGroup(wssdNSG *wssdcloudnetwork.NetworkSecurityGroup) (ne
 		Location: &wssdNSG.LocationName,
 		ID:       &wssdNSG.Id,
 		SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
-			Statuses: status.GetStatuses(wssdNSG.GetStatus()),
+			Statuses: provisioning.GetStatuses(wssdNSG.GetStatus()),
 		},
 	}
 
+	if wssdNSG.Status != nil && wssdNSG.Status.Version != nil {
+		networkNSG.Version = &wssdNSG.Status.Version.Number
+	}
+
 	if wssdNSG.Tags != nil {
-		networkNSG.Tags = tags.ProtoToMap(wssdNSG.Tags)
+		networkNSG.Tags = conversion.TagsFromProto(conversion.NetworkSecurityGroup, wssdNSG.Tags)
 	}
 
 	if len(wssdNSG.Networksecuritygrouprules) > 0 {
@@ -418,6 +430,8 @@ func getNetworkSecurityGroup(wssdNSG *wssdcloudnetwork.NetworkSecurityGroup) (ne
 				networkNSGRules = append(networkNSGRules, securityRule)
 			}
 		}
+		list.SortResponse(&networkNSGRules)
+		list.SortResponse(&networkDefaultNSGRules)
 		networkNSG.SecurityGroupPropertiesFormat.SecurityRules = &networkNSGRules
 		networkNSG.SecurityGroupPropertiesFormat.DefaultSecurityRules = &networkDefaultNSGRules
 	}
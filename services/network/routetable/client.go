@@ -0,0 +1,142 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package routetable
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/network/logicalnetwork"
+	"github.com/microsoft/moc-sdk-for-go/services/network/virtualnetwork"
+	"github.com/microsoft/moc/pkg/auth"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// RouteTableClient associates route tables with the subnets of virtual and logical networks
+type RouteTableClient struct {
+	network.BaseClient
+	subnets *virtualnetwork.SubnetClient
+	lnets   *logicalnetwork.LogicalNetworkClient
+}
+
+// NewRouteTableClient method returns new client
+func NewRouteTableClient(cloudFQDN string, authorizer auth.Authorizer) (*RouteTableClient, error) {
+	subnets, err := virtualnetwork.NewSubnetClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	lnets, err := logicalnetwork.NewLogicalNetworkClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &RouteTableClient{subnets: subnets, lnets: lnets}, nil
+}
+
+// AssociateVirtualNetworkSubnet replaces the routes of the subnet of the virtual network with those of the route table
+func (c *RouteTableClient) AssociateVirtualNetworkSubnet(ctx context.Context, group, vnetName, subnetName string, table *network.RouteTable) error {
+	associated, err := getAssociatedTable(table)
+	if err != nil {
+		return err
+	}
+	_, err = c.subnets.Update(ctx, group, vnetName, subnetName, func(subnet *network.Subnet) error {
+		if subnet.SubnetPropertiesFormat == nil {
+			subnet.SubnetPropertiesFormat = &network.SubnetPropertiesFormat{}
+		}
+		subnet.RouteTable = associated
+		return nil
+	})
+	return err
+}
+
+// DissociateVirtualNetworkSubnet removes the routes of the subnet of the virtual network
+func (c *RouteTableClient) DissociateVirtualNetworkSubnet(ctx context.Context, group, vnetName, subnetName string) error {
+	_, err := c.subnets.Update(ctx, group, vnetName, subnetName, func(subnet *network.Subnet) error {
+		if subnet.SubnetPropertiesFormat != nil {
+			subnet.RouteTable = nil
+		}
+		return nil
+	})
+	return err
+}
+
+// AssociateLogicalNetworkSubnet replaces the routes of the subnet of the logical network with those of the route table
+func (c *RouteTableClient) AssociateLogicalNetworkSubnet(ctx context.Context, location, lnetName, subnetName string, table *network.RouteTable) error {
+	associated, err := getAssociatedTable(table)
+	if err != nil {
+		return err
+	}
+	return c.updateLogicalSubnet(ctx, location, lnetName, subnetName, func(subnet *network.LogicalSubnet) {
+		if subnet.LogicalSubnetPropertiesFormat == nil {
+			subnet.LogicalSubnetPropertiesFormat = &network.LogicalSubnetPropertiesFormat{}
+		}
+		subnet.RouteTable = associated
+	})
+}
+
+// DissociateLogicalNetworkSubnet removes the routes of the subnet of the logical network
+func (c *RouteTableClient) DissociateLogicalNetworkSubnet(ctx context.Context, location, lnetName, subnetName string) error {
+	return c.updateLogicalSubnet(ctx, location, lnetName, subnetName, func(subnet *network.LogicalSubnet) {
+		if subnet.LogicalSubnetPropertiesFormat != nil {
+			subnet.RouteTable = nil
+		}
+	})
+}
+
+// updateLogicalSubnet applies update to the subnet of the logical network, retrying on a stale version
+func (c *RouteTableClient) updateLogicalSubnet(ctx context.Context, location, lnetName, subnetName string, update func(*network.LogicalSubnet)) error {
+	location = moc.Location(ctx, location)
+	for {
+		lnets, err := c.lnets.Get(ctx, location, lnetName)
+		if err != nil {
+			return err
+		}
+		if lnets == nil || len(*lnets) == 0 {
+			return errors.Wrapf(errors.NotFound, "Logical Network [%s] not found", lnetName)
+		}
+		lnet := &(*lnets)[0]
+
+		found := false
+		if lnet.LogicalNetworkPropertiesFormat != nil && lnet.Subnets != nil {
+			for i := range *lnet.Subnets {
+				subnet := &(*lnet.Subnets)[i]
+				if subnet.Name != nil && strings.EqualFold(*subnet.Name, subnetName) {
+					update(subnet)
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return errors.Wrapf(errors.NotFound, "Subnet [%s] not found in logical network [%s]", subnetName, lnetName)
+		}
+
+		_, err = c.lnets.CreateOrUpdate(ctx, location, lnetName, lnet)
+		if err != nil {
+			if errors.IsInvalidVersion(err) && ctx.Err() == nil {
+				// Retry only on invalid version
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// getAssociatedTable validates the route table and returns the copy stored on a subnet
+func getAssociatedTable(table *network.RouteTable) (*network.RouteTable, error) {
+	if table == nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "Missing route table")
+	}
+	if err := Validate(table); err != nil {
+		return nil, err
+	}
+	associated := *table
+	routes := getRoutes(table)
+	associated.RouteTablePropertiesFormat = &network.RouteTablePropertiesFormat{Routes: &routes}
+	return &associated, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+// Package routetable manages route tables and their association with the subnets of virtual and logical networks.
+// The agent has no route table resource: the routes of a route table are stored on each subnet it is associated
+// with, so a route table is built on the client and associated again after its routes change.
+package routetable
+
+import (
+	"net"
+	"strings"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// ValidateRoute checks that the route has a name, a destination CIDR and a next hop ip address of the same family
+func ValidateRoute(route *network.Route) error {
+	if route == nil || route.Name == nil || len(*route.Name) == 0 {
+		return errors.Wrapf(errors.InvalidInput, "Missing Name for route")
+	}
+	if route.RoutePropertiesFormat == nil {
+		return errors.Wrapf(errors.InvalidInput, "Missing properties for route [%s]", *route.Name)
+	}
+	if route.AddressPrefix == nil {
+		return errors.Wrapf(errors.InvalidInput, "Missing AddressPrefix for route [%s]", *route.Name)
+	}
+	_, prefix, err := net.ParseCIDR(*route.AddressPrefix)
+	if err != nil {
+		return errors.Wrapf(errors.InvalidInput, "Route [%s] address prefix [%s] is not a valid CIDR", *route.Name, *route.AddressPrefix)
+	}
+	if route.NextHopIPAddress == nil {
+		return errors.Wrapf(errors.InvalidInput, "Missing NextHopIPAddress for route [%s]", *route.Name)
+	}
+	nextHop := net.ParseIP(*route.NextHopIPAddress)
+	if nextHop == nil {
+		return errors.Wrapf(errors.InvalidInput, "Route [%s] next hop [%s] is not a valid IP address", *route.Name, *route.NextHopIPAddress)
+	}
+	if (nextHop.To4() == nil) != (prefix.IP.To4() == nil) {
+		return errors.Wrapf(errors.InvalidInput, "Route [%s] next hop [%s] and address prefix [%s] are of different address families", *route.Name, *route.NextHopIPAddress, *route.AddressPrefix)
+	}
+	return nil
+}
+
+// Validate checks the routes of the route table and that no two routes share a name or an address prefix
+func Validate(table *network.RouteTable) error {
+	names := map[string]bool{}
+	prefixes := map[string]string{}
+	for _, route := range getRoutes(table) {
+		if err := ValidateRoute(&route); err != nil {
+			return err
+		}
+		name := strings.ToLower(*route.Name)
+		if names[name] {
+			return errors.Wrapf(errors.InvalidInput, "Duplicate route [%s]", *route.Name)
+		}
+		names[name] = true
+		_, prefix, _ := net.ParseCIDR(*route.AddressPrefix)
+		if other, ok := prefixes[prefix.String()]; ok {
+			return errors.Wrapf(errors.InvalidInput, "Routes [%s] and [%s] have the same address prefix [%s]", other, *route.Name, prefix)
+		}
+		prefixes[prefix.String()] = *route.Name
+	}
+	return nil
+}
+
+// GetRoute returns the route of the route table
+func GetRoute(table *network.RouteTable, name string) (*network.Route, error) {
+	routes := getRoutes(table)
+	if i := findRoute(routes, name); i >= 0 {
+		return &routes[i], nil
+	}
+	return nil, errors.Wrapf(errors.NotFound, "Route [%s] not found", name)
+}
+
+// CreateOrUpdateRoute adds the route to the route table, or replaces the route of the same name
+func CreateOrUpdateRoute(table *network.RouteTable, name string, route *network.Route) error {
+	if table == nil {
+		return errors.Wrapf(errors.InvalidInput, "Missing route table")
+	}
+	if route == nil {
+		return errors.Wrapf(errors.InvalidInput, "Missing route [%s]", name)
+	}
+	updated := *route
+	updated.Name = &name
+	if err := ValidateRoute(&updated); err != nil {
+		return err
+	}
+
+	routes := getRoutes(table)
+	if i := findRoute(routes, name); i >= 0 {
+		routes[i] = updated
+	} else {
+		routes = append(routes, updated)
+	}
+	candidate := network.RouteTable{RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{Routes: &routes}}
+	if err := Validate(&candidate); err != nil {
+		return err
+	}
+	setRoutes(table, routes)
+	return nil
+}
+
+// DeleteRoute removes the route from the route table
+func DeleteRoute(table *network.RouteTable, name string) error {
+	routes := getRoutes(table)
+	i := findRoute(routes, name)
+	if i < 0 {
+		return errors.Wrapf(errors.NotFound, "Route [%s] not found", name)
+	}
+	setRoutes(table, append(routes[:i], routes[i+1:]...))
+	return nil
+}
+
+// getRoutes returns a copy of the routes of the route table
+func getRoutes(table *network.RouteTable) []network.Route {
+	routes := []network.Route{}
+	if table != nil && table.RouteTablePropertiesFormat != nil && table.Routes != nil {
+		routes = append(routes, *table.Routes...)
+	}
+	return routes
+}
+
+func setRoutes(table *network.RouteTable, routes []network.Route) {
+	if table.RouteTablePropertiesFormat == nil {
+		table.RouteTablePropertiesFormat = &network.RouteTablePropertiesFormat{}
+	}
+	table.Routes = &routes
+}
+
+func findRoute(routes []network.Route, name string) int {
+	for i, route := range routes {
+		if route.Name != nil && strings.EqualFold(*route.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
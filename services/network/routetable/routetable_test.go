@@ -0,0 +1,38 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package routetable
+
+import (
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRoute(prefix, nextHop string) *network.Route {
+	return &network.Route{RoutePropertiesFormat: &network.RoutePropertiesFormat{AddressPrefix: &prefix, NextHopIPAddress: &nextHop}}
+}
+
+func Test_CreateOrUpdateRoute(t *testing.T) {
+	table := &network.RouteTable{}
+	assert.NoError(t, CreateOrUpdateRoute(table, "default", newRoute("0.0.0.0/0", "10.0.0.1")))
+	assert.NoError(t, CreateOrUpdateRoute(table, "onprem", newRoute("192.168.0.0/16", "10.0.0.4")))
+	assert.Equal(t, 2, len(*table.Routes))
+
+	assert.NoError(t, CreateOrUpdateRoute(table, "default", newRoute("0.0.0.0/0", "10.0.0.2")))
+	route, err := GetRoute(table, "DEFAULT")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", *route.NextHopIPAddress)
+
+	assert.True(t, errors.IsInvalidInput(CreateOrUpdateRoute(table, "dup", newRoute("192.168.0.0/16", "10.0.0.5"))))
+	assert.True(t, errors.IsInvalidInput(CreateOrUpdateRoute(table, "v6", newRoute("fd00::/64", "10.0.0.5"))))
+	assert.True(t, errors.IsInvalidInput(CreateOrUpdateRoute(table, "bad", newRoute("10.0.0.0", "10.0.0.5"))))
+	assert.Equal(t, 2, len(*table.Routes))
+
+	assert.NoError(t, DeleteRoute(table, "onprem"))
+	assert.True(t, errors.IsNotFound(DeleteRoute(table, "onprem")))
+	_, err = GetRoute(table, "onprem")
+	assert.True(t, errors.IsNotFound(err))
+}
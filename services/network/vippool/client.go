@@ -6,6 +6,12 @@ package vippool
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -36,21 +42,67 @@ func NewVipPoolClient(cloudFQDN string, authorizer auth.Authorizer) (*VipPoolCli
 
 // Get methods invokes the client Get method
 func (c *VipPoolClient) Get(ctx context.Context, location, name string) (*[]network.VipPool, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *VipPoolClient) List(ctx context.Context, location string, opts ...list.Options) (*[]network.VipPool, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *VipPoolClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]network.VipPool] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]network.VipPool, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *VipPoolClient) NewLister(location string) *list.Lister[network.VipPool] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]network.VipPool, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *VipPoolClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]network.VipPool, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/VipPool/{name}, see resourceid.ID
+func (c *VipPoolClient) GetByID(ctx context.Context, id string) (*network.VipPool, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.VipPool, c.Get)
+}
+
 // Ensure methods invokes create or update on the client
 func (c *VipPoolClient) CreateOrUpdate(ctx context.Context, location, name string, vp *network.VipPool) (*network.VipPool, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.CreateOrUpdate(ctx, location, name, vp)
 }
 
 // Delete methods invokes delete of the network resource
 func (c *VipPoolClient) Delete(ctx context.Context, location, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Delete(ctx, location, name)
 }
 
 // Prechecks whether the system is able to create specified resources.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *VipPoolClient) Precheck(ctx context.Context, location string, resources []*network.VipPool) (bool, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckDuplicateNames(resources); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, location, resources)
 }
@@ -7,9 +7,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -186,6 +188,7 @@ func (c *client) getVipPoolsFromResponse(response *wssdcloudnetwork.VipPoolRespo
 		networkVPs = append(networkVPs, *networkVP)
 	}
 
+	list.SortResponse(&networkVPs)
 	return &networkVPs, nil
 }
 
@@ -243,7 +246,7 @@ func getVipPool(wssdVP *wssdcloudnetwork.VipPool) (networkVP *network.VipPool, e
 			IPPrefix: &wssdVP.Cidr,
 			StartIP:  &wssdVP.Startip,
 			EndIP:    &wssdVP.Endip,
-			Statuses: status.GetStatuses(wssdVP.GetStatus()),
+			Statuses: provisioning.GetStatuses(wssdVP.GetStatus()),
 		},
 	}
 
@@ -0,0 +1,90 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualnetwork
+
+import (
+	"context"
+	"strings"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// ExpandAddressSpace adds address prefixes to an existing virtual network, without recreating it. The agent virtual
+// network has no address space of its own, so each prefix is added as a subnet named by network.AddressPrefixSubnetName,
+// with the vlan and allocation method of the first subnet of the network. Prefixes already in the network are skipped,
+// and the others must not overlap the subnets of any virtual network in the group.
+func (c *VirtualNetworkClient) ExpandAddressSpace(ctx context.Context, group, networkName string, additionalPrefixes []string) (*network.VirtualNetwork, error) {
+	group = moc.Group(ctx, group)
+	vnets, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var vnet *network.VirtualNetwork
+	existing := []string{}
+	for i := range *vnets {
+		if (*vnets)[i].Name != nil && strings.EqualFold(*(*vnets)[i].Name, networkName) {
+			vnet = &(*vnets)[i]
+		}
+		existing = append(existing, getAddressPrefixes(&(*vnets)[i])...)
+	}
+	if vnet == nil {
+		return nil, errors.Wrapf(errors.NotFound, "Virtual network [%s] not found", networkName)
+	}
+
+	own := map[string]bool{}
+	for _, prefix := range getAddressPrefixes(vnet) {
+		own[prefix] = true
+	}
+	added := []string{}
+	for _, prefix := range additionalPrefixes {
+		if !own[prefix] {
+			added = append(added, prefix)
+		}
+	}
+	if len(added) == 0 {
+		return vnet, nil
+	}
+	if err := network.CheckAddressPrefixes(existing, added); err != nil {
+		return nil, err
+	}
+
+	if vnet.VirtualNetworkPropertiesFormat == nil {
+		vnet.VirtualNetworkPropertiesFormat = &network.VirtualNetworkPropertiesFormat{}
+	}
+	subnets := []network.Subnet{}
+	if vnet.Subnets != nil {
+		subnets = *vnet.Subnets
+	}
+	template := network.SubnetPropertiesFormat{}
+	if len(subnets) > 0 && subnets[0].SubnetPropertiesFormat != nil {
+		template.Vlan = subnets[0].Vlan
+		template.IPAllocationMethod = subnets[0].IPAllocationMethod
+	}
+	for _, prefix := range added {
+		name := network.AddressPrefixSubnetName(networkName, prefix)
+		addressPrefix := prefix
+		props := template
+		props.AddressPrefix = &addressPrefix
+		subnets = append(subnets, network.Subnet{Name: &name, SubnetPropertiesFormat: &props})
+	}
+	vnet.Subnets = &subnets
+
+	return c.internal.CreateOrUpdate(ctx, group, networkName, vnet)
+}
+
+func getAddressPrefixes(vnet *network.VirtualNetwork) []string {
+	prefixes := []string{}
+	if vnet.VirtualNetworkPropertiesFormat == nil || vnet.Subnets == nil {
+		return prefixes
+	}
+	for _, subnet := range *vnet.Subnets {
+		if subnet.SubnetPropertiesFormat != nil && subnet.AddressPrefix != nil {
+			prefixes = append(prefixes, *subnet.AddressPrefix)
+		}
+	}
+	return prefixes
+}
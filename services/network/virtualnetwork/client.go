@@ -6,8 +6,17 @@ package virtualnetwork
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
+	cloudgroup "github.com/microsoft/moc-sdk-for-go/services/cloud/group"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/network/internal/ipconflict"
 	"github.com/microsoft/moc/pkg/auth"
+	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
 )
 
 // Service interface
@@ -21,7 +30,9 @@ type Service interface {
 // Client structure
 type VirtualNetworkClient struct {
 	network.BaseClient
-	internal Service
+	internal  Service
+	ipchecker *ipconflict.Checker
+	groups    *cloudgroup.GroupClient
 }
 
 // NewClient method returns new client
@@ -31,26 +42,113 @@ func NewVirtualNetworkClient(cloudFQDN string, authorizer auth.Authorizer) (*Vir
 		return nil, err
 	}
 
-	return &VirtualNetworkClient{internal: c}, nil
+	ipchecker, err := ipconflict.NewChecker(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := cloudgroup.NewGroupClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VirtualNetworkClient{internal: c, ipchecker: ipchecker, groups: groups}, nil
 }
 
 // Get methods invokes the client Get method
 func (c *VirtualNetworkClient) Get(ctx context.Context, group, name string) (*[]network.VirtualNetwork, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *VirtualNetworkClient) List(ctx context.Context, group string, opts ...list.Options) (*[]network.VirtualNetwork, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *VirtualNetworkClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]network.VirtualNetwork] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]network.VirtualNetwork, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *VirtualNetworkClient) NewLister(group string) *list.Lister[network.VirtualNetwork] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]network.VirtualNetwork, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *VirtualNetworkClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]network.VirtualNetwork, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/VirtualNetwork/{name}, see resourceid.ID
+func (c *VirtualNetworkClient) GetByID(ctx context.Context, id string) (*network.VirtualNetwork, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.VirtualNetwork, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *VirtualNetworkClient) CreateOrUpdate(ctx context.Context, group, name string, network *network.VirtualNetwork) (*network.VirtualNetwork, error) {
+	group = moc.Group(ctx, group)
+	if err := Validate(network); err != nil {
+		return nil, err
+	}
+	network, err := c.withGroupDefaults(ctx, group, name, network)
+	if err != nil {
+		return nil, err
+	}
 	return c.internal.CreateOrUpdate(ctx, group, name, network)
 }
 
 // Delete methods invokes delete of the network resource
 func (c *VirtualNetworkClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
 
 // Prechecks whether the system is able to create specified resources.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *VirtualNetworkClient) Precheck(ctx context.Context, group string, virtualNetworks []*network.VirtualNetwork) (bool, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckDuplicateNames(virtualNetworks); err != nil {
+		return false, err
+	}
+	for _, vnet := range virtualNetworks {
+		if err := Validate(vnet); err != nil {
+			return false, err
+		}
+	}
 	return c.internal.Precheck(ctx, group, virtualNetworks)
 }
+
+// Raw returns the underlying agent client, giving access to agent fields not yet
+// exposed by the sdk. Returns nil if the client is not backed by the agent.
+func (c *VirtualNetworkClient) Raw() wssdcloudnetwork.VirtualNetworkAgentClient {
+	if rc, ok := c.internal.(*client); ok {
+		return rc.VirtualNetworkAgentClient
+	}
+	return nil
+}
+
+// ToProto converts a virtual network to the message sent to the agent
+func ToProto(vnet *network.VirtualNetwork, group string) (*wssdcloudnetwork.VirtualNetwork, error) {
+	return getWssdVirtualNetwork(vnet, group)
+}
+
+// FromProto converts a virtual network message returned by the agent
+func FromProto(vnet *wssdcloudnetwork.VirtualNetwork, group string) *network.VirtualNetwork {
+	return getVirtualNetwork(vnet, group)
+}
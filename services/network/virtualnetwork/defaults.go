@@ -0,0 +1,68 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualnetwork
+
+import (
+	"context"
+
+	cloudgroup "github.com/microsoft/moc-sdk-for-go/services/cloud/group"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// withGroupDefaults returns the virtual network with the default network security group and route table of the group
+// associated with the new subnets that do not specify their own. Subnets that already exist are left as they are.
+// The virtual network of the caller is not changed.
+func (c *VirtualNetworkClient) withGroupDefaults(ctx context.Context, group, name string, vnet *network.VirtualNetwork) (*network.VirtualNetwork, error) {
+	if vnet == nil || vnet.VirtualNetworkPropertiesFormat == nil || vnet.Subnets == nil || len(*vnet.Subnets) == 0 {
+		return vnet, nil
+	}
+
+	location := ""
+	if vnet.Location != nil {
+		location = *vnet.Location
+	}
+	defaults, err := c.groups.LookupNetworkDefaults(ctx, location, group)
+	if err != nil {
+		return nil, err
+	}
+	if defaults.IsEmpty() {
+		return vnet, nil
+	}
+
+	existingSubnets := []network.Subnet{}
+	existing, err := c.internal.Get(ctx, group, name)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && existing != nil && len(*existing) > 0 {
+		if props := (*existing)[0].VirtualNetworkPropertiesFormat; props != nil && props.Subnets != nil {
+			existingSubnets = *props.Subnets
+		}
+	}
+
+	return applyGroupDefaults(vnet, existingSubnets, defaults), nil
+}
+
+// applyGroupDefaults returns a copy of the virtual network with the defaults applied to the subnets that are not
+// in existingSubnets
+func applyGroupDefaults(vnet *network.VirtualNetwork, existingSubnets []network.Subnet, defaults *cloudgroup.NetworkDefaults) *network.VirtualNetwork {
+	copied := *vnet
+	props := *vnet.VirtualNetworkPropertiesFormat
+	subnets := append([]network.Subnet{}, *vnet.Subnets...)
+	props.Subnets = &subnets
+	copied.VirtualNetworkPropertiesFormat = &props
+	for i := range subnets {
+		subnet := &subnets[i]
+		if subnet.Name != nil && findSubnet(existingSubnets, *subnet.Name) >= 0 {
+			continue
+		}
+		if subnet.SubnetPropertiesFormat != nil {
+			subnetProps := *subnet.SubnetPropertiesFormat
+			subnet.SubnetPropertiesFormat = &subnetProps
+		}
+		defaults.ApplyToSubnet(subnet)
+	}
+	return &copied
+}
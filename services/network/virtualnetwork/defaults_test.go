@@ -0,0 +1,30 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualnetwork
+
+import (
+	"testing"
+
+	cloudgroup "github.com/microsoft/moc-sdk-for-go/services/cloud/group"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyGroupDefaults(t *testing.T) {
+	existingName, newName := "existing", "new"
+	subnets := []network.Subnet{
+		{Name: &existingName, SubnetPropertiesFormat: &network.SubnetPropertiesFormat{}},
+		{Name: &newName, SubnetPropertiesFormat: &network.SubnetPropertiesFormat{}},
+	}
+	vnet := &network.VirtualNetwork{VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{Subnets: &subnets}}
+	defaults := &cloudgroup.NetworkDefaults{NetworkSecurityGroup: "baseline"}
+
+	applied := applyGroupDefaults(vnet, []network.Subnet{{Name: &existingName}}, defaults)
+	assert.Nil(t, (*applied.Subnets)[0].NetworkSecurityGroup)
+	assert.Equal(t, "baseline", *(*applied.Subnets)[1].NetworkSecurityGroup.ID)
+
+	// The virtual network of the caller is not changed
+	assert.Nil(t, subnets[1].NetworkSecurityGroup)
+	assert.Equal(t, &subnets, vnet.Subnets)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualnetwork
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"strings"
+
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// MaxAvailableIPAddresses is the number of available addresses near a taken address returned by
+// CheckIPAddressAvailability
+const MaxAvailableIPAddresses = 5
+
+// maxAvailabilitySearch bounds the addresses tried on each side of a taken address
+const maxAvailabilitySearch = 1024
+
+// CheckIPAddressAvailability returns whether the private ip address is free in the subnet of the virtual network and,
+// if it is not, up to MaxAvailableIPAddresses free addresses nearest to it. An address is free if it is in a vm ip
+// pool of the subnet, or in the subnet when it has no vm ip pool, and no network interface or load balancer of the
+// location uses it on the virtual network.
+func (c *VirtualNetworkClient) CheckIPAddressAvailability(ctx context.Context, group, vnetName, subnetName, ipAddress string) (*network.IPAddressAvailabilityResult, error) {
+	group = moc.Group(ctx, group)
+	if c.ipchecker == nil {
+		return nil, errors.Wrapf(errors.NotSupported, "IP address availability requires the agent")
+	}
+	vnets, err := c.Get(ctx, group, vnetName)
+	if err != nil {
+		return nil, err
+	}
+	if vnets == nil || len(*vnets) == 0 {
+		return nil, errors.Wrapf(errors.NotFound, "Virtual Network [%s] not found", vnetName)
+	}
+	vnet := &(*vnets)[0]
+
+	var subnet *network.Subnet
+	if vnet.VirtualNetworkPropertiesFormat != nil && vnet.Subnets != nil {
+		if i := findSubnet(*vnet.Subnets, subnetName); i >= 0 {
+			subnet = &(*vnet.Subnets)[i]
+		}
+	}
+	if subnet == nil || subnet.SubnetPropertiesFormat == nil || subnet.AddressPrefix == nil {
+		return nil, errors.Wrapf(errors.NotFound, "Subnet [%s] with an address prefix not found in virtual network [%s]", subnetName, vnetName)
+	}
+
+	inUse, err := c.ipchecker.InUse(ctx, group, vnetName)
+	if err != nil {
+		return nil, err
+	}
+	return getIPAddressAvailability(*subnet.AddressPrefix, subnet.IPPools, inUse, ipAddress)
+}
+
+type ipRange struct {
+	start, end *big.Int
+}
+
+func getIPAddressAvailability(addressPrefix string, pools []network.IPPool, inUse map[string]bool, ipAddress string) (*network.IPAddressAvailabilityResult, error) {
+	_, cidr, err := net.ParseCIDR(addressPrefix)
+	if err != nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "Subnet address prefix [%s] is not a valid CIDR", addressPrefix)
+	}
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return nil, errors.Wrapf(errors.InvalidInput, "[%s] is not an ip address", ipAddress)
+	}
+	if !cidr.Contains(ip) {
+		return nil, errors.Wrapf(errors.InvalidInput, "IP address [%s] is not in subnet [%s]", ipAddress, addressPrefix)
+	}
+
+	ranges := getAllocatableRanges(cidr, pools)
+	isFree := func(n *big.Int) bool {
+		for _, r := range ranges {
+			if n.Cmp(r.start) >= 0 && n.Cmp(r.end) <= 0 {
+				return !inUse[toIP(n, ip).String()]
+			}
+		}
+		return false
+	}
+
+	target := toInt(ip)
+	available := isFree(target)
+	result := &network.IPAddressAvailabilityResult{Available: &available}
+	if available {
+		return result, nil
+	}
+
+	nearby := []string{}
+	one := big.NewInt(1)
+	above, below := new(big.Int).Set(target), new(big.Int).Set(target)
+	for i := 0; i < maxAvailabilitySearch && len(nearby) < MaxAvailableIPAddresses; i++ {
+		above.Add(above, one)
+		if isFree(above) {
+			nearby = append(nearby, toIP(above, ip).String())
+		}
+		below.Sub(below, one)
+		if len(nearby) < MaxAvailableIPAddresses && below.Sign() >= 0 && isFree(below) {
+			nearby = append(nearby, toIP(below, ip).String())
+		}
+	}
+	result.AvailableIPAddresses = &nearby
+	return result, nil
+}
+
+// getAllocatableRanges returns the vm ip pools of the subnet, or the subnet without its network and broadcast
+// addresses if it has no vm ip pool
+func getAllocatableRanges(cidr *net.IPNet, pools []network.IPPool) []ipRange {
+	ranges := []ipRange{}
+	for _, pool := range pools {
+		if len(pool.Type) > 0 && !strings.EqualFold(string(pool.Type), string(network.VM)) {
+			continue
+		}
+		start, end := net.ParseIP(pool.Start), net.ParseIP(pool.End)
+		if start == nil || end == nil {
+			continue
+		}
+		ranges = append(ranges, ipRange{start: toInt(start), end: toInt(end)})
+	}
+	if len(ranges) > 0 {
+		return ranges
+	}
+
+	first := toInt(cidr.IP)
+	ones, bits := cidr.Mask.Size()
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	last := new(big.Int).Add(first, size)
+	last.Sub(last, big.NewInt(1))
+	if bits == 32 && bits-ones >= 2 {
+		first.Add(first, big.NewInt(1))
+		last.Sub(last, big.NewInt(1))
+	}
+	return []ipRange{{start: first, end: last}}
+}
+
+// toInt returns the ip address as an integer, in 4 bytes for IPv4 addresses
+func toInt(ip net.IP) *big.Int {
+	if ip4 := ip.To4(); ip4 != nil {
+		return new(big.Int).SetBytes(ip4)
+	}
+	return new(big.Int).SetBytes(ip.To16())
+}
+
+// toIP returns the integer as an ip address of the family of like
+func toIP(n *big.Int, like net.IP) net.IP {
+	size := net.IPv6len
+	if like.To4() != nil {
+		size = net.IPv4len
+	}
+	b := n.Bytes()
+	ip := make(net.IP, size)
+	copy(ip[size-len(b):], b)
+	return ip
+}
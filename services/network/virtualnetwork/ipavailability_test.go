@@ -0,0 +1,42 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualnetwork
+
+import (
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getIPAddressAvailability(t *testing.T) {
+	inUse := map[string]bool{"10.0.0.10": true, "10.0.0.11": true, "10.0.0.9": true}
+
+	result, err := getIPAddressAvailability("10.0.0.0/24", nil, inUse, "10.0.0.20")
+	assert.NoError(t, err)
+	assert.True(t, *result.Available)
+
+	result, err = getIPAddressAvailability("10.0.0.0/24", nil, inUse, "10.0.0.10")
+	assert.NoError(t, err)
+	assert.False(t, *result.Available)
+	assert.Equal(t, []string{"10.0.0.12", "10.0.0.8", "10.0.0.13", "10.0.0.7", "10.0.0.14"}, *result.AvailableIPAddresses)
+
+	result, err = getIPAddressAvailability("10.0.0.0/24", nil, inUse, "10.0.0.0")
+	assert.NoError(t, err)
+	assert.False(t, *result.Available)
+
+	pools := []network.IPPool{{Type: network.VM, Start: "10.0.0.10", End: "10.0.0.12"}}
+	result, err = getIPAddressAvailability("10.0.0.0/24", pools, inUse, "10.0.0.11")
+	assert.NoError(t, err)
+	assert.False(t, *result.Available)
+	assert.Equal(t, []string{"10.0.0.12"}, *result.AvailableIPAddresses)
+
+	result, err = getIPAddressAvailability("fd00::/64", nil, map[string]bool{"fd00::5": true}, "fd00::5")
+	assert.NoError(t, err)
+	assert.Equal(t, "fd00::6", (*result.AvailableIPAddresses)[0])
+
+	_, err = getIPAddressAvailability("10.0.0.0/24", nil, inUse, "10.0.1.10")
+	assert.True(t, errors.IsInvalidInput(err))
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualnetwork
+
+import (
+	"context"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// AssociateNSG applies the network security group to the subnet of the virtual network, leaving the rest
+// of the virtual network unchanged
+func (c *VirtualNetworkClient) AssociateNSG(ctx context.Context, group, name, subnetName, nsgName string) (*network.VirtualNetwork, error) {
+	if len(nsgName) == 0 {
+		return nil, errors.Wrapf(errors.InvalidInput, "Network Security Group name not specified")
+	}
+	return c.setSubnetNSG(ctx, group, name, subnetName, &network.SubResource{ID: &nsgName})
+}
+
+// DisassociateNSG removes the network security group applied to the subnet of the virtual network
+func (c *VirtualNetworkClient) DisassociateNSG(ctx context.Context, group, name, subnetName string) (*network.VirtualNetwork, error) {
+	return c.setSubnetNSG(ctx, group, name, subnetName, nil)
+}
+
+// setSubnetNSG sets the network security group of the subnet through the subnet client, so that a virtual network
+// changed since it was read is read again rather than overwritten
+func (c *VirtualNetworkClient) setSubnetNSG(ctx context.Context, group, name, subnetName string, nsg *network.SubResource) (*network.VirtualNetwork, error) {
+	subnets := &SubnetClient{vnets: c}
+	return subnets.updateSubnets(ctx, group, name, func(subnets []network.Subnet) ([]network.Subnet, error) {
+		i := findSubnet(subnets, subnetName)
+		if i < 0 {
+			return nil, errors.Wrapf(errors.NotFound, "Subnet [%s] of Virtual Network [%s] not found", subnetName, name)
+		}
+		if subnets[i].SubnetPropertiesFormat == nil {
+			subnets[i].SubnetPropertiesFormat = &network.SubnetPropertiesFormat{}
+		}
+		subnets[i].NetworkSecurityGroup = nsg
+		return subnets, nil
+	})
+}
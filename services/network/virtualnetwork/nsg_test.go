@@ -0,0 +1,92 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualnetwork
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeVirtualNetworkService stores a single virtual network and rejects writes of a stale version. The first
+// conflicts writes add a subnet before being rejected, as if another writer had updated the virtual network.
+type fakeVirtualNetworkService struct {
+	Service
+	vnet      network.VirtualNetwork
+	conflicts int
+	puts      int
+}
+
+func (f *fakeVirtualNetworkService) Get(ctx context.Context, group, name string) (*[]network.VirtualNetwork, error) {
+	if *f.vnet.Name != name {
+		return nil, errors.Wrapf(errors.NotFound, "Virtual Network [%s] not found", name)
+	}
+	data, err := json.Marshal(f.vnet)
+	if err != nil {
+		return nil, err
+	}
+	vnet := network.VirtualNetwork{}
+	if err := json.Unmarshal(data, &vnet); err != nil {
+		return nil, err
+	}
+	return &[]network.VirtualNetwork{vnet}, nil
+}
+
+func (f *fakeVirtualNetworkService) CreateOrUpdate(ctx context.Context, group, name string, vnet *network.VirtualNetwork) (*network.VirtualNetwork, error) {
+	f.puts++
+	if f.conflicts > 0 {
+		f.conflicts--
+		subnetName := "concurrent" + strconv.Itoa(f.puts)
+		*f.vnet.Subnets = append(*f.vnet.Subnets, network.Subnet{Name: &subnetName, SubnetPropertiesFormat: &network.SubnetPropertiesFormat{}})
+		f.bumpVersion()
+	}
+	if *vnet.Version != *f.vnet.Version {
+		return nil, errors.Wrapf(errors.InvalidVersion, "Virtual Network [%s] was updated", name)
+	}
+	f.vnet = *vnet
+	f.bumpVersion()
+	return f.Get(ctx, group, name)
+}
+
+func (f *fakeVirtualNetworkService) bumpVersion() {
+	version, _ := strconv.Atoi(*f.vnet.Version)
+	next := strconv.Itoa(version + 1)
+	f.vnet.Version = &next
+}
+
+func newNSGTestVirtualNetwork() network.VirtualNetwork {
+	name, version, subnetName := "vnet1", "1", "subnet1"
+	subnets := []network.Subnet{{Name: &subnetName, SubnetPropertiesFormat: &network.SubnetPropertiesFormat{}}}
+	return network.VirtualNetwork{
+		Name:                           &name,
+		Version:                        &version,
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{Subnets: &subnets},
+	}
+}
+
+func Test_AssociateNSG(t *testing.T) {
+	fake := &fakeVirtualNetworkService{vnet: newNSGTestVirtualNetwork(), conflicts: 1}
+	c := &VirtualNetworkClient{internal: fake}
+
+	vnet, err := c.AssociateNSG(context.Background(), "group", "vnet1", "subnet1", "nsg1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fake.puts)
+	// The subnet added by the concurrent writer is kept
+	assert.Equal(t, 2, len(*vnet.Subnets))
+	assert.Equal(t, "nsg1", *(*vnet.Subnets)[0].NetworkSecurityGroup.ID)
+
+	vnet, err = c.DisassociateNSG(context.Background(), "group", "vnet1", "subnet1")
+	assert.NoError(t, err)
+	assert.Nil(t, (*vnet.Subnets)[0].NetworkSecurityGroup)
+
+	_, err = c.AssociateNSG(context.Background(), "group", "vnet1", "subnet2", "nsg1")
+	assert.True(t, errors.IsNotFound(err))
+	_, err = c.AssociateNSG(context.Background(), "group", "vnet1", "subnet1", "")
+	assert.True(t, errors.IsInvalidInput(err))
+}
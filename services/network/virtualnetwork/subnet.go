@@ -0,0 +1,170 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualnetwork
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/auth"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// SubnetClient manages the subnets of a virtual network one at a time. Each change reads the virtual network,
+// updates the subnet and writes the virtual network back with the version that was read, retrying from the read
+// if the virtual network was changed in between.
+type SubnetClient struct {
+	vnets *VirtualNetworkClient
+}
+
+// NewSubnetClient method returns new client
+func NewSubnetClient(cloudFQDN string, authorizer auth.Authorizer) (*SubnetClient, error) {
+	vnets, err := NewVirtualNetworkClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &SubnetClient{vnets: vnets}, nil
+}
+
+// List returns the subnets of the virtual network
+func (c *SubnetClient) List(ctx context.Context, group, vnetName string) (*[]network.Subnet, error) {
+	vnet, err := c.getVirtualNetwork(ctx, group, vnetName)
+	if err != nil {
+		return nil, err
+	}
+	subnets := []network.Subnet{}
+	if vnet.VirtualNetworkPropertiesFormat != nil && vnet.Subnets != nil {
+		subnets = *vnet.Subnets
+	}
+	return &subnets, nil
+}
+
+// Get returns the subnet of the virtual network
+func (c *SubnetClient) Get(ctx context.Context, group, vnetName, name string) (*network.Subnet, error) {
+	subnets, err := c.List(ctx, group, vnetName)
+	if err != nil {
+		return nil, err
+	}
+	if i := findSubnet(*subnets, name); i >= 0 {
+		return &(*subnets)[i], nil
+	}
+	return nil, errors.Wrapf(errors.NotFound, "Subnet [%s] not found in virtual network [%s]", name, vnetName)
+}
+
+// CreateOrUpdate adds the subnet to the virtual network, or replaces the subnet of the same name
+func (c *SubnetClient) CreateOrUpdate(ctx context.Context, group, vnetName, name string, subnet *network.Subnet) (*network.Subnet, error) {
+	if subnet == nil || subnet.SubnetPropertiesFormat == nil {
+		return nil, errors.Wrapf(errors.InvalidConfiguration, "Missing Subnet Properties")
+	}
+	updated := *subnet
+	updated.Name = &name
+
+	vnet, err := c.updateSubnets(ctx, group, vnetName, func(subnets []network.Subnet) ([]network.Subnet, error) {
+		if i := findSubnet(subnets, name); i >= 0 {
+			subnets[i] = updated
+			return subnets, nil
+		}
+		return append(subnets, updated), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if vnet.VirtualNetworkPropertiesFormat != nil && vnet.Subnets != nil {
+		if i := findSubnet(*vnet.Subnets, name); i >= 0 {
+			return &(*vnet.Subnets)[i], nil
+		}
+	}
+	return &updated, nil
+}
+
+// Update applies update to the subnet of the virtual network and writes it back
+func (c *SubnetClient) Update(ctx context.Context, group, vnetName, name string, update func(*network.Subnet) error) (*network.Subnet, error) {
+	vnet, err := c.updateSubnets(ctx, group, vnetName, func(subnets []network.Subnet) ([]network.Subnet, error) {
+		i := findSubnet(subnets, name)
+		if i < 0 {
+			return nil, errors.Wrapf(errors.NotFound, "Subnet [%s] not found in virtual network [%s]", name, vnetName)
+		}
+		if err := update(&subnets[i]); err != nil {
+			return nil, err
+		}
+		return subnets, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if vnet.VirtualNetworkPropertiesFormat != nil && vnet.Subnets != nil {
+		if i := findSubnet(*vnet.Subnets, name); i >= 0 {
+			return &(*vnet.Subnets)[i], nil
+		}
+	}
+	return nil, errors.Wrapf(errors.NotFound, "Subnet [%s] not found in virtual network [%s]", name, vnetName)
+}
+
+// Delete removes the subnet from the virtual network
+func (c *SubnetClient) Delete(ctx context.Context, group, vnetName, name string) error {
+	_, err := c.updateSubnets(ctx, group, vnetName, func(subnets []network.Subnet) ([]network.Subnet, error) {
+		i := findSubnet(subnets, name)
+		if i < 0 {
+			return nil, errors.Wrapf(errors.NotFound, "Subnet [%s] not found in virtual network [%s]", name, vnetName)
+		}
+		return append(subnets[:i], subnets[i+1:]...), nil
+	})
+	return err
+}
+
+// updateSubnets applies update to the subnets of the virtual network, retrying on a stale version
+func (c *SubnetClient) updateSubnets(ctx context.Context, group, vnetName string, update func([]network.Subnet) ([]network.Subnet, error)) (*network.VirtualNetwork, error) {
+	for {
+		vnet, err := c.getVirtualNetwork(ctx, group, vnetName)
+		if err != nil {
+			return nil, err
+		}
+		if vnet.VirtualNetworkPropertiesFormat == nil {
+			vnet.VirtualNetworkPropertiesFormat = &network.VirtualNetworkPropertiesFormat{}
+		}
+
+		current := []network.Subnet{}
+		if vnet.Subnets != nil {
+			current = append(current, *vnet.Subnets...)
+		}
+		subnets, err := update(current)
+		if err != nil {
+			return nil, err
+		}
+		vnet.Subnets = &subnets
+
+		result, err := c.vnets.CreateOrUpdate(ctx, group, vnetName, vnet)
+		if err != nil {
+			if errors.IsInvalidVersion(err) && ctx.Err() == nil {
+				// Retry only on invalid version
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return nil, err
+		}
+		return result, nil
+	}
+}
+
+func (c *SubnetClient) getVirtualNetwork(ctx context.Context, group, name string) (*network.VirtualNetwork, error) {
+	vnets, err := c.vnets.Get(ctx, group, name)
+	if err != nil {
+		return nil, err
+	}
+	if vnets == nil || len(*vnets) == 0 {
+		return nil, errors.Wrapf(errors.NotFound, "Virtual Network [%s] not found", name)
+	}
+	return &(*vnets)[0], nil
+}
+
+func findSubnet(subnets []network.Subnet, name string) int {
+	for i, subnet := range subnets {
+		if subnet.Name != nil && strings.EqualFold(*subnet.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,146 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualnetwork
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// FieldError is a problem with one field of a virtual network
+type FieldError struct {
+	// Path - JSON path of the field, e.g. properties.subnets[0].properties.addressPrefix
+	Path string
+	// Detail - What is wrong with the field
+	Detail string
+}
+
+func (e FieldError) String() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Detail)
+}
+
+// ValidationError is returned when the address prefixes of a virtual network are not valid
+type ValidationError struct {
+	// Fields - The fields that are not valid, in the order they appear in the virtual network
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		fields = append(fields, f.String())
+	}
+	return fmt.Sprintf("%s: invalid virtual network: %s", errors.InvalidInput.Error(), strings.Join(fields, "; "))
+}
+
+// Cause allows errors.IsInvalidInput to match the error
+func (e *ValidationError) Cause() error {
+	return errors.InvalidInput
+}
+
+// Unwrap allows errors.Is(err, errors.InvalidInput) to match the error
+func (e *ValidationError) Unwrap() error {
+	return errors.InvalidInput
+}
+
+// IsValidationError returns true if the error is a ValidationError
+func IsValidationError(err error) bool {
+	_, ok := err.(*ValidationError)
+	return ok
+}
+
+type fieldPrefix struct {
+	path string
+	cidr *net.IPNet
+}
+
+// Validate checks that the address prefixes of the virtual network are valid CIDRs, that the subnet prefixes lie
+// within the address space, if one is given, and that no two subnet prefixes overlap. It returns a ValidationError
+// listing every field in error.
+func Validate(vnet *network.VirtualNetwork) error {
+	if vnet == nil || vnet.VirtualNetworkPropertiesFormat == nil {
+		return nil
+	}
+	fields := []FieldError{}
+	props := vnet.VirtualNetworkPropertiesFormat
+
+	space := []fieldPrefix{}
+	if props.AddressSpace != nil && props.AddressSpace.AddressPrefixes != nil {
+		for i, value := range *props.AddressSpace.AddressPrefixes {
+			path := fmt.Sprintf("properties.addressSpace.addressPrefixes[%d]", i)
+			prefix, err := parsePrefix(path, value)
+			if err != nil {
+				fields = append(fields, *err)
+				continue
+			}
+			if other := findOverlap(space, prefix.cidr); other != nil {
+				fields = append(fields, FieldError{path, fmt.Sprintf("address prefix [%s] overlaps %s", value, other.path)})
+				continue
+			}
+			space = append(space, prefix)
+		}
+	}
+
+	subnetPrefixes := []fieldPrefix{}
+	if props.Subnets != nil {
+		for i, subnet := range *props.Subnets {
+			if subnet.SubnetPropertiesFormat == nil || subnet.AddressPrefix == nil || len(*subnet.AddressPrefix) == 0 {
+				continue
+			}
+			path := fmt.Sprintf("properties.subnets[%d].properties.addressPrefix", i)
+			value := *subnet.AddressPrefix
+			prefix, err := parsePrefix(path, value)
+			if err != nil {
+				fields = append(fields, *err)
+				continue
+			}
+			if len(space) > 0 && !withinAddressSpace(space, prefix.cidr) {
+				fields = append(fields, FieldError{path, fmt.Sprintf("address prefix [%s] is outside the address space of the virtual network", value)})
+				continue
+			}
+			if other := findOverlap(subnetPrefixes, prefix.cidr); other != nil {
+				fields = append(fields, FieldError{path, fmt.Sprintf("address prefix [%s] overlaps %s", value, other.path)})
+				continue
+			}
+			subnetPrefixes = append(subnetPrefixes, prefix)
+		}
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
+	}
+	return nil
+}
+
+func parsePrefix(path, value string) (fieldPrefix, *FieldError) {
+	_, cidr, err := net.ParseCIDR(value)
+	if err != nil {
+		return fieldPrefix{}, &FieldError{path, fmt.Sprintf("invalid CIDR [%s]", value)}
+	}
+	return fieldPrefix{path, cidr}, nil
+}
+
+func findOverlap(prefixes []fieldPrefix, cidr *net.IPNet) *fieldPrefix {
+	for i, p := range prefixes {
+		if p.cidr.Contains(cidr.IP) || cidr.Contains(p.cidr.IP) {
+			return &prefixes[i]
+		}
+	}
+	return nil
+}
+
+func withinAddressSpace(space []fieldPrefix, cidr *net.IPNet) bool {
+	ones, bits := cidr.Mask.Size()
+	for _, p := range space {
+		spaceOnes, spaceBits := p.cidr.Mask.Size()
+		if spaceBits == bits && spaceOnes <= ones && p.cidr.Contains(cidr.IP) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualnetwork
+
+import (
+	goerrors "errors"
+	"testing"
+
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newValidateTestNetwork(space []string, subnetPrefixes ...string) *network.VirtualNetwork {
+	subnets := []network.Subnet{}
+	for i := range subnetPrefixes {
+		subnets = append(subnets, network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{AddressPrefix: &subnetPrefixes[i]}})
+	}
+	vnet := &network.VirtualNetwork{
+		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{Subnets: &subnets},
+	}
+	if space != nil {
+		vnet.AddressSpace = &network.AddressSpace{AddressPrefixes: &space}
+	}
+	return vnet
+}
+
+func Test_Validate(t *testing.T) {
+	assert.NoError(t, Validate(newValidateTestNetwork([]string{"10.0.0.0/16"}, "10.0.0.0/24", "10.0.1.0/24")))
+	assert.NoError(t, Validate(newValidateTestNetwork(nil, "10.0.0.0/24", "192.168.0.0/24")))
+
+	err := Validate(newValidateTestNetwork([]string{"10.0.0.0/16", "10.0.0/8"}, "10.0.0.0/24", "10.1.0.0/24", "10.0.0.128/25", "bad"))
+	assert.True(t, IsValidationError(err))
+	assert.True(t, goerrors.Is(err, errors.InvalidInput))
+	fields := err.(*ValidationError).Fields
+	paths := []string{}
+	for _, f := range fields {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{
+		"properties.addressSpace.addressPrefixes[1]",
+		"properties.subnets[1].properties.addressPrefix",
+		"properties.subnets[2].properties.addressPrefix",
+		"properties.subnets[3].properties.addressPrefix",
+	}, paths)
+	assert.Contains(t, fields[2].Detail, "properties.subnets[0].properties.addressPrefix")
+}
@@ -5,10 +5,12 @@ package virtualnetwork
 import (
 	"strings"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	"github.com/microsoft/moc/pkg/tags"
 	wssdcloudnetwork "github.com/microsoft/moc/rpc/cloudagent/network"
 	wssdcommonproto "github.com/microsoft/moc/rpc/common"
 )
@@ -24,7 +26,7 @@ func getWssdVirtualNetwork(c *network.VirtualNetwork, groupName string) (*wssdcl
 	wssdnetwork := &wssdcloudnetwork.VirtualNetwork{
 		Name:      *c.Name,
 		GroupName: groupName,
-		Tags:      tags.MapToProto(c.Tags),
+		Tags:      conversion.TagsToProto(conversion.VirtualNetwork, c.Tags),
 	}
 
 	if c.Version != nil {
@@ -49,11 +51,11 @@ func getWssdVirtualNetwork(c *network.VirtualNetwork, groupName string) (*wssdcl
 			wssdnetwork.MacPoolName = *c.VirtualNetworkPropertiesFormat.MacPoolName
 		}
 
-		if c.DhcpOptions != nil && c.DhcpOptions.DNSServers != nil {
-			wssdnetwork.Dns = &wssdcommonproto.Dns{
-				Servers: *c.DhcpOptions.DNSServers,
-			}
+		dns, err := getWssdDns(c.DhcpOptions)
+		if err != nil {
+			return nil, err
 		}
+		wssdnetwork.Dns = dns
 	}
 
 	if c.Type == nil {
@@ -223,10 +225,6 @@ func getWssdNetworkRoutes(routetable *network.RouteTable) (wssdcloudroutes []*ws
 // Conversion function from wssdcloudnetwork to network
 func getVirtualNetwork(c *wssdcloudnetwork.VirtualNetwork, group string) *network.VirtualNetwork {
 	stringType := virtualNetworkTypeToString(c.Type)
-	dnsservers := []string{}
-	if c.Dns != nil {
-		dnsservers = c.Dns.Servers
-	}
 	return &network.VirtualNetwork{
 		Name:     &c.Name,
 		Location: &c.LocationName,
@@ -235,13 +233,11 @@ func getVirtualNetwork(c *wssdcloudnetwork.VirtualNetwork, group string) *networ
 		Version:  &c.Status.Version.Number,
 		VirtualNetworkPropertiesFormat: &network.VirtualNetworkPropertiesFormat{
 			Subnets:     getNetworkSubnets(c.Subnets),
-			Statuses:    status.GetStatuses(c.GetStatus()),
+			Statuses:    provisioning.GetStatuses(c.GetStatus()),
 			MacPoolName: &c.MacPoolName,
-			DhcpOptions: &network.DhcpOptions{
-				DNSServers: &dnsservers,
-			},
+			DhcpOptions: getDhcpOptions(c.Dns),
 		},
-		Tags: tags.ProtoToMap(c.Tags),
+		Tags: conversion.TagsFromProto(conversion.VirtualNetwork, c.Tags),
 	}
 }
 
@@ -264,6 +260,7 @@ func getNetworkSubnets(wssdsubnets []*wssdcloudnetwork.Subnet) *[]network.Subnet
 		})
 	}
 
+	list.SortResponse(&subnets)
 	return &subnets
 }
 
@@ -347,3 +344,36 @@ func virtualNetworkTypeFromString(vnNetworkString string) (wssdcloudnetwork.Virt
 	}
 	return typevalue, nil
 }
+
+// getWssdDns converts the DHCP options of the network, returning nil if they set neither servers nor a suffix
+func getWssdDns(options *network.DhcpOptions) (*wssdcommonproto.Dns, error) {
+	if options == nil {
+		return nil, nil
+	}
+	if err := network.ValidateDhcpOptions(options); err != nil {
+		return nil, err
+	}
+	if options.DNSServers == nil && (options.DNSSuffix == nil || len(*options.DNSSuffix) == 0) {
+		return nil, nil
+	}
+	dns := &wssdcommonproto.Dns{}
+	if options.DNSServers != nil {
+		dns.Servers = *options.DNSServers
+	}
+	if options.DNSSuffix != nil {
+		dns.Domain = *options.DNSSuffix
+	}
+	return dns, nil
+}
+
+func getDhcpOptions(dns *wssdcommonproto.Dns) *network.DhcpOptions {
+	dnsservers := []string{}
+	if dns != nil {
+		dnsservers = dns.Servers
+	}
+	options := &network.DhcpOptions{DNSServers: &dnsservers}
+	if dns != nil && len(dns.Domain) > 0 {
+		options.DNSSuffix = &dns.Domain
+	}
+	return options
+}
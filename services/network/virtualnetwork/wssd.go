@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/network"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -153,5 +154,6 @@ func getVirtualNetworksFromResponse(response *wssdcloudnetwork.VirtualNetworkRes
 		virtualNetworks = append(virtualNetworks, *(getVirtualNetwork(vnet, group)))
 	}
 
+	list.SortResponse(&virtualNetworks)
 	return &virtualNetworks
 }
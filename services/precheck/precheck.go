@@ -0,0 +1,174 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package precheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/moc-sdk-for-go/services/compute"
+	"github.com/microsoft/moc-sdk-for-go/services/compute/virtualmachine"
+	"github.com/microsoft/moc-sdk-for-go/services/network"
+	"github.com/microsoft/moc-sdk-for-go/services/network/loadbalancer"
+	"github.com/microsoft/moc-sdk-for-go/services/network/networkinterface"
+	"github.com/microsoft/moc-sdk-for-go/services/storage"
+	"github.com/microsoft/moc-sdk-for-go/services/storage/virtualharddisk"
+	"github.com/microsoft/moc/pkg/auth"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// Resources is a set of resources of a group deployed together
+type Resources struct {
+	VirtualMachines   []*compute.VirtualMachine
+	NetworkInterfaces []*network.Interface
+	LoadBalancers     []*network.LoadBalancer
+	// Container - Storage container of the virtual hard disks
+	Container        string
+	VirtualHardDisks []*storage.VirtualHardDisk
+}
+
+// Client prechecks sets of resources of different types
+type Client struct {
+	vmClient  *virtualmachine.VirtualMachineClient
+	nicClient *networkinterface.InterfaceClient
+	lbClient  *loadbalancer.LoadBalancerClient
+	vhdClient *virtualharddisk.VirtualHardDiskClient
+}
+
+// NewPrecheckClient returns a client prechecking sets of resources
+func NewPrecheckClient(cloudFQDN string, authorizer auth.Authorizer) (*Client, error) {
+	vmClient, err := virtualmachine.NewVirtualMachineClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	nicClient, err := networkinterface.NewInterfaceClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	lbClient, err := loadbalancer.NewLoadBalancerClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	vhdClient, err := virtualharddisk.NewVirtualHardDiskClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{vmClient: vmClient, nicClient: nicClient, lbClient: lbClient, vhdClient: vhdClient}, nil
+}
+
+// Precheck checks whether the system is able to create all the resources of the group.
+// The network interfaces and disks referenced by the virtual machines must either be part of the resources
+// or already exist. Each type of resource is then prechecked by the agent, concurrently, as the agent has
+// no precheck spanning several types.
+// Returns true if it is possible; or false with the reasons of all the failed checks in the error message if not.
+func (c *Client) Precheck(ctx context.Context, group string, resources *Resources) (bool, error) {
+	if resources == nil {
+		return false, errors.Wrapf(errors.InvalidInput, "Resources not specified")
+	}
+	if err := c.checkReferences(ctx, group, resources); err != nil {
+		return false, err
+	}
+
+	checks := []func() (bool, error){}
+	if len(resources.VirtualMachines) > 0 {
+		checks = append(checks, func() (bool, error) { return c.vmClient.Precheck(ctx, group, resources.VirtualMachines) })
+	}
+	if len(resources.NetworkInterfaces) > 0 {
+		checks = append(checks, func() (bool, error) { return c.nicClient.Precheck(ctx, group, resources.NetworkInterfaces) })
+	}
+	if len(resources.LoadBalancers) > 0 {
+		checks = append(checks, func() (bool, error) { return c.lbClient.Precheck(ctx, group, resources.LoadBalancers) })
+	}
+	if len(resources.VirtualHardDisks) > 0 {
+		checks = append(checks, func() (bool, error) {
+			return c.vhdClient.Precheck(ctx, group, resources.Container, resources.VirtualHardDisks)
+		})
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mux  sync.Mutex
+		errs []string
+	)
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check func() (bool, error)) {
+			defer wg.Done()
+			ok, err := check()
+			if ok && err == nil {
+				return
+			}
+			if err == nil {
+				err = fmt.Errorf("precheck failed")
+			}
+			mux.Lock()
+			errs = append(errs, err.Error())
+			mux.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return false, errors.New(strings.Join(errs, "; "))
+	}
+	return true, nil
+}
+
+// checkReferences fails if a virtual machine references a network interface or data disk that is neither
+// part of the resources nor already created in the group
+func (c *Client) checkReferences(ctx context.Context, group string, resources *Resources) error {
+	nics := map[string]bool{}
+	for _, nic := range resources.NetworkInterfaces {
+		if nic != nil && nic.Name != nil {
+			nics[*nic.Name] = true
+		}
+	}
+	disks := map[string]bool{}
+	for _, disk := range resources.VirtualHardDisks {
+		if disk != nil && disk.Name != nil {
+			disks[*disk.Name] = true
+		}
+	}
+
+	for _, vm := range resources.VirtualMachines {
+		if vm == nil || vm.VirtualMachineProperties == nil {
+			continue
+		}
+		vmName := ""
+		if vm.Name != nil {
+			vmName = *vm.Name
+		}
+		if vm.NetworkProfile != nil && vm.NetworkProfile.NetworkInterfaces != nil {
+			for _, ref := range *vm.NetworkProfile.NetworkInterfaces {
+				if ref.ID == nil || nics[*ref.ID] {
+					continue
+				}
+				exists, err := c.nicClient.Exists(ctx, group, *ref.ID)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					return errors.Wrapf(errors.NotFound, "Network Interface [%s] of Virtual Machine [%s] is neither in the resources nor in group [%s]", *ref.ID, vmName, group)
+				}
+			}
+		}
+		if vm.StorageProfile != nil && vm.StorageProfile.DataDisks != nil {
+			for _, disk := range *vm.StorageProfile.DataDisks {
+				if disk.Vhd == nil || disk.Vhd.URI == nil || disks[*disk.Vhd.URI] {
+					continue
+				}
+				vhds, err := c.vhdClient.Get(ctx, group, resources.Container, *disk.Vhd.URI)
+				if err != nil && !errors.IsNotFound(err) {
+					return err
+				}
+				if vhds == nil || len(*vhds) == 0 {
+					return errors.Wrapf(errors.NotFound, "Data disk [%s] of Virtual Machine [%s] is neither in the resources nor in group [%s]", *disk.Vhd.URI, vmName, group)
+				}
+			}
+		}
+	}
+	return nil
+}
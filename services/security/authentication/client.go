@@ -6,6 +6,7 @@ package authentication
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -48,10 +49,12 @@ func NewAuthenticationClientAuthMode(cloudFQDN string, loginconfig auth.LoginCon
 
 // Get methods invokes the client Get method
 func (c *AuthenticationClient) Login(ctx context.Context, group string, identity *security.Identity) (*string, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Login(ctx, group, identity)
 }
 
 // Get methods invokes the client Get method
 func (c *AuthenticationClient) LoginWithConfig(ctx context.Context, group string, loginconfig auth.LoginConfig, enableRenewRoutine bool) (*auth.WssdConfig, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.LoginWithConfig(ctx, group, loginconfig, enableRenewRoutine)
 }
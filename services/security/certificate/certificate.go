@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/certs"
 	"github.com/microsoft/moc/pkg/errors"
@@ -27,7 +28,7 @@ func GetCertificate(cert *wssdcloudsecurity.Certificate) *security.Certificate {
 		Attributes: &security.CertificateAttributes{
 			NotBefore: &cert.NotBefore,
 			Expires:   &cert.NotAfter,
-			Statuses:  status.GetStatuses(cert.GetStatus()),
+			Statuses:  provisioning.GetStatuses(cert.GetStatus()),
 		},
 	}
 }
@@ -6,6 +6,12 @@ package certificate
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -38,31 +44,78 @@ func NewCertificateClient(cloudFQDN string, authorizer auth.Authorizer) (*Certif
 
 // Get methods invokes the client Get method
 func (c *CertificateClient) Get(ctx context.Context, group, name string) (*[]security.Certificate, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *CertificateClient) List(ctx context.Context, group string, opts ...list.Options) (*[]security.Certificate, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *CertificateClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]security.Certificate] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]security.Certificate, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *CertificateClient) NewLister(group string) *list.Lister[security.Certificate] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]security.Certificate, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *CertificateClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]security.Certificate, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/Certificate/{name}, see resourceid.ID
+func (c *CertificateClient) GetByID(ctx context.Context, id string) (*security.Certificate, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.Certificate, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *CertificateClient) CreateOrUpdate(ctx context.Context, group, name string, Certificate *security.Certificate) (*security.Certificate, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, Certificate)
 }
 
 // Delete methods invokes delete of the Certificate resource
 func (c *CertificateClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
 
 // Sign methods invokes sign to create a CA-Signed Certificate
 func (c *CertificateClient) Sign(ctx context.Context, group, name string, csr *security.CertificateRequest) (*security.Certificate, string, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Sign(ctx, group, name, csr)
 }
 
 // Renew methods invokes renew to renew signed-certificate
 func (c *CertificateClient) Renew(ctx context.Context, group, name string, csr *security.CertificateRequest) (*security.Certificate, string, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Renew(ctx, group, name, csr)
 }
 
 // Prechecks whether the system is able to create specified resources.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *CertificateClient) Precheck(ctx context.Context, certificates []*security.Certificate) (bool, error) {
+	if err := naming.CheckDuplicateNames(certificates); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, certificates)
 }
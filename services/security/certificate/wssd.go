@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -145,6 +146,7 @@ func getCertificatesFromResponse(response *wssdcloudsecurity.CertificateResponse
 		certs = append(certs, *(GetCertificate(certificates)))
 	}
 
+	list.SortResponse(&certs)
 	return &certs
 }
 
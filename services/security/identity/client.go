@@ -6,6 +6,12 @@ package identity
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -40,41 +46,90 @@ func NewIdentityClient(cloudFQDN string, authorizer auth.Authorizer) (*IdentityC
 
 // Get methods invokes the client Get method
 func (c *IdentityClient) Get(ctx context.Context, group, name string) (*[]security.Identity, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *IdentityClient) List(ctx context.Context, group string, opts ...list.Options) (*[]security.Identity, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *IdentityClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]security.Identity] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]security.Identity, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *IdentityClient) NewLister(group string) *list.Lister[security.Identity] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]security.Identity, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *IdentityClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]security.Identity, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/Identity/{name}, see resourceid.ID
+func (c *IdentityClient) GetByID(ctx context.Context, id string) (*security.Identity, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.Identity, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *IdentityClient) CreateOrUpdate(ctx context.Context, group, name string, identity *security.Identity) (*security.Identity, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, identity)
 }
 
 // Delete methods invokes delete of the Identity resource
 func (c *IdentityClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
 
 // Revoke methods invokes revokes an identity
 func (c *IdentityClient) Revoke(ctx context.Context, group, name string) (*security.Identity, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Revoke(ctx, group, name)
 }
 
 // Rotate methods rotates identity token
 func (c *IdentityClient) Rotate(ctx context.Context, group, name string) (*security.Identity, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Rotate(ctx, group, name)
 }
 
 // CreateCertificate methods invokes creates client certificate for the identity
 func (c *IdentityClient) CreateCertificate(ctx context.Context, group, name string, csr []*security.CertificateRequest) ([]*security.Certificate, string, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateCertificate(ctx, group, name, csr)
 }
 
 // RenewCertificate methods invokes renew client certificate for the identity
 func (c *IdentityClient) RenewCertificate(ctx context.Context, group, name string, csr []*security.CertificateRequest) ([]*security.Certificate, string, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.RenewCertificate(ctx, group, name, csr)
 }
 
 // Prechecks whether the system is able to create specified resources.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *IdentityClient) Precheck(ctx context.Context, identities []*security.Identity) (bool, error) {
+	if err := naming.CheckDuplicateNames(identities); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, identities)
 }
@@ -6,12 +6,13 @@ package identity
 import (
 	"path/filepath"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	wssdcloudtags "github.com/microsoft/moc/pkg/tags"
 	wssdcloudsecurity "github.com/microsoft/moc/rpc/cloudagent/security"
 	wssdcloudcommon "github.com/microsoft/moc/rpc/common"
 )
@@ -41,7 +42,7 @@ func getIdentity(id *wssdcloudsecurity.Identity) *security.Identity {
 		Version:              &id.Status.Version.Number,
 		AuthType:             auth.AuthTypeToLoginType(id.AuthType),
 		IdentityProperties: &security.IdentityProperties{
-			Statuses:      status.GetStatuses(id.GetStatus()),
+			Statuses:      provisioning.GetStatuses(id.GetStatus()),
 			ClientType:    clitype,
 			CloudFqdn:     &id.CloudFqdn,
 			CloudPort:     &id.CloudPort,
@@ -49,7 +50,7 @@ func getIdentity(id *wssdcloudsecurity.Identity) *security.Identity {
 		},
 		AutoRotate:    id.AutoRotate,
 		LoginFilePath: &id.LoginFilePath,
-		Tags:          wssdcloudtags.ProtoToMap(id.Tags),
+		Tags:          conversion.TagsFromProto(conversion.Identity, id.Tags),
 	}
 }
 
@@ -121,7 +122,7 @@ func getWssdIdentity(id *security.Identity) (*wssdcloudsecurity.Identity, error)
 	if id.LoginFilePath != nil {
 		wssdidentity.LoginFilePath = *id.LoginFilePath
 	}
-	wssdidentity.Tags = wssdcloudtags.MapToProto(id.Tags)
+	wssdidentity.Tags = conversion.TagsToProto(conversion.Identity, id.Tags)
 
 	return wssdidentity, nil
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc-sdk-for-go/services/security/certificate"
 	"github.com/microsoft/moc/pkg/auth"
@@ -201,6 +202,7 @@ func getIdentitysFromResponse(response *wssdcloudsecurity.IdentityResponse) *[]s
 		certs = append(certs, *(getIdentity(identitys)))
 	}
 
+	list.SortResponse(&certs)
 	return &certs
 }
 
@@ -268,6 +270,7 @@ func getCertificatesFromResponse(response *wssdcloudsecurity.IdentityCertificate
 	for _, wssdCert := range response.GetCertificates() {
 		certificates = append(certificates, certificate.GetCertificate(wssdCert))
 	}
+	list.SortResponse(certificates)
 	return certificates
 }
 
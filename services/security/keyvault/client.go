@@ -5,6 +5,12 @@ package keyvault
 
 import (
 	"context"
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -34,15 +40,57 @@ func NewKeyVaultClient(cloudFQDN string, authorizer auth.Authorizer) (*KeyVaultC
 
 // Get methods invokes the client Get method
 func (c *KeyVaultClient) Get(ctx context.Context, group, name string) (*[]security.KeyVault, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, group, name)
 }
 
+// List methods returns all the resources in the group
+func (c *KeyVaultClient) List(ctx context.Context, group string, opts ...list.Options) (*[]security.KeyVault, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *KeyVaultClient) GetMany(ctx context.Context, group string, names []string, parallelism int) map[string]parallel.Result[*[]security.KeyVault] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]security.KeyVault, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the group
+func (c *KeyVaultClient) NewLister(group string) *list.Lister[security.KeyVault] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]security.KeyVault, error) {
+		return c.List(ctx, group)
+	})
+}
+
+// Exists returns true if the resource exists in the group
+func (c *KeyVaultClient) Exists(ctx context.Context, group, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]security.KeyVault, error) {
+		return c.Get(ctx, group, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /groups/{group}/providers/KeyVault/{name}, see resourceid.ID
+func (c *KeyVaultClient) GetByID(ctx context.Context, id string) (*security.KeyVault, error) {
+	return resourceid.GetByID(ctx, id, resourceid.GroupScope, conversion.KeyVault, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *KeyVaultClient) CreateOrUpdate(ctx context.Context, group, name string, keyvault *security.KeyVault) (*security.KeyVault, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, keyvault)
 }
 
 // Delete methods invokes delete of the keyvault resource
 func (c *KeyVaultClient) Delete(ctx context.Context, group, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name)
 }
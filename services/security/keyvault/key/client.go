@@ -6,6 +6,9 @@ package key
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc-sdk-for-go/services/security/keyvault"
 	"github.com/microsoft/moc/pkg/auth"
@@ -44,58 +47,82 @@ func NewKeyClient(cloudFQDN string, authorizer auth.Authorizer) (*KeyClient, err
 
 // Get methods invokes the client Get method
 func (c *KeyClient) Get(ctx context.Context, group, vaultName, name string) (*[]keyvault.Key, error) {
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
+	group = moc.Group(ctx, group)
 	return c.internal.Get(ctx, group, vaultName, name)
 }
 
+// List methods returns all the resources in the vault
+func (c *KeyClient) List(ctx context.Context, group, vaultName string, opts ...list.Options) (*[]keyvault.Key, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, vaultName, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *KeyClient) CreateOrUpdate(ctx context.Context, group, vaultName, name string,
 	param *keyvault.Key) (*keyvault.Key, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, vaultName, name, param)
 }
 
 // Import methods invokes import on the client
 func (c *KeyClient) Import(ctx context.Context, group, vaultName, name string,
 	param *keyvault.Key) (*keyvault.Key, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.ImportKey(ctx, group, vaultName, name, param)
 }
 
 // Export methods invokes export on the client
 func (c *KeyClient) Export(ctx context.Context, group, vaultName, name string,
 	param *keyvault.Key) (*keyvault.Key, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.ExportKey(ctx, group, vaultName, name, param)
 }
 
 // Delete methods invokes delete of the keyvault resource
 func (c *KeyClient) Delete(ctx context.Context, group, vaultName, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name, vaultName)
 }
 
 // Encrypt methods invokes encrypt of the keyvault resource
 func (c *KeyClient) Encrypt(ctx context.Context, group, vaultName, name string, parameters *keyvault.KeyOperationsParameters) (result *keyvault.KeyOperationResult, err error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Encrypt(ctx, group, vaultName, name, parameters)
 }
 
 // Decrypt methods invokes encrypt of the keyvault resource
 func (c *KeyClient) Decrypt(ctx context.Context, group, vaultName, name string, parameters *keyvault.KeyOperationsParameters) (result *keyvault.KeyOperationResult, err error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Decrypt(ctx, group, vaultName, name, parameters)
 }
 
 // WrapKey
 func (c *KeyClient) WrapKey(ctx context.Context, group, vaultName, name string, parameters *keyvault.KeyOperationsParameters) (result *keyvault.KeyOperationResult, err error) {
+	group = moc.Group(ctx, group)
 	return c.internal.WrapKey(ctx, group, vaultName, name, parameters)
 }
 
 // UnwrapKey
 func (c *KeyClient) UnwrapKey(ctx context.Context, group, vaultName, name string, parameters *keyvault.KeyOperationsParameters) (result *keyvault.KeyOperationResult, err error) {
+	group = moc.Group(ctx, group)
 	return c.internal.UnwrapKey(ctx, group, vaultName, name, parameters)
 }
 
 // Sign
 func (c *KeyClient) Sign(ctx context.Context, group, vaultName, name string, parameters *keyvault.KeySignParameters) (result *keyvault.KeyOperationResult, err error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Sign(ctx, group, vaultName, name, parameters)
 }
 
 // Verify
 func (c *KeyClient) Verify(ctx context.Context, group, vaultName, name string, parameters *keyvault.KeyVerifyParameters) (result *keyvault.KeyVerifyResult, err error) {
+	group = moc.Group(ctx, group)
 	return c.internal.Verify(ctx, group, vaultName, name, parameters)
 }
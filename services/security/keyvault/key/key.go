@@ -8,6 +8,7 @@ import (
 
 	"github.com/microsoft/moc-sdk-for-go/services/security/keyvault"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/convert"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -26,7 +27,7 @@ func getKey(sec *wssdcloudsecurity.Key, vaultName string, getCustomValue func(*w
 		Name:    &sec.Name,
 		Version: &sec.Status.Version.Number,
 		KeyProperties: &keyvault.KeyProperties{
-			Statuses:                      status.GetStatuses(sec.GetStatus()),
+			Statuses:                      provisioning.GetStatuses(sec.GetStatus()),
 			KeyType:                       getKeyType(sec.Type),
 			KeySize:                       keysize,
 			KeyRotationFrequencyInSeconds: &sec.KeyRotationFrequencyInSeconds,
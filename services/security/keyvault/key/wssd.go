@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/security/keyvault"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
@@ -407,6 +408,7 @@ func getKeysFromResponse(response *wssdcloudsecurity.KeyResponse, vaultName stri
 		tmp = append(tmp, tmpKey)
 	}
 
+	list.SortResponse(&tmp)
 	return &tmp, nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/date"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
@@ -292,7 +293,7 @@ func getKeyVault(vault *wssdcloudsecurity.KeyVault, group string) *security.KeyV
 		Version: &vault.Status.Version.Number,
 		//	Source : &vault.Source,
 		KeyVaultProperties: &security.KeyVaultProperties{
-			Statuses: status.GetStatuses(vault.GetStatus()),
+			Statuses: provisioning.GetStatuses(vault.GetStatus()),
 		},
 	}
 }
@@ -5,6 +5,9 @@ package secret
 
 import (
 	"context"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc-sdk-for-go/services/security/keyvault"
 	"github.com/microsoft/moc/pkg/auth"
@@ -35,15 +38,31 @@ func NewSecretClient(cloudFQDN string, authorizer auth.Authorizer) (*SecretClien
 
 // Get methods invokes the client Get method
 func (c *SecretClient) Get(ctx context.Context, group, name, vaultName string) (*[]keyvault.Secret, error) {
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
+	group = moc.Group(ctx, group)
 	return c.internal.Get(ctx, group, name, vaultName)
 }
 
+// List methods returns all the resources in the vault
+func (c *SecretClient) List(ctx context.Context, group, vaultName string, opts ...list.Options) (*[]keyvault.Secret, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, "", vaultName)
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *SecretClient) CreateOrUpdate(ctx context.Context, group, name string, sec *keyvault.Secret) (*keyvault.Secret, error) {
+	group = moc.Group(ctx, group)
 	return c.internal.CreateOrUpdate(ctx, group, name, sec)
 }
 
 // Delete methods invokes delete of the keyvault resource
 func (c *SecretClient) Delete(ctx context.Context, group, name, vaultName string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, name, vaultName)
 }
@@ -6,6 +6,7 @@ package secret
 import (
 	"github.com/microsoft/moc-sdk-for-go/services/security/keyvault"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
 	wssdcloudsecurity "github.com/microsoft/moc/rpc/cloudagent/security"
@@ -22,7 +23,7 @@ func getSecret(sec *wssdcloudsecurity.Secret, vaultName string) *keyvault.Secret
 		SecretProperties: &keyvault.SecretProperties{
 			FileName:  &sec.Filename,
 			VaultName: &vaultName,
-			Statuses:  status.GetStatuses(sec.GetStatus()),
+			Statuses:  provisioning.GetStatuses(sec.GetStatus()),
 		},
 	}
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/security/keyvault"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
@@ -104,6 +105,7 @@ func getSecretsFromResponse(response *wssdcloudsecurity.SecretResponse, vaultNam
 		Secrets = append(Secrets, *(getSecret(secrets, vaultName)))
 	}
 
+	list.SortResponse(&Secrets)
 	return &Secrets
 }
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -84,6 +85,7 @@ func getKeyVaultsFromResponse(response *wssdcloudsecurity.KeyVaultResponse, grou
 		vaults = append(vaults, *(getKeyVault(keyvaults, group)))
 	}
 
+	list.SortResponse(&vaults)
 	return &vaults
 }
 
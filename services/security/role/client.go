@@ -6,6 +6,8 @@ package role
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/auth"
 )
@@ -35,9 +37,21 @@ func NewRoleClient(cloudFQDN string, authorizer auth.Authorizer) (*RoleClient, e
 
 // Get methods invokes the client Get method
 func (c *RoleClient) Get(ctx context.Context, name string) (*[]security.Role, error) {
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, name)
 }
 
+// List methods returns all the resources
+func (c *RoleClient) List(ctx context.Context, opts ...list.Options) (*[]security.Role, error) {
+	result, err := c.internal.Get(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
 // Ensure methods invokes create or update on the client
 func (c *RoleClient) CreateOrUpdate(ctx context.Context, name string, role *security.Role) (*security.Role, error) {
 	return c.internal.CreateOrUpdate(ctx, name, role)
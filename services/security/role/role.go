@@ -6,6 +6,7 @@ package role
 import (
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
 	wssdcloudsecurity "github.com/microsoft/moc/rpc/cloudagent/security"
@@ -96,7 +97,7 @@ func getRole(role *wssdcloudsecurity.Role) (*security.Role, error) {
 		Name:    &role.Name,
 		Version: &role.Status.Version.Number,
 		RoleProperties: &security.RoleProperties{
-			Statuses:         status.GetStatuses(role.GetStatus()),
+			Statuses:         provisioning.GetStatuses(role.GetStatus()),
 			Permissions:      permissions,
 			AssignableScopes: scopes,
 		},
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -128,6 +129,7 @@ func (c *client) getRolesFromResponse(response *wssdcloudsecurity.RoleResponse)
 		roles = append(roles, *role)
 	}
 
+	list.SortResponse(&roles)
 	return &roles, nil
 }
 
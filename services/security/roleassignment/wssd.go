@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/security"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -129,6 +130,7 @@ func (c *client) getRoleAssignmentFromResponse(response *wssdcloudsecurity.RoleA
 		ras = append(ras, *getRoleAssignment(ra))
 	}
 
+	list.SortResponse(&ras)
 	return &ras, nil
 }
 
@@ -6,8 +6,15 @@ package container
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
+	"github.com/microsoft/moc-sdk-for-go/pkg/parallel"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourceid"
 	"github.com/microsoft/moc-sdk-for-go/services/storage"
 	"github.com/microsoft/moc/pkg/auth"
+	wssdcloudstorage "github.com/microsoft/moc/rpc/cloudagent/storage"
 )
 
 // Service interface
@@ -36,21 +43,86 @@ func NewContainerClient(cloudFQDN string, authorizer auth.Authorizer) (*Containe
 
 // Get methods invokes the client Get method
 func (c *ContainerClient) Get(ctx context.Context, location, name string) (*[]storage.Container, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
 	return c.internal.Get(ctx, location, name)
 }
 
+// List methods returns all the resources in the location
+func (c *ContainerClient) List(ctx context.Context, location string, opts ...list.Options) (*[]storage.Container, error) {
+	location = moc.Location(ctx, location)
+	result, err := c.internal.Get(ctx, location, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// GetMany methods invokes Get for each of the names, running at most parallelism lookups concurrently
+func (c *ContainerClient) GetMany(ctx context.Context, location string, names []string, parallelism int) map[string]parallel.Result[*[]storage.Container] {
+	return parallel.GetMany(ctx, names, parallelism, func(ctx context.Context, name string) (*[]storage.Container, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// NewLister returns an iterator over all the resources in the location
+func (c *ContainerClient) NewLister(location string) *list.Lister[storage.Container] {
+	return list.NewSinglePageLister(func(ctx context.Context) (*[]storage.Container, error) {
+		return c.List(ctx, location)
+	})
+}
+
+// Exists returns true if the resource exists in the location
+func (c *ContainerClient) Exists(ctx context.Context, location, name string) (bool, error) {
+	return resourceid.Exists(ctx, name, func(ctx context.Context, name string) (*[]storage.Container, error) {
+		return c.Get(ctx, location, name)
+	})
+}
+
+// GetByID returns the resource identified by an sdk reference of the form /locations/{location}/providers/Container/{name}, see resourceid.ID
+func (c *ContainerClient) GetByID(ctx context.Context, id string) (*storage.Container, error) {
+	return resourceid.GetByID(ctx, id, resourceid.LocationScope, conversion.Container, c.Get)
+}
+
 // CreateOrUpdate methods invokes create or update on the client
 func (c *ContainerClient) CreateOrUpdate(ctx context.Context, location, name string, storage *storage.Container) (*storage.Container, error) {
+	location = moc.Location(ctx, location)
 	return c.internal.CreateOrUpdate(ctx, location, name, storage)
 }
 
 // Delete methods invokes delete of the storage resource
 func (c *ContainerClient) Delete(ctx context.Context, location, name string) error {
+	location = moc.Location(ctx, location)
 	return c.internal.Delete(ctx, location, name)
 }
 
 // Prechecks whether the system is able to create specified resources.
 // Returns true if it is possible; or false with reason in error message if not.
 func (c *ContainerClient) Precheck(ctx context.Context, location string, containers []*storage.Container) (bool, error) {
+	location = moc.Location(ctx, location)
+	if err := naming.CheckDuplicateNames(containers); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, location, containers)
 }
+
+// Raw returns the underlying agent client, giving access to agent fields not yet
+// exposed by the sdk. Returns nil if the client is not backed by the agent.
+func (c *ContainerClient) Raw() wssdcloudstorage.ContainerAgentClient {
+	if rc, ok := c.internal.(*client); ok {
+		return rc.ContainerAgentClient
+	}
+	return nil
+}
+
+// ToProto converts a container to the message sent to the agent
+func ToProto(container *storage.Container, location string) (*wssdcloudstorage.Container, error) {
+	return getWssdContainer(container, location)
+}
+
+// FromProto converts a container message returned by the agent
+func FromProto(container *wssdcloudstorage.Container, location string) *storage.Container {
+	return getContainer(container, location)
+}
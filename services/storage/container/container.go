@@ -4,11 +4,12 @@ package container
 
 import (
 	"code.cloudfoundry.org/bytefmt"
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
 	"github.com/microsoft/moc-sdk-for-go/services/storage"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	"github.com/microsoft/moc/pkg/tags"
 	wssdcloudstorage "github.com/microsoft/moc/rpc/cloudagent/storage"
 )
 
@@ -24,7 +25,7 @@ func getWssdContainer(c *storage.Container, locationName string) (*wssdcloudstor
 	wssdcontainer := &wssdcloudstorage.Container{
 		Name:         *c.Name,
 		LocationName: locationName,
-		Tags:         tags.MapToProto(c.Tags),
+		Tags:         conversion.TagsToProto(conversion.Container, c.Tags),
 	}
 
 	if c.Version != nil {
@@ -64,7 +65,7 @@ func getContainer(c *wssdcloudstorage.Container, location string) *storage.Conta
 		Name: &c.Name,
 		ID:   &c.Id,
 		ContainerProperties: &storage.ContainerProperties{
-			Statuses: status.GetStatuses(c.GetStatus()),
+			Statuses: provisioning.GetStatuses(c.GetStatus()),
 			Path:     &c.Path,
 			Isolated: c.Isolated,
 			ContainerInfo: &storage.ContainerInfo{
@@ -73,6 +74,6 @@ func getContainer(c *wssdcloudstorage.Container, location string) *storage.Conta
 			},
 		},
 		Version: &c.Status.Version.Number,
-		Tags:    tags.ProtoToMap(c.Tags),
+		Tags:    conversion.TagsFromProto(conversion.Container, c.Tags),
 	}
 }
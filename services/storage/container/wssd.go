@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/storage"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -154,5 +155,6 @@ func getContainersFromResponse(response *wssdcloudstorage.ContainerResponse, loc
 		virtualHardDisks = append(virtualHardDisks, *(getContainer(container, location)))
 	}
 
+	list.SortResponse(&virtualHardDisks)
 	return &virtualHardDisks
 }
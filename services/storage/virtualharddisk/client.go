@@ -6,9 +6,14 @@ package virtualharddisk
 import (
 	"context"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/naming"
 	"github.com/microsoft/moc-sdk-for-go/services/storage"
+	"github.com/microsoft/moc-sdk-for-go/services/storage/container"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
+	wssdcloudstorage "github.com/microsoft/moc/rpc/cloudagent/storage"
 )
 
 // Service interface
@@ -22,7 +27,9 @@ type Service interface {
 // Client structure
 type VirtualHardDiskClient struct {
 	storage.BaseClient
-	internal Service
+	internal   Service
+	containers *container.ContainerClient
+	selector   ContainerSelector
 }
 
 // NewClient method returns new client
@@ -32,21 +39,57 @@ func NewVirtualHardDiskClient(cloudFQDN string, authorizer auth.Authorizer) (*Vi
 		return nil, err
 	}
 
-	return &VirtualHardDiskClient{internal: c}, nil
+	containers, err := container.NewContainerClient(cloudFQDN, authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VirtualHardDiskClient{internal: c, containers: containers}, nil
 }
 
 // Get methods invokes the client Get method
 func (c *VirtualHardDiskClient) Get(ctx context.Context, group, container, name string) (*[]storage.VirtualHardDisk, error) {
+	if err := naming.CheckGetName(name); err != nil {
+		return nil, err
+	}
+	group = moc.Group(ctx, group)
 	return c.internal.Get(ctx, group, container, name)
 }
 
-// CreateOrUpdate methods invokes create or update on the client
+// List methods returns all the resources in the container
+func (c *VirtualHardDiskClient) List(ctx context.Context, group, container string, opts ...list.Options) (*[]storage.VirtualHardDisk, error) {
+	group = moc.Group(ctx, group)
+	result, err := c.internal.Get(ctx, group, container, "")
+	if err != nil {
+		return nil, err
+	}
+	return list.Apply(result, opts...)
+}
+
+// CreateOrUpdate methods invokes create or update on the client. If container is empty and a container selector
+// is set, the disk is created in the container chosen by the selector, which is reported in ContainerName.
 func (c *VirtualHardDiskClient) CreateOrUpdate(ctx context.Context, group, container, name string, storage *storage.VirtualHardDisk) (*storage.VirtualHardDisk, error) {
-	return c.internal.CreateOrUpdate(ctx, group, container, name, storage)
+	group = moc.Group(ctx, group)
+	if len(container) == 0 && c.selector != nil && c.containers != nil {
+		selected, err := c.selectContainer(ctx, group, storage)
+		if err != nil {
+			return nil, err
+		}
+		container = selected
+	}
+	vhd, err := c.internal.CreateOrUpdate(ctx, group, container, name, storage)
+	if err != nil {
+		return nil, err
+	}
+	if vhd != nil && vhd.VirtualHardDiskProperties != nil && (vhd.ContainerName == nil || len(*vhd.ContainerName) == 0) && len(container) > 0 {
+		vhd.ContainerName = &container
+	}
+	return vhd, nil
 }
 
 // Delete methods invokes delete of the storage resource
 func (c *VirtualHardDiskClient) Delete(ctx context.Context, group, container, name string) error {
+	group = moc.Group(ctx, group)
 	return c.internal.Delete(ctx, group, container, name)
 }
 
@@ -72,5 +115,28 @@ func (c *VirtualHardDiskClient) Resize(ctx context.Context, group, container, na
 // Prechecks whether the system is able to create specified virtual hard disks.
 // Returns true with virtual hard disk placement in mapping from virtual hard disk names to container names; or false with reason in error message.
 func (c *VirtualHardDiskClient) Precheck(ctx context.Context, group, container string, vhds []*storage.VirtualHardDisk) (bool, error) {
+	group = moc.Group(ctx, group)
+	if err := naming.CheckDuplicateNames(vhds); err != nil {
+		return false, err
+	}
 	return c.internal.Precheck(ctx, group, container, vhds)
 }
+
+// Raw returns the underlying agent client, giving access to agent fields not yet
+// exposed by the sdk. Returns nil if the client is not backed by the agent.
+func (c *VirtualHardDiskClient) Raw() wssdcloudstorage.VirtualHardDiskAgentClient {
+	if rc, ok := c.internal.(*client); ok {
+		return rc.VirtualHardDiskAgentClient
+	}
+	return nil
+}
+
+// ToProto converts a virtual hard disk to the message sent to the agent
+func ToProto(vhd *storage.VirtualHardDisk, group, container string) (*wssdcloudstorage.VirtualHardDisk, error) {
+	return getWssdVirtualHardDisk(vhd, group, container)
+}
+
+// FromProto converts a virtual hard disk message returned by the agent
+func FromProto(vhd *wssdcloudstorage.VirtualHardDisk, group string) *storage.VirtualHardDisk {
+	return getVirtualHardDisk(vhd, group)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the Apache v2.0 License.
+
+package virtualharddisk
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/bytefmt"
+	"github.com/microsoft/moc-sdk-for-go/pkg/moc"
+	"github.com/microsoft/moc-sdk-for-go/pkg/resourcetags"
+	"github.com/microsoft/moc-sdk-for-go/services/storage"
+	"github.com/microsoft/moc/pkg/errors"
+)
+
+// ContainerSelector chooses the container of a virtual hard disk created without one
+type ContainerSelector interface {
+	// SelectContainer returns the name of one of the containers of the location
+	SelectContainer(ctx context.Context, group string, vhd *storage.VirtualHardDisk, containers []storage.Container) (string, error)
+}
+
+// ContainerSelectorFunc adapts a function to a ContainerSelector
+type ContainerSelectorFunc func(ctx context.Context, group string, vhd *storage.VirtualHardDisk, containers []storage.Container) (string, error)
+
+// SelectContainer calls f
+func (f ContainerSelectorFunc) SelectContainer(ctx context.Context, group string, vhd *storage.VirtualHardDisk, containers []storage.Container) (string, error) {
+	return f(ctx, group, vhd, containers)
+}
+
+// RoundRobinContainers returns a selector that cycles through the containers in name order
+func RoundRobinContainers() ContainerSelector {
+	var (
+		mux  sync.Mutex
+		next int
+	)
+	return ContainerSelectorFunc(func(_ context.Context, _ string, _ *storage.VirtualHardDisk, containers []storage.Container) (string, error) {
+		names := getContainerNames(containers)
+		if len(names) == 0 {
+			return "", errors.Wrapf(errors.NotFound, "No container available")
+		}
+		mux.Lock()
+		defer mux.Unlock()
+		name := names[next%len(names)]
+		next++
+		return name, nil
+	})
+}
+
+// MostFreeSpaceContainers returns a selector that chooses the container with the most available space
+func MostFreeSpaceContainers() ContainerSelector {
+	return ContainerSelectorFunc(func(_ context.Context, _ string, _ *storage.VirtualHardDisk, containers []storage.Container) (string, error) {
+		return getMostFreeSpaceContainer(containers)
+	})
+}
+
+// TaggedContainers returns a selector that chooses, among the containers tagged with key set to value, the one with
+// the most available space
+func TaggedContainers(key, value string) ContainerSelector {
+	return ContainerSelectorFunc(func(_ context.Context, _ string, _ *storage.VirtualHardDisk, containers []storage.Container) (string, error) {
+		tagged := []storage.Container{}
+		for _, ct := range containers {
+			if v, ok := resourcetags.Get(ct.Tags, key); ok && v != nil && *v == value {
+				tagged = append(tagged, ct)
+			}
+		}
+		if len(tagged) == 0 {
+			return "", errors.Wrapf(errors.NotFound, "No container tagged [%s=%s]", key, value)
+		}
+		return getMostFreeSpaceContainer(tagged)
+	})
+}
+
+// SetContainerSelector sets the selector choosing the container of virtual hard disks created without one. With no
+// selector, the default, the agent chooses the container.
+func (c *VirtualHardDiskClient) SetContainerSelector(selector ContainerSelector) {
+	c.selector = selector
+}
+
+// selectContainer returns the container chosen by the selector for a virtual hard disk created without one
+func (c *VirtualHardDiskClient) selectContainer(ctx context.Context, group string, vhd *storage.VirtualHardDisk) (string, error) {
+	location := moc.Location(ctx, "")
+	if len(location) == 0 {
+		return "", errors.Wrapf(errors.InvalidInput, "Container selection requires a location in the context")
+	}
+	containers, err := c.containers.List(ctx, location)
+	if err != nil {
+		return "", err
+	}
+	if containers == nil {
+		containers = &[]storage.Container{}
+	}
+	return c.selector.SelectContainer(ctx, group, vhd, *containers)
+}
+
+func getMostFreeSpaceContainer(containers []storage.Container) (string, error) {
+	best, bestSize := "", int64(-1)
+	for _, name := range getContainerNames(containers) {
+		size := int64(0)
+		for _, ct := range containers {
+			if ct.Name != nil && *ct.Name == name {
+				size = getAvailableBytes(&ct)
+				break
+			}
+		}
+		if size > bestSize {
+			best, bestSize = name, size
+		}
+	}
+	if len(best) == 0 {
+		return "", errors.Wrapf(errors.NotFound, "No container available")
+	}
+	return best, nil
+}
+
+// getAvailableBytes returns the space available in the container, 0 if the agent did not report it
+func getAvailableBytes(ct *storage.Container) int64 {
+	if ct.ContainerProperties == nil || ct.ContainerInfo == nil || len(ct.AvailableSize) == 0 {
+		return 0
+	}
+	size, err := bytefmt.ToBytes(ct.AvailableSize)
+	if err != nil {
+		return 0
+	}
+	return int64(size)
+}
+
+// getContainerNames returns the names of the containers, sorted
+func getContainerNames(containers []storage.Container) []string {
+	names := []string{}
+	for _, ct := range containers {
+		if ct.Name != nil && len(strings.TrimSpace(*ct.Name)) > 0 {
+			names = append(names, *ct.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -3,11 +3,12 @@
 package virtualharddisk
 
 import (
+	"github.com/microsoft/moc-sdk-for-go/pkg/conversion"
 	"github.com/microsoft/moc-sdk-for-go/services/storage"
 
+	"github.com/microsoft/moc-sdk-for-go/pkg/provisioning"
 	"github.com/microsoft/moc/pkg/errors"
 	"github.com/microsoft/moc/pkg/status"
-	"github.com/microsoft/moc/pkg/tags"
 	wssdcloudstorage "github.com/microsoft/moc/rpc/cloudagent/storage"
 )
 
@@ -24,7 +25,7 @@ func getWssdVirtualHardDisk(c *storage.VirtualHardDisk, groupName, containerName
 		Name:          *c.Name,
 		GroupName:     groupName,
 		ContainerName: containerName,
-		Tags:          tags.MapToProto(c.Tags),
+		Tags:          conversion.TagsToProto(conversion.VirtualHardDisk, c.Tags),
 	}
 
 	if c.Version != nil {
@@ -68,7 +69,7 @@ func getVirtualHardDisk(c *wssdcloudstorage.VirtualHardDisk, group string) *stor
 		ID:      &c.Id,
 		Version: &c.Status.Version.Number,
 		VirtualHardDiskProperties: &storage.VirtualHardDiskProperties{
-			Statuses:            status.GetStatuses(c.GetStatus()),
+			Statuses:            provisioning.GetStatuses(c.GetStatus()),
 			DiskSizeBytes:       &c.Size,
 			Dynamic:             &c.Dynamic,
 			Blocksizebytes:      &c.Blocksizebytes,
@@ -83,6 +84,6 @@ func getVirtualHardDisk(c *wssdcloudstorage.VirtualHardDisk, group string) *stor
 			DiskFileFormat:      c.DiskFileFormat,
 			ContainerName:       &c.ContainerName,
 		},
-		Tags: tags.ProtoToMap(c.Tags),
+		Tags: conversion.TagsFromProto(conversion.VirtualHardDisk, c.Tags),
 	}
 }
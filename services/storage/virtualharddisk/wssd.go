@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	wssdcloudclient "github.com/microsoft/moc-sdk-for-go/pkg/client"
+	"github.com/microsoft/moc-sdk-for-go/pkg/list"
 	"github.com/microsoft/moc-sdk-for-go/services/storage"
 	"github.com/microsoft/moc/pkg/auth"
 	"github.com/microsoft/moc/pkg/errors"
@@ -150,5 +151,6 @@ func getVirtualHardDisksFromResponse(response *wssdcloudstorage.VirtualHardDiskR
 		virtualHardDisks = append(virtualHardDisks, *(getVirtualHardDisk(vhd, group)))
 	}
 
+	list.SortResponse(&virtualHardDisks)
 	return &virtualHardDisks
 }